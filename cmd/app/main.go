@@ -6,14 +6,17 @@ import (
 	"log"
 	"nft/config"
 	"nft/contract"
+	"nft/infra/eventbus"
 	"nft/infra/jtrace"
 	"nft/infra/persist"
 	"nft/infra/server"
 	"nft/infra/storage"
+	"nft/infra/vault"
 	"nft/internal/offer"
 	"nft/internal/sale"
 	"nft/internal/talan"
 	"nft/internal/transaction"
+	"nft/internal/transfer"
 	"os"
 	"syscall"
 	"time"
@@ -24,14 +27,22 @@ import (
 	"nft/internal/auth"
 	"nft/internal/card"
 	"nft/internal/category"
+	"nft/internal/chain"
 	"nft/internal/collection"
 	"nft/internal/email"
+	"nft/internal/fee"
+	"nft/internal/feed"
 	"nft/internal/file"
 	"nft/internal/jwt"
 	"nft/internal/kyc"
+	"nft/internal/ledger"
+	"nft/internal/limit"
 	"nft/internal/nft"
+	"nft/internal/notification"
 	"nft/internal/otp"
+	"nft/internal/outbox"
 	"nft/internal/user"
+	"nft/internal/withdrawal"
 )
 
 func main() {
@@ -44,6 +55,9 @@ func main() {
 			fx.Provide(server.New),
 			fx.Provide(persist.New),
 			fx.Provide(storage.New),
+			fx.Provide(vault.New),
+			fx.Provide(eventbus.New),
+			fx.Provide(fx.Annotated{Group: "outbox_handlers", Target: eventbus.NewOutboxHandler}),
 
 			sale.Module,
 			auth.Module,
@@ -60,10 +74,27 @@ func main() {
 			talan.Module,
 			offer.Module,
 			transaction.Module,
+			ledger.Module,
+			fee.Module,
+			limit.Module,
+			transfer.Module,
+			chain.Module,
+			withdrawal.Module,
+			outbox.Module,
+			notification.Module,
+			feed.Module,
 
 			fx.Invoke(initConfig),
 			fx.Invoke(jtrace.InitGlobalTracer),
+			fx.Invoke(fee.CheckConfigPolicy),
 			fx.Invoke(migrate),
+			fx.Invoke(resealSecrets),
+			fx.Invoke(offer.StartAuctionCloser),
+			fx.Invoke(sale.StartSaleExpirer),
+			fx.Invoke(chain.StartChainWorker),
+			fx.Invoke(ledger.StartDepositWatcher),
+			fx.Invoke(outbox.StartOutboxWorker),
+			fx.Invoke(feed.StartFeed),
 			fx.Invoke(serve),
 		)
 
@@ -117,3 +148,15 @@ func migrate(lc fx.Lifecycle, db contract.IPersist) {
 		},
 	)
 }
+
+// resealSecrets runs after the migrations, so the wallet secrets of every
+// user are sealed with the current master key before anything reads them.
+func resealSecrets(lc fx.Lifecycle, userService contract.IUserService) {
+	lc.Append(
+		fx.Hook{
+			OnStart: func(c context.Context) error {
+				return userService.ResealSecrets(c)
+			},
+		},
+	)
+}
@@ -0,0 +1,74 @@
+// Command bootstrap makes the first admin out of a user who signed up and
+// verified their email:
+//
+//	go run ./cmd/bootstrap -email admin@example.com
+//
+// It refuses to run once there is an admin; admins give out roles from then on.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"nft/config"
+	"nft/contract"
+	"nft/infra/persist"
+	"nft/infra/vault"
+	"nft/internal/email"
+	"nft/internal/jwt"
+	"nft/internal/otp"
+	"nft/internal/outbox"
+	"nft/internal/talan"
+	"nft/internal/user"
+	"os"
+
+	"go.uber.org/fx"
+)
+
+func main() {
+	adminEmail := flag.String("email", "", "verified email of the user to make admin")
+	flag.Parse()
+
+	if len(*adminEmail) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	app := fx.New(
+		fx.Provide(persist.New),
+		fx.Provide(vault.New),
+
+		user.Module,
+		email.Module,
+		otp.Module,
+		outbox.Module,
+		jwt.Module,
+		talan.Module,
+
+		fx.Invoke(initConfig),
+		fx.Invoke(func(lc fx.Lifecycle, userService contract.IUserService) {
+			lc.Append(fx.Hook{
+				OnStart: func(c context.Context) error {
+					return userService.BootstrapAdmin(c, *adminEmail)
+				},
+			})
+		}),
+	)
+
+	if err := app.Start(context.Background()); err != nil {
+		log.Fatalln(err)
+	}
+	if err := app.Stop(context.Background()); err != nil {
+		log.Fatalln(err)
+	}
+
+	log.Printf("%s is now an admin\n", *adminEmail)
+}
+
+func initConfig(down fx.Shutdowner) {
+	path, err := os.Getwd()
+	if err != nil {
+		panic("unable to initialize config")
+	}
+	config.InitConfigs(down, path)
+}
@@ -0,0 +1,8 @@
+package config
+
+type Auction struct {
+	MinIncrement         float64 `yaml:"auction.minIncrement"`
+	ExtensionWindowInMin int     `yaml:"auction.extensionWindowInMin"`
+	ExtensionInMin       int     `yaml:"auction.extensionInMin"`
+	CloserIntervalInSec  int     `yaml:"auction.closerIntervalInSec" required:"true"`
+}
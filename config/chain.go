@@ -0,0 +1,19 @@
+package config
+
+// Chain selects the chain nfts are minted on. The marketplace contract holds
+// the nfts that are not minted in a contract of their own collection. The
+// block time only applies to the simulator. A worker claims a batch of
+// transactions for LeaseInSec; a submission that fails waits twice as long
+// before each retry, from BackoffInSec up to MaxBackoffInSec, and fails for
+// good once it failed MaxAttempts times.
+type Chain struct {
+	Driver              string `yaml:"chain.driver" required:"true"`
+	MarketplaceContract string `yaml:"chain.marketplaceContract" required:"true"`
+	WorkerIntervalInSec int    `yaml:"chain.workerIntervalInSec" required:"true"`
+	BlockTimeInSec      int    `yaml:"chain.blockTimeInSec"`
+	BatchSize           int    `yaml:"chain.batchSize" required:"true"`
+	LeaseInSec          int    `yaml:"chain.leaseInSec" required:"true"`
+	MaxAttempts         int    `yaml:"chain.maxAttempts" required:"true"`
+	BackoffInSec        int    `yaml:"chain.backoffInSec" required:"true"`
+	MaxBackoffInSec     int    `yaml:"chain.maxBackoffInSec" required:"true"`
+}
@@ -21,20 +21,30 @@ var (
 
 // Config is base of configs we need for project
 type Config struct {
-	Env      Env      `yaml:"env" required:"true"`
-	App      App      `yaml:"app" required:"true"`
-	Jaeger   Jaeger   `yaml:"jaeger" required:"true"`
-	Etcd     Etcd     `yaml:"etcd" required:"true"`
-	Redis    Redis    `yaml:"redis" required:"true"`
-	Postgres Database `yaml:"database" required:"true"`
-	Storage  Storage  `yaml:"storage" required:"true"`
-	File     File     `yaml:"file" required:"true"`
-	Nats     NATS     `yaml:"nats" required:"true"`
-	JWT      JWT      `yaml:"jwt" json:"jwt" required:"true"`
-	Otp      Otp      `yaml:"otp" json:"otp" required:"true"`
-	Logstash Logstash `yaml:"logstash" required:"true"`
-	Smtp     Smtp     `yaml:"smtp" required:"true"`
-	Talan    Talan    `yaml:"talan" json:"talan" required:"true"`
+	Env        Env        `yaml:"env" required:"true"`
+	App        App        `yaml:"app" required:"true"`
+	Jaeger     Jaeger     `yaml:"jaeger" required:"true"`
+	Etcd       Etcd       `yaml:"etcd" required:"true"`
+	Redis      Redis      `yaml:"redis" required:"true"`
+	Postgres   Database   `yaml:"database" required:"true"`
+	Storage    Storage    `yaml:"storage" required:"true"`
+	File       File       `yaml:"file" required:"true"`
+	Nats       NATS       `yaml:"nats" required:"true"`
+	JWT        JWT        `yaml:"jwt" json:"jwt" required:"true"`
+	Otp        Otp        `yaml:"otp" json:"otp" required:"true"`
+	Logstash   Logstash   `yaml:"logstash" required:"true"`
+	Smtp       Smtp       `yaml:"smtp" required:"true"`
+	Talan      Talan      `yaml:"talan" json:"talan" required:"true"`
+	Auction    Auction    `yaml:"auction" json:"auction" required:"true"`
+	Sale       Sale       `yaml:"sale" json:"sale" required:"true"`
+	Ledger     Ledger     `yaml:"ledger" json:"ledger" required:"true"`
+	Settlement Settlement `yaml:"settlement" json:"settlement" required:"true"`
+	Fee        Fee        `yaml:"fee" json:"fee" required:"true"`
+	Kyc        Kyc        `yaml:"kyc" json:"kyc" required:"true"`
+	Chain      Chain      `yaml:"chain" json:"chain" required:"true"`
+	Withdrawal Withdrawal `yaml:"withdrawal" json:"withdrawal" required:"true"`
+	Secrets    Secrets    `yaml:"secrets" json:"secrets" required:"true"`
+	Outbox     Outbox     `yaml:"outbox" json:"outbox" required:"true"`
 }
 
 func Validate(c any) error {
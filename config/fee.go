@@ -0,0 +1,18 @@
+package config
+
+type Fee struct {
+	KycDiscountPercent float64   `yaml:"fee.kycDiscountPercent"`
+	Rules              []FeeRule `yaml:"fee.rules" required:"true"`
+}
+
+type FeeRule struct {
+	SaleType  string    `yaml:"saleType"`
+	AssetType string    `yaml:"assetType"`
+	MinFee    float64   `yaml:"minFee"`
+	Tiers     []FeeTier `yaml:"tiers"`
+}
+
+type FeeTier struct {
+	FromPrice float64 `yaml:"fromPrice"`
+	Percent   float64 `yaml:"percent"`
+}
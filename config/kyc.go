@@ -0,0 +1,13 @@
+package config
+
+type Kyc struct {
+	Tiers []KycTier `yaml:"kyc.tiers" required:"true"`
+}
+
+// KycTier is the trading volume a user approved at the level may reach in a
+// day and in a month.
+type KycTier struct {
+	Level         int     `yaml:"level"`
+	DailyVolume   float64 `yaml:"dailyVolume"`
+	MonthlyVolume float64 `yaml:"monthlyVolume"`
+}
@@ -0,0 +1,6 @@
+package config
+
+type Ledger struct {
+	DepositConfirmations int `yaml:"ledger.depositConfirmations" required:"true"`
+	WatcherIntervalInSec int `yaml:"ledger.watcherIntervalInSec" required:"true"`
+}
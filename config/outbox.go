@@ -0,0 +1,14 @@
+package config
+
+// Outbox paces the delivery of queued messages. A worker claims a batch of
+// messages for LeaseInSec. A failed message waits twice as long before each
+// retry, from BackoffInSec up to MaxBackoffInSec, and is dead once it failed
+// MaxAttempts times.
+type Outbox struct {
+	WorkerIntervalInSec int `yaml:"outbox.workerIntervalInSec" required:"true"`
+	BatchSize           int `yaml:"outbox.batchSize" required:"true"`
+	LeaseInSec          int `yaml:"outbox.leaseInSec" required:"true"`
+	MaxAttempts         int `yaml:"outbox.maxAttempts" required:"true"`
+	BackoffInSec        int `yaml:"outbox.backoffInSec" required:"true"`
+	MaxBackoffInSec     int `yaml:"outbox.maxBackoffInSec" required:"true"`
+}
@@ -0,0 +1,5 @@
+package config
+
+type Sale struct {
+	ExpirerIntervalInSec int `yaml:"sale.expirerIntervalInSec" required:"true"`
+}
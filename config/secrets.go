@@ -0,0 +1,17 @@
+package config
+
+// Secrets holds the master keys that wrap the data keys of the wallet
+// secrets kept at rest. The config driver reads the keys below; the file
+// driver reads them from KeyFile, standing in for a KMS. Keys are base64
+// encoded 32 byte AES keys, and new secrets are sealed with CurrentVersion.
+type Secrets struct {
+	Driver         string      `yaml:"secrets.driver" required:"true"`
+	CurrentVersion int         `yaml:"secrets.currentVersion"`
+	MasterKeys     []MasterKey `yaml:"secrets.masterKeys"`
+	KeyFile        string      `yaml:"secrets.keyFile"`
+}
+
+type MasterKey struct {
+	Version int    `yaml:"version"`
+	Key     string `yaml:"key"`
+}
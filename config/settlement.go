@@ -0,0 +1,5 @@
+package config
+
+type Settlement struct {
+	MaxRoyaltyPercent float64 `yaml:"settlement.maxRoyaltyPercent" required:"true"`
+}
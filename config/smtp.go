@@ -1,5 +1,7 @@
 package config
 
+// Smtp is the server emails are sent through, logging in as From when a
+// password is set. Without a host emails are only logged.
 type Smtp struct {
 	Host     string `yaml:"smtp.host"`
 	Port     string `yaml:"smtp.port"`
@@ -6,4 +6,5 @@ type Talan struct {
 	Generate     string `yaml:"generate" required:"true"`
 	Transactions string `yaml:"transactions" required:"true"`
 	Balance      string `yaml:"balance" required:"true"`
+	Send         string `yaml:"send" required:"true"`
 }
@@ -0,0 +1,14 @@
+package config
+
+// Withdrawal bounds what users may take out of the platform. Talan
+// withdrawals are paid from the platform wallet. The code that confirms a
+// withdrawal lasts CodeExpInMin and takes CodeMaxAttempts guesses.
+type Withdrawal struct {
+	MinAmount        float64 `yaml:"withdrawal.minAmount" required:"true"`
+	MaxAmount        float64 `yaml:"withdrawal.maxAmount" required:"true"`
+	DailyAmount      float64 `yaml:"withdrawal.dailyAmount" required:"true"`
+	WalletAddress    string  `yaml:"withdrawal.walletAddress" required:"true"`
+	WalletPrivateKey string  `yaml:"withdrawal.walletPrivateKey" required:"true"`
+	CodeExpInMin     int     `yaml:"withdrawal.codeExpInMin" required:"true"`
+	CodeMaxAttempts  int     `yaml:"withdrawal.codeMaxAttempts" required:"true"`
+}
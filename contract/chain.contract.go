@@ -0,0 +1,31 @@
+package contract
+
+import (
+	"context"
+	"nft/infra/persist/type"
+	"nft/internal/chain/model"
+	collectionmodel "nft/internal/collection/model"
+	nftmodel "nft/internal/nft/model"
+	txmodel "nft/internal/transaction/model"
+)
+
+// IChainClient talks to the chain the nfts live on.
+type IChainClient interface {
+	DeployCollection(c context.Context, m model.Deploy) (model.Submission, error)
+	Mint(c context.Context, m model.Mint) (model.Submission, error)
+	Transfer(c context.Context, m model.Transfer) (model.Submission, error)
+	GetReceipt(c context.Context, txHash string) (model.Receipt, error)
+}
+
+type IChainService interface {
+	QueueDeploy(c context.Context, m collectionmodel.Collection) error
+	QueueMint(c context.Context, m nftmodel.Nft) error
+	QueueTransfer(c context.Context, m txmodel.Transaction) error
+	Process(c context.Context) error
+}
+
+type IChainRepository interface {
+	Add(c context.Context, m model.ChainTx) (model.ChainTx, error)
+	Find(c context.Context, query persist.Query) ([]model.ChainTx, error)
+	Update(c context.Context, m model.ChainTx) error
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"nft/infra/persist/type"
 	model "nft/internal/collection/model"
+	nftmodel "nft/internal/nft/model"
 )
 
 type ICollectionController interface {
@@ -13,6 +14,8 @@ type ICollectionController interface {
 	Get(c *fiber.Ctx) error
 	GetAll(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
+	SetRoyalty(c *fiber.Ctx) error
+	GetNfts(c *fiber.Ctx) error
 }
 
 type ICollectionService interface {
@@ -21,6 +24,8 @@ type ICollectionService interface {
 	AddCollection(c context.Context, m model.Collection) (model.Collection, error)
 	DeleteCollection(c context.Context, m model.Collection) error
 	GetOwnedCollection(c context.Context, m model.Collection) (model.Collection, error)
+	SetRoyalty(c context.Context, m model.Collection) error
+	GetNfts(c context.Context, m model.Collection) ([]nftmodel.Nft, error)
 }
 
 type ICollectionRepository interface {
@@ -28,6 +33,9 @@ type ICollectionRepository interface {
 	Update(c context.Context, userModel model.Collection) (model.Collection, error)
 	Delete(c context.Context, m model.Collection) error
 	Get(c context.Context, conditions persist.D) (model.Collection, error)
+	Lock(c context.Context, conditions persist.D) (model.Collection, error)
 	GetAll(c context.Context, conditions persist.D) ([]model.Collection, error)
 	HardDelete(c context.Context, id uuid.UUID) error
+	UpdateRoyalty(c context.Context, m model.Collection) error
+	UpdateContract(c context.Context, m model.Collection) error
 }
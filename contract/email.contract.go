@@ -13,7 +13,6 @@ type IEmailRepository interface {
 	Last(c context.Context, conditions persist.D) (model.Email, error)
 	Add(c context.Context, userId uuid.UUID, email string) (model.Email, error)
 	Update(c context.Context, emailModel model.Email) (model.Email, error)
-	Send(c context.Context, receivers []string, message string) error
 	Exists(c context.Context, conditions persist.D) (bool, error)
 }
 
@@ -24,5 +23,11 @@ type IEmailService interface {
 	AddEmail(c context.Context, userId uuid.UUID, email string) (model.Email, error)
 	ApproveEmail(c context.Context, userId uuid.UUID, email string) error
 	SendOtpEmail(c context.Context, emailId uint) error
+	SendToUser(c context.Context, userId uuid.UUID, content model.Content) error
 	GetLastVerifiedEmail(c context.Context, userId uuid.UUID) (model.Email, error)
 }
+
+// IMailSender delivers a rendered message to its recipients.
+type IMailSender interface {
+	Send(c context.Context, m model.Message) error
+}
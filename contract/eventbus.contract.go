@@ -0,0 +1,16 @@
+package contract
+
+import (
+	"context"
+	"nft/infra/eventbus/model"
+)
+
+// EventHandler receives the subject and json body of an event.
+type EventHandler func(subject string, data []byte)
+
+// IEventBus carries marketplace events to whoever subscribed to them.
+// Subjects take the nats wildcards: "*" for one token and ">" for the rest.
+type IEventBus interface {
+	Publish(c context.Context, event model.Event) error
+	Subscribe(subject string, handler EventHandler) (unsubscribe func() error, err error)
+}
@@ -0,0 +1,24 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/internal/fee/model"
+)
+
+type IFeeController interface {
+	GetPolicy(c *fiber.Ctx) error
+	SetPolicy(c *fiber.Ctx) error
+}
+
+type IFeeService interface {
+	GetPolicy(c context.Context) (model.Policy, error)
+	SetPolicy(c context.Context, m model.Policy, adminId uuid.UUID) error
+	Quote(c context.Context, q model.Quote) (float64, error)
+}
+
+type IFeeRepository interface {
+	Last(c context.Context) (model.Policy, error)
+	Add(c context.Context, m model.Policy, createdBy uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+	"nft/internal/feed/model"
+)
+
+type IFeedController interface {
+	Upgrade(c *fiber.Ctx) error
+	Connect(conn *websocket.Conn)
+}
+
+type IFeedService interface {
+	Connect(c context.Context, userId uuid.UUID) model.Client
+	Disconnect(c context.Context, clientId uuid.UUID)
+	Subscribe(c context.Context, clientId uuid.UUID, m model.Subscription) error
+	Unsubscribe(c context.Context, clientId uuid.UUID, m model.Subscription) error
+	Dispatch(subject string, data []byte)
+}
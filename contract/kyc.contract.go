@@ -15,6 +15,11 @@ type IKycController interface {
 	Reject(c *fiber.Ctx) error
 	GetAppeal(c *fiber.Ctx) error
 	GetAllAppeals(c *fiber.Ctx) error
+	Resubmit(c *fiber.Ctx) error
+	Assign(c *fiber.Ctx) error
+	RequestInfo(c *fiber.Ctx) error
+	GetCases(c *fiber.Ctx) error
+	GetDecisions(c *fiber.Ctx) error
 }
 
 type IKycService interface {
@@ -23,6 +28,13 @@ type IKycService interface {
 	Reject(c context.Context, m model.Kyc) error
 	GetAppeal(c context.Context, m model.Kyc) (model.Kyc, error)
 	GetAllAppeals(c context.Context, m model.Kyc) ([]model.Kyc, error)
+	Resubmit(c context.Context, m model.Kyc) (model.Kyc, error)
+	Assign(c context.Context, m model.Kyc) error
+	RequestInfo(c context.Context, m model.Kyc) error
+	GetCases(c context.Context, status model.KycStatus) ([]model.Kyc, error)
+	GetDecisions(c context.Context, kycId uuid.UUID) ([]model.Decision, error)
+	IsApproved(c context.Context, userId uuid.UUID) (bool, error)
+	GetTier(c context.Context, userId uuid.UUID) (int, error)
 }
 
 type IKycRepository interface {
@@ -32,4 +44,10 @@ type IKycRepository interface {
 	Delete(c context.Context, userId uuid.UUID) error
 	Get(c context.Context, conditions persist.D) (model.Kyc, error)
 	GetAll(c context.Context, conditions persist.D) ([]model.Kyc, error)
+	Find(c context.Context, query persist.Query) ([]model.Kyc, error)
+	Lock(c context.Context, conditions persist.D) (model.Kyc, error)
+	UpdateStatus(c context.Context, m model.Kyc) error
+	UpdateDocuments(c context.Context, m model.Kyc) error
+	AddDecision(c context.Context, m model.Decision) error
+	GetDecisions(c context.Context, kycId uuid.UUID) ([]model.Decision, error)
 }
@@ -0,0 +1,53 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/infra/persist/type"
+	"nft/internal/ledger/model"
+	talanmodel "nft/internal/talan/model"
+	"nft/pkg/money"
+)
+
+type ILedgerController interface {
+	GetBalance(c *fiber.Ctx) error
+	SyncDeposits(c *fiber.Ctx) error
+}
+
+type ILedgerService interface {
+	GetBalance(c context.Context, userId uuid.UUID) (model.Balance, error)
+	SyncDeposits(c context.Context, userId uuid.UUID) error
+	WatchDeposits(c context.Context) error
+	CreditDeposit(c context.Context, userId uuid.UUID, tx talanmodel.Transaction) error
+	PlaceHold(c context.Context, m model.Hold) error
+	ReleaseHold(c context.Context, offerId uuid.UUID) error
+	CaptureHold(c context.Context, m model.Capture) error
+	GetSettledFee(c context.Context, transactionId uuid.UUID) (money.Amount, error)
+	HoldWithdrawal(c context.Context, m model.Withdrawal) error
+	SettleWithdrawal(c context.Context, m model.Withdrawal) error
+	RefundWithdrawal(c context.Context, m model.Withdrawal) error
+}
+
+type ILedgerRepository interface {
+	GetAccount(c context.Context, conditions persist.D) (model.Account, error)
+	LockAccount(c context.Context, conditions persist.D) (model.Account, error)
+	AddAccount(c context.Context, m model.Account) (model.Account, error)
+	UpdateBalance(c context.Context, m model.Account) error
+	AddEntry(c context.Context, m model.Entry) error
+	GetEntries(c context.Context, conditions persist.D) ([]model.Entry, error)
+	GetHold(c context.Context, conditions persist.D) (model.Hold, error)
+	LockHold(c context.Context, conditions persist.D) (model.Hold, error)
+	AddHold(c context.Context, m model.Hold) (model.Hold, error)
+	UpdateHoldStatus(c context.Context, m model.Hold) error
+	DepositExists(c context.Context, conditions persist.D) (bool, error)
+	AddDeposit(c context.Context, m model.Deposit) error
+	GetCursor(c context.Context, address string) (model.DepositCursor, error)
+	SaveCursor(c context.Context, m model.DepositCursor) error
+}
+
+// IDepositListener is told about every payment credited to a user. The
+// credit is already committed when it is told.
+type IDepositListener interface {
+	OnDeposit(c context.Context, m model.DepositEvent) error
+}
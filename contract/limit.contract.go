@@ -0,0 +1,17 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/internal/limit/model"
+)
+
+type ILimitController interface {
+	GetAllowance(c *fiber.Ctx) error
+}
+
+type ILimitService interface {
+	GetAllowance(c context.Context, userId uuid.UUID) (model.Allowance, error)
+	Check(c context.Context, userId uuid.UUID, amount float64) error
+}
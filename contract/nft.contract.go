@@ -15,6 +15,12 @@ type INftController interface {
 	Approve(c *fiber.Ctx) error
 	Reject(c *fiber.Ctx) error
 	DeleteDraft(c *fiber.Ctx) error
+	SetRoyalty(c *fiber.Ctx) error
+	Submit(c *fiber.Ctx) error
+	GetStatusHistory(c *fiber.Ctx) error
+	GetHistory(c *fiber.Ctx) error
+	SetCollection(c *fiber.Ctx) error
+	RemoveFromCollection(c *fiber.Ctx) error
 }
 
 type INftService interface {
@@ -24,7 +30,14 @@ type INftService interface {
 	GetNft(c context.Context, m model.Nft) (model.Nft, error)
 	GetOwnedNft(c context.Context, m model.Nft) (model.Nft, error)
 	GetAllNfts(c context.Context, userId uuid.UUID) ([]model.Nft, error)
+	QueryNfts(c context.Context, query model.QueryNft) ([]model.Nft, error)
 	DeleteDraft(c context.Context, m model.Nft) error
+	SetRoyalty(c context.Context, m model.Nft) error
+	Submit(c context.Context, m model.Nft) error
+	GetStatusHistory(c context.Context, nftId uuid.UUID) ([]model.StatusChange, error)
+	GetHistory(c context.Context, query model.HistoryQuery) (model.HistoryPage, error)
+	SetCollection(c context.Context, m model.Nft) error
+	GetCollectionNfts(c context.Context, collectionId uuid.UUID) ([]model.Nft, error)
 }
 
 type INftRepository interface {
@@ -35,4 +48,13 @@ type INftRepository interface {
 	HardDelete(c context.Context, id uuid.UUID) error
 	Get(c context.Context, conditions persist.D) (model.Nft, error)
 	GetAll(c context.Context, conditions persist.D) ([]model.Nft, error)
+	UpdateRoyalty(c context.Context, m model.Nft) error
+	UpdateCollection(c context.Context, m model.Nft) error
+	UpdateToken(c context.Context, m model.Nft) error
+	Lock(c context.Context, conditions persist.D) (model.Nft, error)
+	UpdateStatus(c context.Context, m model.Nft) error
+	AddStatusChange(c context.Context, m model.StatusChange) error
+	GetStatusChanges(c context.Context, nftId uuid.UUID) ([]model.StatusChange, error)
+	GetHistory(c context.Context, query persist.Query) ([]model.HistoryEvent, error)
+	CountHistory(c context.Context, nftId uuid.UUID) (int, error)
 }
@@ -0,0 +1,46 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/infra/persist/type"
+	"nft/internal/notification/model"
+	"nft/pkg/lang"
+)
+
+type INotificationController interface {
+	GetNotifications(c *fiber.Ctx) error
+	GetUnreadCount(c *fiber.Ctx) error
+	MarkRead(c *fiber.Ctx) error
+	MarkAllRead(c *fiber.Ctx) error
+	GetPreferences(c *fiber.Ctx) error
+	SetPreferences(c *fiber.Ctx) error
+	GetLanguage(c *fiber.Ctx) error
+	SetLanguage(c *fiber.Ctx) error
+}
+
+type INotificationService interface {
+	Notify(c context.Context, m model.Notification) error
+	GetNotifications(c context.Context, userId uuid.UUID, unreadOnly bool) ([]model.Notification, error)
+	GetUnreadCount(c context.Context, userId uuid.UUID) (int, error)
+	MarkRead(c context.Context, id uuid.UUID, userId uuid.UUID) error
+	MarkAllRead(c context.Context, userId uuid.UUID) error
+	GetPreferences(c context.Context, userId uuid.UUID) ([]model.Preference, error)
+	SetPreferences(c context.Context, userId uuid.UUID, preferences []model.Preference) ([]model.Preference, error)
+	GetLanguage(c context.Context, userId uuid.UUID) (lang.Language, error)
+	SetLanguage(c context.Context, userId uuid.UUID, language lang.Language) error
+}
+
+type INotificationRepository interface {
+	Add(c context.Context, m model.Notification) (model.Notification, error)
+	Get(c context.Context, conditions persist.D) (model.Notification, error)
+	Find(c context.Context, query persist.Query) ([]model.Notification, error)
+	Count(c context.Context, conditions persist.D) (int, error)
+	MarkRead(c context.Context, id uuid.UUID) error
+	MarkAllRead(c context.Context, userId uuid.UUID) error
+	GetPreferences(c context.Context, userId uuid.UUID) ([]model.Preference, error)
+	SetPreference(c context.Context, userId uuid.UUID, preference model.Preference) error
+	GetLanguage(c context.Context, userId uuid.UUID) (lang.Language, error)
+	SetLanguage(c context.Context, userId uuid.UUID, language lang.Language) error
+}
@@ -19,6 +19,8 @@ type IOfferService interface {
 	CancelOffer(c context.Context, m model.Offer) error
 	AcceptOffer(c context.Context, m model.Offer) error
 	GetAllOffers(c context.Context, m model.Offer) ([]model.Offer, error)
+	CloseEndedAuctions(c context.Context) error
+	Buy(c context.Context, m model.Offer) error
 }
 
 type IOfferRepository interface {
@@ -27,4 +29,5 @@ type IOfferRepository interface {
 	GetAll(c context.Context, conditions persist.D) ([]model.Offer, error)
 	Delete(c context.Context, m model.Offer) error
 	Update(c context.Context, m model.Offer) (model.Offer, error)
+	Reject(c context.Context, m model.Offer) error
 }
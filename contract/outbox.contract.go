@@ -0,0 +1,37 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/infra/persist/type"
+	"nft/internal/outbox/model"
+	"time"
+)
+
+type IOutboxController interface {
+	GetMessages(c *fiber.Ctx) error
+	Requeue(c *fiber.Ctx) error
+}
+
+type IOutboxService interface {
+	Enqueue(c context.Context, topic string, payload any) error
+	Deliver(c context.Context) error
+	GetMessages(c context.Context, status model.Status) ([]model.Message, error)
+	Requeue(c context.Context, id uuid.UUID) (model.Message, error)
+}
+
+type IOutboxRepository interface {
+	Add(c context.Context, m model.Message) (model.Message, error)
+	Lock(c context.Context, conditions persist.D) (model.Message, error)
+	Find(c context.Context, query persist.Query) ([]model.Message, error)
+	Update(c context.Context, m model.Message) error
+	UpdateLeased(c context.Context, m model.Message, leasedUntil time.Time) error
+}
+
+// IOutboxHandler delivers the messages of one topic. Messages are delivered
+// at least once, so handlers should tolerate seeing one again.
+type IOutboxHandler interface {
+	Topic() string
+	Handle(c context.Context, payload []byte) error
+}
@@ -1,6 +1,9 @@
 package contract
 
-import "context"
+import (
+	"context"
+	"nft/infra/persist/type"
+)
 
 type IPersist interface {
 	Init(c context.Context) error
@@ -8,9 +11,14 @@ type IPersist interface {
 	Close(c context.Context) error
 	Get(c context.Context, entity any, conditions map[string]any) (any, error)
 	GetAll(c context.Context, entity any, conditions map[string]any) (any, error)
+	Find(c context.Context, entity any, query persist.Query) (any, error)
 	Create(c context.Context, entity any) (any, error)
 	Update(c context.Context, entity any, data any) (any, error)
+	UpdateWhere(c context.Context, entity any, conditions map[string]any, data any) (int, error)
 	Delete(c context.Context, entity any) error
 	Count(c context.Context, entity any, conditions map[string]any) (int, error)
 	Last(c context.Context, entity any, conditions map[string]any) (any, error)
+	Lock(c context.Context, entity any, conditions map[string]any) (any, error)
+	Transaction(c context.Context, fn func(c context.Context) error) error
+	AfterCommit(c context.Context, fn func(c context.Context))
 }
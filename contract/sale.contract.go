@@ -12,16 +12,21 @@ type ISaleController interface {
 	SellNft(c *fiber.Ctx) error
 	SellCollection(c *fiber.Ctx) error
 	CancelSale(c *fiber.Ctx) error
+	BuySale(c *fiber.Ctx) error
 	GetAllSales(c *fiber.Ctx) error
 	GetSale(c *fiber.Ctx) error
+	GetMarket(c *fiber.Ctx) error
 }
 
 type ISaleService interface {
 	CreateNftSale(c context.Context, m model.Sale) (model.Sale, error)
 	CreateCollectionSale(c context.Context, m model.Sale) (model.Sale, error)
 	CancelSale(c context.Context, m model.Sale) error
+	BuySale(c context.Context, m model.Sale) error
 	GetSalesList(c context.Context, userId uuid.UUID) ([]model.Sale, error)
 	GetSale(c context.Context, m model.Sale) (model.Sale, error)
+	ExpireSales(c context.Context) error
+	GetMarket(c context.Context, query model.MarketQuery) (model.MarketPage, error)
 }
 
 type ISaleRepository interface {
@@ -29,4 +34,8 @@ type ISaleRepository interface {
 	Get(c context.Context, conditions persist.D) (model.Sale, error)
 	GetAll(c context.Context, conditions persist.D) ([]model.Sale, error)
 	Cancel(c context.Context, m model.Sale) error
+	Lock(c context.Context, conditions persist.D) (model.Sale, error)
+	UpdateStatus(c context.Context, m model.Sale) error
+	Extend(c context.Context, m model.Sale) error
+	Find(c context.Context, query persist.Query) ([]model.Sale, error)
 }
@@ -9,6 +9,7 @@ type ITalanRepository interface {
 	GenerateAddress(c context.Context) (*model.Address, error)
 	GetBalance(c context.Context, address string) (float64, error)
 	GetTransactions(c context.Context, address string) ([]model.Transaction, error)
+	Send(c context.Context, m model.Payment) (string, error)
 }
 
 type ITalanService interface {
@@ -9,8 +9,13 @@ import (
 
 type ITransactionService interface {
 	GetLastTransaction(c context.Context, AssetId uuid.UUID) (model.Transaction, error)
+	GetOwner(c context.Context, assetId uuid.UUID, creatorId uuid.UUID) (uuid.UUID, error)
 }
 
 type ITransactionRepository interface {
 	Get(c context.Context, conditions persist.D) (model.Transaction, error)
+	Last(c context.Context, conditions persist.D) (model.Transaction, error)
+	Add(c context.Context, m model.Transaction) (model.Transaction, error)
+	GetAll(c context.Context, conditions persist.D) ([]model.Transaction, error)
+	UpdateChain(c context.Context, m model.Transaction) error
 }
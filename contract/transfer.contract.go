@@ -0,0 +1,32 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/infra/persist/type"
+	"nft/internal/transfer/model"
+)
+
+type ITransferController interface {
+	Transfer(c *fiber.Ctx) error
+	GetIncoming(c *fiber.Ctx) error
+	Accept(c *fiber.Ctx) error
+	Decline(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+}
+
+type ITransferService interface {
+	Transfer(c context.Context, m model.Transfer, recipient model.Recipient, requireAcceptance bool) (model.Transfer, error)
+	Accept(c context.Context, m model.Transfer) error
+	Decline(c context.Context, m model.Transfer) error
+	Cancel(c context.Context, m model.Transfer) error
+	GetIncoming(c context.Context, userId uuid.UUID) ([]model.Transfer, error)
+}
+
+type ITransferRepository interface {
+	Add(c context.Context, m model.Transfer) (model.Transfer, error)
+	Lock(c context.Context, conditions persist.D) (model.Transfer, error)
+	Find(c context.Context, query persist.Query) ([]model.Transfer, error)
+	UpdateStatus(c context.Context, m model.Transfer) error
+}
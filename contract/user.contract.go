@@ -15,6 +15,11 @@ type IUserController interface {
 	AddUser(c *fiber.Ctx) error
 	UpdateUser(c *fiber.Ctx) error
 	DeleteUser(c *fiber.Ctx) error
+	SetRole(c *fiber.Ctx) error
+}
+
+type IRoleMiddleware interface {
+	Require(permission model.Permission) fiber.Handler
 }
 
 type IUserService interface {
@@ -23,6 +28,9 @@ type IUserService interface {
 	AddUser(c context.Context, userModel model.User) (model.User, error)
 	UpdateUser(c context.Context, userModel model.User) (model.User, error)
 	DeleteUser(c context.Context, userId uuid.UUID) error
+	SetRole(c context.Context, userId uuid.UUID, role model.Role) error
+	BootstrapAdmin(c context.Context, email string) error
+	ResealSecrets(c context.Context) error
 }
 
 type IUserRepository interface {
@@ -31,5 +39,9 @@ type IUserRepository interface {
 	Update(c context.Context, userModel model.User) (model.User, error)
 	Delete(c context.Context, userId uuid.UUID) error
 	Get(c context.Context, conditions persist.D) (model.User, error)
+	Lock(c context.Context, conditions persist.D) (model.User, error)
 	GetAll(c context.Context) ([]model.User, error)
+	UpdateRole(c context.Context, userId uuid.UUID, role model.Role) error
+	GetWalletSecrets(c context.Context, userId uuid.UUID) (model.WalletSecrets, error)
+	ResealSecrets(c context.Context) (int, error)
 }
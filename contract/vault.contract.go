@@ -0,0 +1,10 @@
+package contract
+
+// IVault seals secrets kept at rest and opens them again. Stale tells the
+// values that aren't sealed with the current master key, plaintext ones
+// included, so they can be sealed again.
+type IVault interface {
+	Seal(plaintext string) (string, error)
+	Open(sealed string) (string, error)
+	Stale(value string) bool
+}
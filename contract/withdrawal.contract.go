@@ -0,0 +1,36 @@
+package contract
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"nft/infra/persist/type"
+	"nft/internal/withdrawal/model"
+)
+
+type IWithdrawalController interface {
+	Request(c *fiber.Ctx) error
+	Confirm(c *fiber.Ctx) error
+	GetWithdrawals(c *fiber.Ctx) error
+	GetAll(c *fiber.Ctx) error
+	Approve(c *fiber.Ctx) error
+	MarkSent(c *fiber.Ctx) error
+	Fail(c *fiber.Ctx) error
+}
+
+type IWithdrawalService interface {
+	Request(c context.Context, m model.Withdrawal) (model.Withdrawal, error)
+	Confirm(c context.Context, id uuid.UUID, userId uuid.UUID, code string) (model.Withdrawal, error)
+	GetWithdrawals(c context.Context, userId uuid.UUID) ([]model.Withdrawal, error)
+	GetAll(c context.Context, status model.Status) ([]model.Withdrawal, error)
+	Approve(c context.Context, id uuid.UUID, approverId uuid.UUID) (model.Withdrawal, error)
+	MarkSent(c context.Context, id uuid.UUID, reference string) (model.Withdrawal, error)
+	Fail(c context.Context, id uuid.UUID, reason string) (model.Withdrawal, error)
+}
+
+type IWithdrawalRepository interface {
+	Add(c context.Context, m model.Withdrawal) (model.Withdrawal, error)
+	Lock(c context.Context, conditions persist.D) (model.Withdrawal, error)
+	Find(c context.Context, query persist.Query) ([]model.Withdrawal, error)
+	Update(c context.Context, m model.Withdrawal) error
+}
@@ -0,0 +1,13 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrUnknownChainDriver    = errors.New("unknown chain driver")
+	ErrUnknownChainTxKind    = errors.New("unknown chain transaction kind")
+	ErrChainTxNotFound       = errors.New("chain transaction not found")
+	ErrChainContractNotFound = errors.New("contract not found on chain")
+	ErrChainTokenNotFound    = errors.New("token not found on chain")
+	ErrChainTokenNotOwned    = errors.New("token is not owned by the sender")
+	ErrChainAssetNotMinted   = errors.New("nft is not minted yet")
+)
@@ -7,4 +7,7 @@ var (
 	ErrCollectionDraftNotFound = errors.New("collection draft not found")
 	ErrCollectionIsNotDraft    = errors.New("collection isn't drafted")
 	ErrInvalidCollectionId     = errors.New("invalid collection id")
+	ErrCollectionEmpty         = errors.New("collection has no nfts")
+	ErrCollectionNftNotOwned   = errors.New("collection has nfts you don't own")
+	ErrCollectionOnSale        = errors.New("collection is listed for sale")
 )
@@ -0,0 +1,9 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrFeePolicyNotFound = errors.New("fee policy not found")
+	ErrInvalidFeePolicy  = errors.New("invalid fee policy")
+	ErrNoFeeRule         = errors.New("no fee rule matches the sale")
+)
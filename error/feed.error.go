@@ -0,0 +1,8 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrFeedClientNotFound = errors.New("feed client not found")
+	ErrFeedSaleIdRequired = errors.New("sale id is required to subscribe to a sale")
+)
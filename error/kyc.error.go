@@ -3,6 +3,9 @@ package apperrors
 import "errors"
 
 var (
-	ErrAppealNotFound  = errors.New("appeal not found")
-	ErrInvalidAppealId = errors.New("invalid appeal id")
+	ErrAppealNotFound          = errors.New("appeal not found")
+	ErrInvalidAppealId         = errors.New("invalid appeal id")
+	ErrOpenAppealExists        = errors.New("you already have an open appeal")
+	ErrInvalidAppealTransition = errors.New("appeal can not move to this status")
+	ErrNotAppealReviewer       = errors.New("appeal is assigned to another reviewer")
 )
@@ -0,0 +1,11 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrAccountNotFound    = errors.New("account not found")
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrInsufficientFunds  = errors.New("insufficient funds")
+	ErrUnbalancedJournal  = errors.New("journal entries don't balance")
+	ErrWalletNotAvailable = errors.New("user has no wallet address")
+)
@@ -0,0 +1,8 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrKycRequired          = errors.New("you need an approved kyc to trade")
+	ErrTradingLimitExceeded = errors.New("this trade exceeds the trading limit of your kyc tier")
+)
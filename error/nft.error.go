@@ -8,4 +8,7 @@ var (
 	ErrNftIsNotDraft            = errors.New("nft isn't drafted")
 	ErrInvalidNftId             = errors.New("invalid nft id")
 	ErrNftNotSubmittedForReview = errors.New("nft is not submitted for review")
+	ErrInvalidNftTransition     = errors.New("nft can't move to this status from its current one")
+	ErrNftIncomplete            = errors.New("nft needs a title, description, category and image to be submitted")
+	ErrNftMoved                 = errors.New("nft was moved to another collection meanwhile, try again")
 )
@@ -0,0 +1,8 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrInvalidNotificationId = errors.New("invalid notification id")
+)
@@ -3,7 +3,13 @@ package apperrors
 import "errors"
 
 var (
-	ErrOfferLowerMinPrice = errors.New("offer price is should be higher than min price")
-	ErrOfferYourSale      = errors.New("you can't make offer on your sale")
-	ErrOfferNotFound      = errors.New("offer not found")
+	ErrOfferLowerMinPrice  = errors.New("offer price is should be higher than min price")
+	ErrOfferYourSale       = errors.New("you can't make offer on your sale")
+	ErrOfferNotFound       = errors.New("offer not found")
+	ErrOfferNotActive      = errors.New("offer is no longer active")
+	ErrBidBelowIncrement   = errors.New("bid should beat the highest bid by at least the minimum increment")
+	ErrBidWithdrawal       = errors.New("active auction bids can't be withdrawn")
+	ErrAuctionEnded        = errors.New("auction has ended")
+	ErrAuctionManualAccept = errors.New("auction bids are settled automatically when the auction ends")
+	ErrFixedPriceOffer     = errors.New("fixed price sales can't take offers, buy them at list price instead")
 )
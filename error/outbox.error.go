@@ -0,0 +1,11 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrOutboxMessageNotFound  = errors.New("outbox message not found")
+	ErrInvalidOutboxMessageId = errors.New("invalid outbox message id")
+	ErrOutboxMessageNotDead   = errors.New("only dead messages can be requeued")
+	ErrUnknownOutboxTopic     = errors.New("no handler for the outbox topic")
+	ErrOutboxLeaseLost        = errors.New("outbox message was claimed again after its lease ran out")
+)
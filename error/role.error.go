@@ -0,0 +1,9 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrPermissionDenied = errors.New("you don't have permission to do this")
+	ErrInvalidRole      = errors.New("invalid role")
+	ErrAdminExists      = errors.New("an admin already exists")
+)
@@ -0,0 +1,7 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrInvalidRoyalty = errors.New("royalty percent should be between zero and the platform maximum")
+)
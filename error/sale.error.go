@@ -3,5 +3,16 @@ package apperrors
 import "errors"
 
 var (
-	ErrSaleNotFound = errors.New("sale not found")
+	ErrSaleNotFound      = errors.New("sale not found")
+	ErrSaleClosed        = errors.New("sale is no longer in progress")
+	ErrSaleExpired       = errors.New("sale has expired")
+	ErrSaleNotFixedPrice = errors.New("only fixed price sales can be bought directly")
+	ErrBuyYourSale       = errors.New("you can't buy your own sale")
+
+	ErrAssetListed           = errors.New("asset is already listed for sale")
+	ErrNftListedSeparately   = errors.New("an nft of the collection is listed for sale on its own")
+	ErrNftListedInCollection = errors.New("nft is listed for sale as part of its collection")
+
+	ErrInvalidMarketCursor = errors.New("invalid market cursor")
+	ErrInvalidMarketSort   = errors.New("invalid market sort")
 )
@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrUnableToParseResult = errors.New("unable to parse result")
+	ErrTalanRejected       = errors.New("talan rejected the request")
 )
@@ -0,0 +1,13 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrTransferNotFound  = errors.New("transfer not found")
+	ErrInvalidTransferId = errors.New("invalid transfer id")
+	ErrRecipientNotFound = errors.New("recipient not found")
+	ErrTransferToSelf    = errors.New("you can't transfer an nft to yourself")
+	ErrTransferPending   = errors.New("nft already has a pending transfer")
+	ErrTransferClosed    = errors.New("transfer is no longer pending")
+	ErrNftOnSale         = errors.New("nft is listed for sale")
+)
@@ -0,0 +1,8 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrUnknownVaultDriver = errors.New("unknown secrets driver")
+	ErrInvalidKeyFile     = errors.New("invalid master key file")
+)
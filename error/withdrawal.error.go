@@ -0,0 +1,18 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrWithdrawalNotFound     = errors.New("withdrawal not found")
+	ErrInvalidWithdrawalId    = errors.New("invalid withdrawal id")
+	ErrWithdrawalTooSmall     = errors.New("withdrawal amount is below the minimum")
+	ErrWithdrawalTooLarge     = errors.New("withdrawal amount is above the maximum")
+	ErrWithdrawalDailyLimit   = errors.New("daily withdrawal limit exceeded")
+	ErrWithdrawalConfirmed    = errors.New("withdrawal is already confirmed")
+	ErrWithdrawalNotConfirmed = errors.New("withdrawal is not confirmed yet")
+	ErrWithdrawalClosed       = errors.New("withdrawal can no longer be changed")
+	ErrCardNotApproved        = errors.New("card is not approved")
+	ErrEmailNotVerified       = errors.New("user has no verified email")
+	ErrWithdrawalCodeExpired  = errors.New("withdrawal code expired, request the withdrawal again")
+	ErrWithdrawalCodeLocked   = errors.New("too many wrong withdrawal codes, request the withdrawal again")
+)
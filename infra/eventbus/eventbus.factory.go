@@ -0,0 +1,32 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"nft/contract"
+	"nft/infra/eventbus/nats"
+
+	"go.uber.org/fx"
+)
+
+func New(lc fx.Lifecycle) contract.IEventBus {
+	var bus nats.Nats
+	lc.Append(fx.Hook{
+		OnStart: func(c context.Context) error {
+
+			if err := bus.Init(c); err != nil {
+				return err
+			}
+			log.Println("nats event bus connected successfully")
+			return nil
+		},
+		OnStop: func(c context.Context) error {
+			if err := bus.Close(c); err != nil {
+				return err
+			}
+			log.Println("nats event bus connection closed")
+			return nil
+		},
+	})
+	return &bus
+}
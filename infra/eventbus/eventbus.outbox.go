@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"nft/contract"
+	"nft/infra/eventbus/model"
+	"nft/infra/jtrace"
+
+	"go.uber.org/fx"
+)
+
+// OutboxTopic is the outbox topic events wait on until they are published.
+const OutboxTopic = "event"
+
+// outboxEvent is an event as the outbox keeps it, already encoded.
+type outboxEvent struct {
+	Subject string          `json:"subject"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Enqueue queues the event on the outbox in the transaction the context
+// carries, so it is published only if the change commits, and published
+// at least once even if the bus is down at the time.
+func Enqueue(c context.Context, outboxService contract.IOutboxService, event model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return outboxService.Enqueue(c, OutboxTopic, outboxEvent{Subject: event.Subject(), Data: data})
+}
+
+// OutboxHandler publishes the events queued on the outbox to the bus.
+type OutboxHandler struct {
+	eventBus contract.IEventBus
+}
+
+type OutboxHandlerParams struct {
+	fx.In
+	EventBus contract.IEventBus
+}
+
+func NewOutboxHandler(params OutboxHandlerParams) contract.IOutboxHandler {
+	return &OutboxHandler{
+		eventBus: params.EventBus,
+	}
+}
+
+func (o OutboxHandler) Topic() string {
+	return OutboxTopic
+}
+
+func (o OutboxHandler) Handle(c context.Context, payload []byte) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxHandler[Handle]")
+	defer span.Finish()
+
+	var event outboxEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+
+	return o.eventBus.Publish(c, model.Encoded{Name: event.Subject, Data: event.Data})
+}
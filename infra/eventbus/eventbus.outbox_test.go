@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"nft/infra/eventbus/memory"
+	"nft/infra/eventbus/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestOutboxHandlerPublishesTheQueuedEvent(t *testing.T) {
+	bus := memory.New()
+	handler := NewOutboxHandler(OutboxHandlerParams{EventBus: bus})
+
+	var subject string
+	var received model.SaleCanceled
+	if _, err := bus.Subscribe("marketplace.>", func(s string, data []byte) {
+		subject = s
+		if err := json.Unmarshal(data, &received); err != nil {
+			t.Fatal(err)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	event := model.SaleCanceled{SaleId: uuid.New(), SellerId: uuid.New(), OccurredAt: time.Now().UTC()}
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(outboxEvent{Subject: event.Subject(), Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handler.Handle(context.Background(), payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if subject != model.SubjectSaleCanceled {
+		t.Errorf("subject = %q, want %q", subject, model.SubjectSaleCanceled)
+	}
+	if received.SaleId != event.SaleId || received.SellerId != event.SellerId || !received.OccurredAt.Equal(event.OccurredAt) {
+		t.Errorf("received %+v, want %+v", received, event)
+	}
+}
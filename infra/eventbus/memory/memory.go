@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"nft/contract"
+	"nft/infra/eventbus/model"
+	"nft/infra/jtrace"
+	"strings"
+	"sync"
+)
+
+// Memory delivers events to the subscribers of the same process, right
+// away and in order. It stands in for nats in tests.
+type Memory struct {
+	mu            sync.RWMutex
+	next          int
+	subscriptions map[int]subscription
+}
+
+type subscription struct {
+	subject string
+	handler contract.EventHandler
+}
+
+func New() contract.IEventBus {
+	return &Memory{subscriptions: make(map[int]subscription)}
+}
+
+func (m *Memory) Publish(c context.Context, event model.Event) error {
+	span, _ := jtrace.T().SpanFromContext(c, "Memory[Publish]")
+	defer span.Finish()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	var handlers []contract.EventHandler
+	for id := 0; id < m.next; id++ {
+		if s, ok := m.subscriptions[id]; ok && matches(s.subject, event.Subject()) {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event.Subject(), data)
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(subject string, handler contract.EventHandler) (func() error, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.next
+	m.next++
+	m.subscriptions[id] = subscription{subject: subject, handler: handler}
+
+	return func() error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.subscriptions, id)
+		return nil
+	}, nil
+}
+
+// matches tells whether the subject falls under the pattern, where "*"
+// stands for one token and a trailing ">" for one or more.
+func matches(pattern string, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" && i == len(patternTokens)-1 {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"nft/infra/eventbus/model"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		subject string
+		want    bool
+	}{
+		{"marketplace.sale.created", "marketplace.sale.created", true},
+		{"marketplace.sale.created", "marketplace.sale.canceled", false},
+		{"marketplace.*.created", "marketplace.nft.created", true},
+		{"marketplace.*", "marketplace.nft.created", false},
+		{"marketplace.>", "marketplace.nft.created", true},
+		{"marketplace.>", "marketplace", false},
+		{"marketplace.sale", "marketplace.sale.created", false},
+	}
+
+	for _, tt := range tests {
+		if got := matches(tt.pattern, tt.subject); got != tt.want {
+			t.Errorf("matches(%q, %q) = %v, want %v", tt.pattern, tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	bus := New()
+
+	var received []model.SaleCanceled
+	unsubscribe, err := bus.Subscribe("marketplace.sale.*", func(subject string, data []byte) {
+		var event model.SaleCanceled
+		if err := json.Unmarshal(data, &event); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		received = append(received, event)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	saleId := uuid.New()
+	if err := bus.Publish(context.Background(), model.SaleCanceled{SaleId: saleId}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := bus.Publish(context.Background(), model.NftCreated{}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(received) != 1 || received[0].SaleId != saleId {
+		t.Errorf("received %+v, want only the canceled sale", received)
+	}
+
+	if err := unsubscribe(); err != nil {
+		t.Fatalf("unsubscribe() error = %v", err)
+	}
+	if err := bus.Publish(context.Background(), model.SaleCanceled{SaleId: saleId}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(received) != 1 {
+		t.Errorf("received %d events after unsubscribing, want 1", len(received))
+	}
+}
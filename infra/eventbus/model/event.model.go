@@ -0,0 +1,153 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Event is something that happened on the marketplace, published on its
+// subject as json once the change is committed. Events go through the
+// outbox, so consumers may see one more than once.
+type Event interface {
+	Subject() string
+}
+
+// Encoded is an event that is already json, as the outbox keeps it.
+type Encoded struct {
+	Name string
+	Data json.RawMessage
+}
+
+func (e Encoded) Subject() string { return e.Name }
+
+func (e Encoded) MarshalJSON() ([]byte, error) { return e.Data, nil }
+
+// Prefix starts the subject of every event, so consumers can take all of
+// them with "marketplace.>".
+const Prefix = "marketplace."
+
+const (
+	SubjectNftCreated    = Prefix + "nft.created"
+	SubjectNftApproved   = Prefix + "nft.approved"
+	SubjectSaleCreated   = Prefix + "sale.created"
+	SubjectSaleCanceled  = Prefix + "sale.canceled"
+	SubjectOfferMade     = Prefix + "offer.made"
+	SubjectOfferAccepted = Prefix + "offer.accepted"
+	SubjectOfferCanceled = Prefix + "offer.canceled"
+	SubjectOfferRejected = Prefix + "offer.rejected"
+	SubjectSaleExpired   = Prefix + "sale.expired"
+	SubjectSaleExtended  = Prefix + "sale.extended"
+)
+
+type NftCreated struct {
+	NftId      uuid.UUID `json:"nft_id"`
+	CreatorId  uuid.UUID `json:"creator_id"`
+	Title      string    `json:"title"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (NftCreated) Subject() string { return SubjectNftCreated }
+
+type NftApproved struct {
+	NftId      uuid.UUID `json:"nft_id"`
+	CreatorId  uuid.UUID `json:"creator_id"`
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (NftApproved) Subject() string { return SubjectNftApproved }
+
+type SaleCreated struct {
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	SaleType   string    `json:"sale_type"`
+	AssetType  string    `json:"asset_type"`
+	AssetId    uuid.UUID `json:"asset_id"`
+	MinPrice   float64   `json:"min_price"`
+	Expiration time.Time `json:"expiration"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (SaleCreated) Subject() string { return SubjectSaleCreated }
+
+type SaleCanceled struct {
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (SaleCanceled) Subject() string { return SubjectSaleCanceled }
+
+type OfferMade struct {
+	OfferId    uuid.UUID `json:"offer_id"`
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	BuyerId    uuid.UUID `json:"buyer_id"`
+	Price      float64   `json:"price"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (OfferMade) Subject() string { return SubjectOfferMade }
+
+type OfferAccepted struct {
+	OfferId    uuid.UUID `json:"offer_id"`
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	BuyerId    uuid.UUID `json:"buyer_id"`
+	Price      float64   `json:"price"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (OfferAccepted) Subject() string { return SubjectOfferAccepted }
+
+type OfferCanceled struct {
+	OfferId    uuid.UUID `json:"offer_id"`
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	BuyerId    uuid.UUID `json:"buyer_id"`
+	Price      float64   `json:"price"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (OfferCanceled) Subject() string { return SubjectOfferCanceled }
+
+// RejectReason tells why an offer was taken out of its sale.
+type RejectReason string
+
+const (
+	RejectReasonOutbid       RejectReason = "outbid"
+	RejectReasonSold         RejectReason = "sold"
+	RejectReasonSaleExpired  RejectReason = "sale_expired"
+	RejectReasonSaleCanceled RejectReason = "sale_canceled"
+)
+
+type OfferRejected struct {
+	OfferId    uuid.UUID    `json:"offer_id"`
+	SaleId     uuid.UUID    `json:"sale_id"`
+	SellerId   uuid.UUID    `json:"seller_id"`
+	BuyerId    uuid.UUID    `json:"buyer_id"`
+	Price      float64      `json:"price"`
+	Reason     RejectReason `json:"reason"`
+	OccurredAt time.Time    `json:"occurred_at"`
+}
+
+func (OfferRejected) Subject() string { return SubjectOfferRejected }
+
+type SaleExpired struct {
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (SaleExpired) Subject() string { return SubjectSaleExpired }
+
+// SaleExtended is an auction whose end was pushed back by a late bid.
+type SaleExtended struct {
+	SaleId     uuid.UUID `json:"sale_id"`
+	SellerId   uuid.UUID `json:"seller_id"`
+	Expiration time.Time `json:"expiration"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (SaleExtended) Subject() string { return SubjectSaleExtended }
@@ -0,0 +1,93 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"nft/config"
+	"nft/contract"
+	"nft/infra/eventbus/model"
+	"nft/infra/jtrace"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+type Nats struct {
+	conn *nats.Conn
+}
+
+func (n *Nats) Init(c context.Context) error {
+	span, _ := jtrace.T().SpanFromContext(c, "Nats[Init]")
+	defer span.Finish()
+
+	options := []nats.Option{
+		nats.MaxReconnects(config.C().Nats.MaxReconnect),
+		nats.ReconnectWait(time.Duration(config.C().Nats.ReconnectWait) * time.Second),
+	}
+	if config.C().Nats.Timeout > 0 {
+		options = append(options, nats.Timeout(time.Duration(config.C().Nats.Timeout)*time.Second))
+	}
+	if !config.C().Nats.AllowReconnect {
+		options = append(options, nats.NoReconnect())
+	}
+	if config.C().Nats.Auth {
+		options = append(options, nats.UserInfo(config.C().Nats.Username, config.C().Nats.Password))
+	}
+
+	conn, err := nats.Connect(strings.Join(config.C().Nats.Endpoints, ","), options...)
+	if err != nil {
+		return fmt.Errorf("error happened while initializing the connection to nats: %w", err)
+	}
+
+	n.conn = conn
+
+	return nil
+}
+
+func (n *Nats) Close(c context.Context) error {
+	span, _ := jtrace.T().SpanFromContext(c, "Nats[Close]")
+	defer span.Finish()
+
+	if n.conn == nil {
+		return nil
+	}
+	return n.conn.Drain()
+}
+
+func (n *Nats) Publish(c context.Context, event model.Event) error {
+	span, _ := jtrace.T().SpanFromContext(c, "Nats[Publish]")
+	defer span.Finish()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := n.conn.Publish(event.Subject(), data); err != nil {
+		return fmt.Errorf("error happened while publishing %s: %w", event.Subject(), err)
+	}
+
+	// publish only buffers the event, the outbox must not count it as
+	// delivered before the server has it
+	timeout := nats.DefaultTimeout
+	if config.C().Nats.Timeout > 0 {
+		timeout = time.Duration(config.C().Nats.Timeout) * time.Second
+	}
+	if err := n.conn.FlushTimeout(timeout); err != nil {
+		return fmt.Errorf("error happened while flushing %s: %w", event.Subject(), err)
+	}
+	return nil
+}
+
+func (n *Nats) Subscribe(subject string, handler contract.EventHandler) (func() error, error) {
+	subscription, err := n.conn.Subscribe(subject, func(msg *nats.Msg) {
+		handler(msg.Subject, msg.Data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error happened while subscribing to %s: %w", subject, err)
+	}
+
+	return subscription.Unsubscribe, nil
+}
@@ -7,27 +7,71 @@ import (
 	"nft/config"
 	apperrors "nft/error"
 	"nft/infra/jtrace"
+	"nft/infra/persist/type"
 	card "nft/internal/card/entity"
 	category "nft/internal/category/entity"
+	chain "nft/internal/chain/entity"
 	collection "nft/internal/collection/entity"
 	email "nft/internal/email/entity"
+	fee "nft/internal/fee/entity"
 	jwt "nft/internal/jwt/entity"
 	kyc "nft/internal/kyc/entity"
+	ledger "nft/internal/ledger/entity"
 	nft "nft/internal/nft/entity"
+	notification "nft/internal/notification/entity"
 	offer "nft/internal/offer/entity"
 	otp "nft/internal/otp/entity"
+	outbox "nft/internal/outbox/entity"
 	sale "nft/internal/sale/entity"
 	transaction "nft/internal/transaction/entity"
+	transfer "nft/internal/transfer/entity"
 	user "nft/internal/user/entity"
+	withdrawal "nft/internal/withdrawal/entity"
+	"nft/pkg/money"
+	"strings"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+type txKey struct{}
+
+// hooksKey carries the functions to run once the transaction commits.
+type hooksKey struct{}
+
 type Postgres struct {
 	db *gorm.DB
 }
 
+// conn returns the transaction carried by the context if there is one,
+// so repositories called inside Transaction join it transparently.
+func (p *Postgres) conn(c context.Context) *gorm.DB {
+	if tx, ok := c.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(c)
+	}
+	return p.db.WithContext(c)
+}
+
+// where applies conditions to the query. A key is either a bare column,
+// compared for equality (or "is null" when the value is nil), or a column
+// followed by an operator such as "expiration <" or "price >=".
+func where(tx *gorm.DB, conditions map[string]any) *gorm.DB {
+	for column, value := range conditions {
+		if strings.Contains(column, " ") {
+			tx = tx.Where(fmt.Sprintf("%s ?", column), value)
+			continue
+		}
+		if value == nil {
+			tx = tx.Where(fmt.Sprintf("%s is null", column))
+			continue
+		}
+		tx = tx.Where(fmt.Sprintf("%s = ?", column), value)
+	}
+	return tx
+}
+
 func (p *Postgres) Init(c context.Context) error {
 	span, _ := jtrace.T().SpanFromContext(c, "postgres[Init]")
 	defer span.Finish()
@@ -52,6 +96,10 @@ func (p *Postgres) Migrate(c context.Context) error {
 	defer span.Finish()
 
 	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := migrateLedgerAmounts(tx); err != nil {
+			return fmt.Errorf("error happened while migrating ledger amounts: %w", err)
+		}
+
 		if err := tx.AutoMigrate(
 			&category.Category{},
 			&user.User{},
@@ -60,19 +108,210 @@ func (p *Postgres) Migrate(c context.Context) error {
 			&otp.Otp{},
 			&card.Card{},
 			&kyc.Kyc{},
+			&kyc.KycDecision{},
 			&nft.Nft{},
+			&nft.NftStatusChange{},
 			&collection.Collection{},
 			&sale.Sale{},
 			&offer.Offer{},
 			&transaction.Transaction{},
+			&transfer.Transfer{},
+			&chain.ChainTx{},
+			&ledger.Account{},
+			&ledger.Entry{},
+			&ledger.Hold{},
+			&ledger.Deposit{},
+			&ledger.DepositCursor{},
+			&withdrawal.Withdrawal{},
+			&outbox.OutboxMessage{},
+			&notification.Notification{},
+			&notification.NotificationPreference{},
+			&notification.NotificationLanguage{},
+			&fee.FeePolicy{},
 		); err != nil {
 			return fmt.Errorf("error happened while migrating tables: %w", err)
 		}
 
+		if err := migrateUserWallet(tx); err != nil {
+			return fmt.Errorf("error happened while migrating user wallets: %w", err)
+		}
+
+		if err := migrateNftStatus(tx); err != nil {
+			return fmt.Errorf("error happened while migrating nft status: %w", err)
+		}
+
+		if err := migrateSaleStatus(tx); err != nil {
+			return fmt.Errorf("error happened while migrating sale status: %w", err)
+		}
+
+		if err := migrateKycStatus(tx); err != nil {
+			return fmt.Errorf("error happened while migrating kyc status: %w", err)
+		}
+
+		if err := migrateTransactionPrice(tx); err != nil {
+			return fmt.Errorf("error happened while migrating transaction price: %w", err)
+		}
+
+		if err := migrateNftHistory(tx); err != nil {
+			return fmt.Errorf("error happened while migrating nft history: %w", err)
+		}
+
+		if err := migrateChainAttempts(tx); err != nil {
+			return fmt.Errorf("error happened while migrating chain attempts: %w", err)
+		}
+
 		return nil
 	})
 }
 
+// migrateLedgerAmounts converts the ledger amounts that were kept as talan
+// floats into whole units. It runs before the tables are migrated, which
+// would change the column type without scaling, and is a no-op once done.
+func migrateLedgerAmounts(tx *gorm.DB) error {
+	columns := map[string]string{"accounts": "balance", "entries": "amount", "holds": "amount", "deposits": "amount"}
+	for table, column := range columns {
+		var dataType string
+		if err := tx.Raw(`select data_type from information_schema.columns
+			where table_schema = current_schema() and table_name = ? and column_name = ?`, table, column).
+			Scan(&dataType).Error; err != nil {
+			return err
+		}
+		if dataType != "double precision" {
+			continue
+		}
+
+		if err := tx.Exec(fmt.Sprintf("alter table %s alter column %s type bigint using round(%s * %d)::bigint",
+			table, column, column, money.Scale)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateUserWallet moves the wallet address of users created when it was
+// kept in the address column into public key, where deposits are read from.
+func migrateUserWallet(tx *gorm.DB) error {
+	return tx.Exec(`update users set public_key = address
+		where (public_key is null or public_key = '') and address <> ''`).Error
+}
+
+// migrateNftStatus derives the status column from the draft flag and review
+// columns it replaced, then drops the flag. It is a no-op once done.
+func migrateNftStatus(tx *gorm.DB) error {
+	if !tx.Migrator().HasColumn(&nft.Nft{}, "draft") {
+		return nil
+	}
+
+	if err := tx.Exec(`update nfts set status = case
+		when draft then 'Draft'
+		when approved_by is not null then 'Approved'
+		when rejected_by is not null then 'Rejected'
+		else 'Pending' end
+		where status is null or status = ''`).Error; err != nil {
+		return err
+	}
+
+	return tx.Migrator().DropColumn(&nft.Nft{}, "draft")
+}
+
+// migrateSaleStatus derives the status of sales closed before it existed,
+// which the column default left in progress: a sale with an accepted offer
+// was sold, one with a cancel date was canceled.
+func migrateSaleStatus(tx *gorm.DB) error {
+	if err := tx.Exec(`update sales set status = 'sold' where status = 'in_progress'
+		and exists (select 1 from offers where offers.sale_id = sales.id and offers.accepted)`).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`update sales set status = 'canceled' where status = 'in_progress' and canceled_at is not null`).Error
+}
+
+// migrateKycStatus derives the status of cases created before it existed.
+// Decisions used to write the nil uuid into the other decision column, so
+// those are cleared first.
+func migrateKycStatus(tx *gorm.DB) error {
+	for _, column := range []string{"approved_by", "rejected_by"} {
+		if err := tx.Exec(fmt.Sprintf("update kycs set %s = null where %s = ?", column, column), uuid.Nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(`update kycs set status = case
+		when approved_by is not null then 'approved'
+		when rejected_by is not null then 'rejected'
+		else 'submitted' end
+		where status is null or status = ''`).Error
+}
+
+// migrateTransactionPrice copies the accepted offer price into sales
+// recorded before transactions had one.
+func migrateTransactionPrice(tx *gorm.DB) error {
+	return tx.Exec(`update transactions set price = offers.price from offers
+		where offers.id = transactions.offer_id and transactions.kind = 'sale' and transactions.price = 0`).Error
+}
+
+// migrateNftHistory creates the view of the provenance of every nft, which
+// pages through it in one query. The sales of an nft are its own listings
+// and the collection sales it changed hands in; their offers are shown
+// along with how they were rejected or canceled. Events at the same instant
+// keep the order of their ord column.
+func migrateNftHistory(tx *gorm.DB) error {
+	return tx.Exec(fmt.Sprintf(`create or replace view nft_history_events as
+		with nft_sales as (
+			select asset_id as nft_id, id as sale_id from sales
+			where asset_type = 'nft' and deleted_at is null
+			union
+			select asset_id, sale_id from transactions
+			where kind = 'sale' and sale_id <> '%[1]s' and deleted_at is null
+		)
+		select * from (
+			select distinct on (nft_id) id, nft_id, created_at as at, 0 as ord, 'mint' as kind,
+				actor_id, null::uuid as counterparty_id, 0::float8 as price,
+				null::uuid as sale_id, null::uuid as offer_id, null::uuid as transaction_id
+			from nft_status_changes where deleted_at is null
+			order by nft_id, created_at
+		) mints
+		union all
+		select s.id, ns.nft_id, s.created_at, 1, 'listed', s.user_id, null, s.min_price, s.id, null, null
+		from nft_sales ns join sales s on s.id = ns.sale_id
+		union all
+		select s.id, ns.nft_id, s.canceled_at, 2, 'listing_canceled',
+			coalesce(nullif(s.canceled_by, '%[1]s'), s.user_id), null, 0, s.id, null, null
+		from nft_sales ns join sales s on s.id = ns.sale_id
+		where s.canceled_at is not null
+		union all
+		select s.id, ns.nft_id, s.expiration, 2, 'listing_expired', s.user_id, null, 0, s.id, null, null
+		from nft_sales ns join sales s on s.id = ns.sale_id
+		where s.canceled_at is null and s.status = 'expired'
+		union all
+		select o.id, ns.nft_id, o.created_at, 3, 'offer', o.user_id, s.user_id, o.price, s.id, o.id, null
+		from nft_sales ns join sales s on s.id = ns.sale_id join offers o on o.sale_id = s.id
+		union all
+		select o.id, ns.nft_id, o.rejected_at, 4, 'offer_rejected', s.user_id, o.user_id, o.price, s.id, o.id, null
+		from nft_sales ns join sales s on s.id = ns.sale_id join offers o on o.sale_id = s.id
+		where o.rejected_at is not null
+		union all
+		select o.id, ns.nft_id, o.deleted_at, 4, 'offer_canceled', o.user_id, s.user_id, o.price, s.id, o.id, null
+		from nft_sales ns join sales s on s.id = ns.sale_id join offers o on o.sale_id = s.id
+		where o.deleted_at is not null
+		union all
+		select t.id, t.asset_id, t.created_at, 5,
+			case when t.kind = 'transfer' then 'transferred' else 'sold' end,
+			t.seller_id, t.buyer_id, t.price,
+			case when t.kind = 'transfer' then null else nullif(t.sale_id, '%[1]s') end,
+			case when t.kind = 'transfer' then null else nullif(t.offer_id, '%[1]s') end,
+			t.id
+		from transactions t where t.deleted_at is null`, uuid.Nil)).Error
+}
+
+// migrateChainAttempts makes the chain transactions queued before they were
+// retried due right away. It is a no-op once done.
+func migrateChainAttempts(tx *gorm.DB) error {
+	return tx.Model(&chain.ChainTx{}).Where("next_attempt_at is null").
+		Update("next_attempt_at", gorm.Expr("created_at")).Error
+}
+
 func (p *Postgres) Close(c context.Context) error {
 	span, _ := jtrace.T().SpanFromContext(c, "Postgres[Close]")
 	defer span.Finish()
@@ -83,11 +322,7 @@ func (p *Postgres) Get(c context.Context, entity any, conditions map[string]any)
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Get]")
 	defer span.Finish()
 
-	tx := p.db.WithContext(ctx).Where("deleted_at is null")
-
-	for column, value := range conditions {
-		tx = tx.Where(fmt.Sprintf("%s = ?", column), value)
-	}
+	tx := where(p.conn(ctx).Where("deleted_at is null"), conditions)
 
 	if err := tx.First(entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -103,11 +338,7 @@ func (p *Postgres) GetAll(c context.Context, entity any, conditions map[string]a
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[GetAll]")
 	defer span.Finish()
 
-	tx := p.db.WithContext(ctx).Where("deleted_at is null")
-
-	for column, value := range conditions {
-		tx = tx.Where(fmt.Sprintf("%s = ?", column), value)
-	}
+	tx := where(p.conn(ctx).Where("deleted_at is null"), conditions)
 
 	if err := tx.Find(entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -119,11 +350,44 @@ func (p *Postgres) GetAll(c context.Context, entity any, conditions map[string]a
 	return entity, nil
 }
 
+func (p *Postgres) Find(c context.Context, entity any, query persist.Query) (any, error) {
+	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Find]")
+	defer span.Finish()
+
+	tx := p.conn(ctx)
+	if !query.Unscoped {
+		tx = tx.Where("deleted_at is null")
+	}
+	tx = where(tx, query.Conditions)
+
+	if len(query.Order) > 0 {
+		tx = tx.Order(query.Order)
+	}
+
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	if query.SkipLocked {
+		tx = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	}
+
+	if err := tx.Find(entity).Error; err != nil {
+		return nil, fmt.Errorf("error happened while searching for records: %w", err)
+	}
+
+	return entity, nil
+}
+
 func (p *Postgres) Create(c context.Context, entity any) (any, error) {
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Create]")
 	defer span.Finish()
 
-	if err := p.db.WithContext(ctx).Create(entity).Error; err != nil {
+	if err := p.conn(ctx).Create(entity).Error; err != nil {
 		return user.User{}, fmt.Errorf("error happened while creating a record: %w", err)
 	}
 
@@ -134,17 +398,40 @@ func (p *Postgres) Update(c context.Context, entity any, data any) (any, error)
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Update]")
 	defer span.Finish()
 
-	if err := p.db.WithContext(ctx).Model(entity).Updates(data).Error; err != nil {
+	// gorm only takes column maps of the unnamed map type
+	if d, ok := data.(persist.D); ok {
+		data = map[string]any(d)
+	}
+
+	if err := p.conn(ctx).Model(entity).Updates(data).Error; err != nil {
 		return user.User{}, fmt.Errorf("error happened while updating a record: %w", err)
 	}
 
 	return entity, nil
 }
 
+// UpdateWhere updates the rows of the entity's table matching the conditions
+// in a single statement and returns how many it changed.
+func (p *Postgres) UpdateWhere(c context.Context, entity any, conditions map[string]any, data any) (int, error) {
+	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[UpdateWhere]")
+	defer span.Finish()
+
+	if d, ok := data.(persist.D); ok {
+		data = map[string]any(d)
+	}
+
+	tx := where(p.conn(ctx).Model(entity), conditions).Updates(data)
+	if tx.Error != nil {
+		return 0, fmt.Errorf("error happened while updating records: %w", tx.Error)
+	}
+
+	return int(tx.RowsAffected), nil
+}
+
 func (p *Postgres) Delete(c context.Context, entity any) error {
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Delete]")
 	defer span.Finish()
-	if err := p.db.WithContext(ctx).Delete(entity).Error; err != nil {
+	if err := p.conn(ctx).Delete(entity).Error; err != nil {
 		return fmt.Errorf("error happened while updating a record: %w", err)
 	}
 	return nil
@@ -156,11 +443,7 @@ func (p *Postgres) Count(c context.Context, entity any, conditions map[string]an
 
 	var count int64
 
-	tx := p.db.WithContext(c).Model(entity)
-
-	for column, value := range conditions {
-		tx = tx.Where(fmt.Sprintf("%s = ?", column), value)
-	}
+	tx := where(p.conn(c).Model(entity), conditions)
 
 	if err := tx.Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("error happened while searching for a record: %w", err)
@@ -173,18 +456,66 @@ func (p *Postgres) Last(c context.Context, entity any, conditions map[string]any
 	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Last]")
 	defer span.Finish()
 
-	tx := p.db.WithContext(ctx).Where("deleted_at is null")
+	tx := where(p.conn(ctx).Where("deleted_at is null"), conditions)
 
-	for column, value := range conditions {
-		tx = tx.Where(fmt.Sprintf("%s = ?", column), value)
+	if err := tx.Order("created_at desc").First(entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("error happened while searching for a record: %w", err)
 	}
 
-	if err := tx.Last(entity).Error; err != nil {
+	return entity, nil
+}
+
+func (p *Postgres) Lock(c context.Context, entity any, conditions map[string]any) (any, error) {
+	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Lock]")
+	defer span.Finish()
+
+	tx := where(p.conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("deleted_at is null"), conditions)
+
+	if err := tx.First(entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperrors.ErrRecordNotFound
 		}
-		return nil, fmt.Errorf("error happened while searching for a record: %w", err)
+		return nil, fmt.Errorf("error happened while locking a record: %w", err)
 	}
 
 	return entity, nil
 }
+
+// Transaction runs fn in a transaction, or in a savepoint of the one the
+// context already carries. What fn registers with AfterCommit runs once the
+// outermost transaction commits and is dropped with any part that rolls back.
+func (p *Postgres) Transaction(c context.Context, fn func(c context.Context) error) error {
+	span, ctx := jtrace.T().SpanFromContext(c, "Postgres[Transaction]")
+	defer span.Finish()
+
+	var hooks []func(c context.Context)
+	err := p.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(context.WithValue(ctx, txKey{}, tx), hooksKey{}, &hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	if parent, ok := ctx.Value(hooksKey{}).(*[]func(c context.Context)); ok {
+		*parent = append(*parent, hooks...)
+		return nil
+	}
+
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction the context carries commits, or
+// right away outside of one.
+func (p *Postgres) AfterCommit(c context.Context, fn func(c context.Context)) {
+	if hooks, ok := c.Value(hooksKey{}).(*[]func(c context.Context)); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn(c)
+}
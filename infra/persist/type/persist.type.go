@@ -1,3 +1,18 @@
 package persist
 
 type D map[string]any
+
+// Query is a filtered lookup with an optional ordering and row window. Order
+// is a raw order clause such as "created_at desc, id desc", a zero Limit
+// means no limit and Offset skips that many rows. Unscoped includes the
+// soft deleted rows, and is required for views, which have no deleted_at.
+// SkipLocked locks the rows found until the transaction ends and leaves out
+// those another transaction holds, so concurrent workers claim apart.
+type Query struct {
+	Conditions D
+	Order      string
+	Limit      int
+	Offset     int
+	Unscoped   bool
+	SkipLocked bool
+}
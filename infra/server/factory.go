@@ -8,9 +8,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/swagger"
+	"github.com/gofiber/websocket/v2"
 	"go.uber.org/fx"
 	_ "nft/docs"
 	fiberapp "nft/infra/server/fiber"
+	usermodel "nft/internal/user/model"
+	"nft/pkg/lang"
 )
 
 func corsHandler(h http.Handler) http.Handler {
@@ -26,18 +29,34 @@ func corsHandler(h http.Handler) http.Handler {
 	})
 }
 
+// acceptLanguage keeps the language the client prefers on the request
+// context, where lang.Get finds it.
+func acceptLanguage(c *fiber.Ctx) error {
+	c.Context().SetUserValue("accept-language", string(lang.Parse(c.Get(fiber.HeaderAcceptLanguage))))
+	return c.Next()
+}
+
 type ControllerContainer struct {
 	fx.In
-	JwtMiddleware        contract.IJwtMiddleware
-	AuthController       contract.IAuthController
-	UserController       contract.IUserController
-	CategoryController   contract.ICategoryController
-	CardController       contract.ICardController
-	KYCController        contract.IKycController
-	NftController        contract.INftController
-	CollectionController contract.ICollectionController
-	SaleController       contract.ISaleController
-	OfferController      contract.IOfferController
+	JwtMiddleware          contract.IJwtMiddleware
+	RoleMiddleware         contract.IRoleMiddleware
+	AuthController         contract.IAuthController
+	UserController         contract.IUserController
+	CategoryController     contract.ICategoryController
+	CardController         contract.ICardController
+	KYCController          contract.IKycController
+	NftController          contract.INftController
+	CollectionController   contract.ICollectionController
+	SaleController         contract.ISaleController
+	OfferController        contract.IOfferController
+	LedgerController       contract.ILedgerController
+	FeeController          contract.IFeeController
+	LimitController        contract.ILimitController
+	TransferController     contract.ITransferController
+	WithdrawalController   contract.IWithdrawalController
+	OutboxController       contract.IOutboxController
+	NotificationController contract.INotificationController
+	FeedController         contract.IFeedController
 }
 
 func New(cc ControllerContainer) contract.IServer {
@@ -47,6 +66,7 @@ func New(cc ControllerContainer) contract.IServer {
 		EnableStackTrace: true,
 	}))
 	app.Get("/swagger/*", swagger.HandlerDefault) // default
+	app.Use(acceptLanguage)
 
 	router := app.Group(config.C().App.BaseURL)
 
@@ -58,45 +78,81 @@ func New(cc ControllerContainer) contract.IServer {
 	authRouter.Post("/resend-email", cc.AuthController.ResendEmail)
 	authRouter.Post("/logout", cc.AuthController.Logout)
 
-	userRouter := router.Group("/user")
-	userRouter.Get("/", cc.UserController.GetAllUsers)
-	userRouter.Get("/:id", cc.UserController.GetUser)
-	userRouter.Post("/", cc.UserController.AddUser)
-	userRouter.Patch("/:id", cc.UserController.UpdateUser)
-	userRouter.Delete("/:id", cc.UserController.DeleteUser)
-
+	adminRouter := router.Group("/admin")
+	adminRouter.Use(cc.JwtMiddleware.Handle)
+
+	adminUserRouter := adminRouter.Group("/user")
+	adminUserRouter.Use(cc.RoleMiddleware.Require(usermodel.PermissionManageUsers))
+	adminUserRouter.Get("/", cc.UserController.GetAllUsers)
+	adminUserRouter.Get("/:id", cc.UserController.GetUser)
+	adminUserRouter.Post("/", cc.UserController.AddUser)
+	adminUserRouter.Patch("/:id", cc.UserController.UpdateUser)
+	adminUserRouter.Delete("/:id", cc.UserController.DeleteUser)
+	adminUserRouter.Put("/:id/role", cc.UserController.SetRole)
+
+	adminFeeRouter := adminRouter.Group("/fee")
+	adminFeeRouter.Use(cc.RoleMiddleware.Require(usermodel.PermissionManageFees))
+	adminFeeRouter.Put("/policy", cc.FeeController.SetPolicy)
+
+	adminWithdrawalRouter := adminRouter.Group("/withdrawal")
+	adminWithdrawalRouter.Use(cc.RoleMiddleware.Require(usermodel.PermissionManageWithdrawals))
+	adminWithdrawalRouter.Get("/", cc.WithdrawalController.GetAll)
+	adminWithdrawalRouter.Post("/:id/approve", cc.WithdrawalController.Approve)
+	adminWithdrawalRouter.Post("/:id/sent", cc.WithdrawalController.MarkSent)
+	adminWithdrawalRouter.Post("/:id/fail", cc.WithdrawalController.Fail)
+
+	adminOutboxRouter := adminRouter.Group("/outbox")
+	adminOutboxRouter.Use(cc.RoleMiddleware.Require(usermodel.PermissionManageOutbox))
+	adminOutboxRouter.Get("/", cc.OutboxController.GetMessages)
+	adminOutboxRouter.Post("/:id/requeue", cc.OutboxController.Requeue)
+
+	manageCategories := cc.RoleMiddleware.Require(usermodel.PermissionManageCategories)
 	categoryRouter := router.Group("/category")
 	categoryRouter.Use(cc.JwtMiddleware.Handle)
 	categoryRouter.Get("/", cc.CategoryController.GetAllCategories)
 	categoryRouter.Get("/:id", cc.CategoryController.GetCategory)
-	categoryRouter.Post("/", cc.CategoryController.AddCategory)
-	categoryRouter.Patch("/:id", cc.CategoryController.UpdateCategory)
-	categoryRouter.Delete("/:id", cc.CategoryController.DeleteCategory)
+	categoryRouter.Post("/", manageCategories, cc.CategoryController.AddCategory)
+	categoryRouter.Patch("/:id", manageCategories, cc.CategoryController.UpdateCategory)
+	categoryRouter.Delete("/:id", manageCategories, cc.CategoryController.DeleteCategory)
 
 	cardRouter := router.Group("/card")
 	cardRouter.Use(cc.JwtMiddleware.Handle)
 	cardRouter.Get("/", cc.CardController.GetAllCards)
 	cardRouter.Get("/:id", cc.CardController.GetCard)
 	cardRouter.Post("/", cc.CardController.AddCard)
-	cardRouter.Post("/:id/approve", cc.CardController.ApproveCard)
+	cardRouter.Post("/:id/approve", cc.RoleMiddleware.Require(usermodel.PermissionReviewCard), cc.CardController.ApproveCard)
 	cardRouter.Delete("/:id", cc.CardController.RemoveCard)
 
 	kycRouter := router.Group("/kyc")
 	kycRouter.Use(cc.JwtMiddleware.Handle)
+	reviewKyc := cc.RoleMiddleware.Require(usermodel.PermissionReviewKyc)
 	kycRouter.Get("/", cc.KYCController.GetAllAppeals)
+	kycRouter.Get("/cases", reviewKyc, cc.KYCController.GetCases)
 	kycRouter.Get("/:id", cc.KYCController.GetAppeal)
 	kycRouter.Post("/", cc.KYCController.Appeal)
-	kycRouter.Post("/:id/approve", cc.KYCController.Approve)
-	kycRouter.Post("/:id/reject", cc.KYCController.Reject)
+	kycRouter.Put("/:id/documents", cc.KYCController.Resubmit)
+	kycRouter.Get("/:id/decisions", reviewKyc, cc.KYCController.GetDecisions)
+	kycRouter.Post("/:id/assign", reviewKyc, cc.KYCController.Assign)
+	kycRouter.Post("/:id/request-info", reviewKyc, cc.KYCController.RequestInfo)
+	kycRouter.Post("/:id/approve", reviewKyc, cc.KYCController.Approve)
+	kycRouter.Post("/:id/reject", reviewKyc, cc.KYCController.Reject)
 
 	nftRouter := router.Group("/nft")
 	nftRouter.Use(cc.JwtMiddleware.Handle)
 	nftRouter.Get("/", cc.NftController.GetNftList)
 	nftRouter.Get("/:id", cc.NftController.GetNft)
 	nftRouter.Post("/", cc.NftController.Create)
-	nftRouter.Post("/:id/approve", cc.NftController.Approve)
-	nftRouter.Post("/:id/reject", cc.NftController.Reject)
+	reviewNft := cc.RoleMiddleware.Require(usermodel.PermissionReviewNft)
+	nftRouter.Post("/:id/approve", reviewNft, cc.NftController.Approve)
+	nftRouter.Post("/:id/reject", reviewNft, cc.NftController.Reject)
 	nftRouter.Delete("/:id", cc.NftController.DeleteDraft)
+	nftRouter.Post("/:id/royalty", cc.NftController.SetRoyalty)
+	nftRouter.Post("/:id/submit", cc.NftController.Submit)
+	nftRouter.Get("/:id/status-history", cc.NftController.GetStatusHistory)
+	nftRouter.Get("/:id/history", cc.NftController.GetHistory)
+	nftRouter.Put("/:id/collection", cc.NftController.SetCollection)
+	nftRouter.Delete("/:id/collection", cc.NftController.RemoveFromCollection)
+	nftRouter.Post("/:id/transfer", cc.TransferController.Transfer)
 
 	collectionRouter := router.Group("/collection")
 	collectionRouter.Use(cc.JwtMiddleware.Handle)
@@ -104,12 +160,18 @@ func New(cc ControllerContainer) contract.IServer {
 	collectionRouter.Get("/:id", cc.CollectionController.Get)
 	collectionRouter.Post("/", cc.CollectionController.Add)
 	collectionRouter.Delete("/:id", cc.CollectionController.Delete)
+	collectionRouter.Post("/:id/royalty", cc.CollectionController.SetRoyalty)
+	collectionRouter.Get("/:id/nfts", cc.CollectionController.GetNfts)
+
+	// the market is public, so it is routed ahead of the authenticated sale group
+	router.Get("/sale/market", cc.SaleController.GetMarket)
 
 	saleRouter := router.Group("/sale")
 	saleRouter.Use(cc.JwtMiddleware.Handle)
 	saleRouter.Post("/sell-nft", cc.SaleController.SellNft)
 	saleRouter.Post("/sell-collection", cc.SaleController.SellCollection)
 	saleRouter.Delete("/:id", cc.SaleController.CancelSale)
+	saleRouter.Post("/:id/buy", cc.SaleController.BuySale)
 	saleRouter.Get("/:id", cc.SaleController.GetSale)
 	saleRouter.Get("/", cc.SaleController.GetAllSales)
 
@@ -120,5 +182,46 @@ func New(cc ControllerContainer) contract.IServer {
 	offerRouter.Get("/", cc.OfferController.GetAllOffers)
 	offerRouter.Post("/:id/accept", cc.OfferController.AcceptOffer)
 
+	ledgerRouter := router.Group("/ledger")
+	ledgerRouter.Use(cc.JwtMiddleware.Handle)
+	ledgerRouter.Get("/balance", cc.LedgerController.GetBalance)
+	ledgerRouter.Post("/deposits/sync", cc.LedgerController.SyncDeposits)
+
+	feeRouter := router.Group("/fee")
+	feeRouter.Use(cc.JwtMiddleware.Handle)
+	feeRouter.Get("/policy", cc.FeeController.GetPolicy)
+
+	limitRouter := router.Group("/limit")
+	limitRouter.Use(cc.JwtMiddleware.Handle)
+	limitRouter.Get("/allowance", cc.LimitController.GetAllowance)
+
+	transferRouter := router.Group("/transfer")
+	transferRouter.Use(cc.JwtMiddleware.Handle)
+	transferRouter.Get("/incoming", cc.TransferController.GetIncoming)
+	transferRouter.Post("/:id/accept", cc.TransferController.Accept)
+	transferRouter.Post("/:id/decline", cc.TransferController.Decline)
+	transferRouter.Post("/:id/cancel", cc.TransferController.Cancel)
+
+	withdrawalRouter := router.Group("/withdrawal")
+	withdrawalRouter.Use(cc.JwtMiddleware.Handle)
+	withdrawalRouter.Post("/", cc.WithdrawalController.Request)
+	withdrawalRouter.Get("/", cc.WithdrawalController.GetWithdrawals)
+	withdrawalRouter.Post("/:id/confirm", cc.WithdrawalController.Confirm)
+
+	notificationRouter := router.Group("/notification")
+	notificationRouter.Use(cc.JwtMiddleware.Handle)
+	notificationRouter.Get("/", cc.NotificationController.GetNotifications)
+	notificationRouter.Get("/unread-count", cc.NotificationController.GetUnreadCount)
+	notificationRouter.Post("/read-all", cc.NotificationController.MarkAllRead)
+	notificationRouter.Get("/preferences", cc.NotificationController.GetPreferences)
+	notificationRouter.Put("/preferences", cc.NotificationController.SetPreferences)
+	notificationRouter.Get("/language", cc.NotificationController.GetLanguage)
+	notificationRouter.Put("/language", cc.NotificationController.SetLanguage)
+	notificationRouter.Post("/:id/read", cc.NotificationController.MarkRead)
+
+	feedRouter := router.Group("/feed")
+	feedRouter.Use(cc.JwtMiddleware.Handle)
+	feedRouter.Get("/", cc.FeedController.Upgrade, websocket.New(cc.FeedController.Connect))
+
 	return &fiberapp.Server{App: app}
 }
@@ -0,0 +1,81 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/pkg/crypt"
+	"os"
+	"strconv"
+)
+
+const (
+	driverConfig = "config"
+	driverFile   = "file"
+)
+
+// keyFile is the layout of the file driver: the current version and the
+// base64 encoded master keys by version.
+type keyFile struct {
+	Current int               `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// New returns the vault of the configured secrets driver. It fails unless
+// the master key of the current version is there.
+func New() (contract.IVault, error) {
+	var keys []config.MasterKey
+	current := config.C().Secrets.CurrentVersion
+
+	switch config.C().Secrets.Driver {
+	case driverConfig:
+		keys = config.C().Secrets.MasterKeys
+	case driverFile:
+		var err error
+		if current, keys, err = readKeyFile(config.C().Secrets.KeyFile); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, apperrors.ErrUnknownVaultDriver
+	}
+
+	keyring := crypt.Keyring{Current: current, Keys: make(map[int][]byte)}
+	for _, key := range keys {
+		decoded, err := base64.StdEncoding.DecodeString(key.Key)
+		if err != nil || len(decoded) != 32 {
+			return nil, crypt.ErrInvalidMasterKey
+		}
+		keyring.Keys[key.Version] = decoded
+	}
+
+	if _, ok := keyring.Keys[current]; !ok {
+		return nil, crypt.ErrUnknownKeyVersion
+	}
+
+	return Vault{keyring: keyring}, nil
+}
+
+func readKeyFile(path string) (int, []config.MasterKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, nil, apperrors.ErrInvalidKeyFile
+	}
+
+	keys := make([]config.MasterKey, 0, len(file.Keys))
+	for version, key := range file.Keys {
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return 0, nil, apperrors.ErrInvalidKeyFile
+		}
+		keys = append(keys, config.MasterKey{Version: v, Key: key})
+	}
+
+	return file.Current, keys, nil
+}
@@ -0,0 +1,24 @@
+package vault
+
+import (
+	"nft/pkg/crypt"
+)
+
+// Vault seals secrets with envelope encryption under the master keys of
+// its keyring.
+type Vault struct {
+	keyring crypt.Keyring
+}
+
+func (v Vault) Seal(plaintext string) (string, error) {
+	return v.keyring.Seal(plaintext)
+}
+
+func (v Vault) Open(sealed string) (string, error) {
+	return v.keyring.Open(sealed)
+}
+
+func (v Vault) Stale(value string) bool {
+	version, ok := crypt.Version(value)
+	return !ok || version != v.keyring.Current
+}
@@ -14,6 +14,7 @@ import (
 )
 
 type AuthService struct {
+	db           contract.IPersist
 	emailService contract.IEmailService
 	jwtService   contract.IJwtService
 	userService  contract.IUserService
@@ -22,6 +23,7 @@ type AuthService struct {
 
 type AuthServiceParams struct {
 	fx.In
+	DB           contract.IPersist
 	EmailService contract.IEmailService
 	JwtService   contract.IJwtService
 	UserService  contract.IUserService
@@ -30,6 +32,7 @@ type AuthServiceParams struct {
 
 func NewAuthService(params AuthServiceParams) contract.IAuthService {
 	return &AuthService{
+		db:           params.DB,
 		emailService: params.EmailService,
 		jwtService:   params.JwtService,
 		userService:  params.UserService,
@@ -37,21 +40,28 @@ func NewAuthService(params AuthServiceParams) contract.IAuthService {
 	}
 }
 
+// SignUp adds the user and queues its verification email in one
+// transaction, so a user is never left without the email and the email is
+// never sent for a user that wasn't added.
 func (a AuthService) SignUp(c context.Context, userModel user.User) (string, error) {
 	span, c := jtrace.T().SpanFromContext(c, "AuthService[SignUp]")
 	defer span.Finish()
 
-	createdUser, err := a.userService.AddUser(c, userModel)
-	if err != nil {
-		return "", err
-	}
+	var createdUser user.User
+	err := a.db.Transaction(c, func(c context.Context) error {
+		var err error
+		createdUser, err = a.userService.AddUser(c, userModel)
+		if err != nil {
+			return err
+		}
 
-	userEmail, err := a.emailService.GetUserEmail(c, createdUser.ID)
-	if err != nil {
-		return "", err
-	}
+		userEmail, err := a.emailService.GetUserEmail(c, createdUser.ID)
+		if err != nil {
+			return err
+		}
 
-	err = a.emailService.SendOtpEmail(c, userEmail.ID)
+		return a.emailService.SendOtpEmail(c, userEmail.ID)
+	})
 	if err != nil {
 		return "", err
 	}
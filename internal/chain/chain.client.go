@@ -0,0 +1,22 @@
+package chain
+
+import (
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"time"
+)
+
+const driverSimulator = "simulator"
+
+// NewChainClient returns the client of the configured chain driver.
+func NewChainClient() (contract.IChainClient, error) {
+	switch config.C().Chain.Driver {
+	case driverSimulator:
+		return NewSimulator(
+			time.Duration(config.C().Chain.BlockTimeInSec)*time.Second,
+			config.C().Chain.MarketplaceContract,
+		), nil
+	}
+	return nil, apperrors.ErrUnknownChainDriver
+}
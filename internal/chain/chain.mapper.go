@@ -0,0 +1,61 @@
+package chain
+
+import (
+	"database/sql"
+	"nft/internal/chain/entity"
+	"nft/internal/chain/model"
+)
+
+func mapChainTxModelToEntity(m model.ChainTx) entity.ChainTx {
+	tx := entity.ChainTx{
+		Kind:          string(m.Kind),
+		Status:        string(m.Status),
+		AssetId:       m.AssetId,
+		SenderId:      m.SenderId,
+		RecipientId:   m.RecipientId,
+		TransactionId: m.TransactionId,
+		TxHash:        m.TxHash,
+		Contract:      m.Contract,
+		TokenId:       m.TokenId,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+	}
+
+	if len(m.Error) > 0 {
+		tx.Error = &sql.NullString{String: m.Error, Valid: true}
+	}
+
+	return tx
+}
+
+func mapChainTxEntityToModel(e entity.ChainTx) model.ChainTx {
+	tx := model.ChainTx{
+		ID:            &e.ID,
+		CreatedAt:     e.CreatedAt,
+		Kind:          model.Kind(e.Kind),
+		Status:        model.Status(e.Status),
+		AssetId:       e.AssetId,
+		SenderId:      e.SenderId,
+		RecipientId:   e.RecipientId,
+		TransactionId: e.TransactionId,
+		TxHash:        e.TxHash,
+		Contract:      e.Contract,
+		TokenId:       e.TokenId,
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+	}
+
+	if e.Error != nil {
+		tx.Error = e.Error.String
+	}
+
+	return tx
+}
+
+func createModelChainTxListFromEntity(txs []entity.ChainTx) []model.ChainTx {
+	txList := make([]model.ChainTx, len(txs))
+	for i := range txs {
+		txList[i] = mapChainTxEntityToModel(txs[i])
+	}
+	return txList
+}
@@ -0,0 +1,9 @@
+package chain
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewChainClient),
+	fx.Provide(NewChainService),
+	fx.Provide(NewChainRepository),
+)
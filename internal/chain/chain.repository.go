@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/chain/entity"
+	"nft/internal/chain/model"
+)
+
+type ChainRepository struct {
+	db contract.IPersist
+}
+
+type ChainRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewChainRepository(params ChainRepositoryParams) contract.IChainRepository {
+	return &ChainRepository{
+		db: params.DB,
+	}
+}
+
+func (r ChainRepository) Add(c context.Context, m model.ChainTx) (model.ChainTx, error) {
+	span, c := jtrace.T().SpanFromContext(c, "ChainRepository[Add]")
+	defer span.Finish()
+
+	txEntity := mapChainTxModelToEntity(m)
+	txEntity.ID = uuid.New()
+
+	created, err := r.db.Create(c, &txEntity)
+	if err != nil {
+		return model.ChainTx{}, err
+	}
+
+	return mapChainTxEntityToModel(*created.(*entity.ChainTx)), nil
+}
+
+func (r ChainRepository) Find(c context.Context, query persist.Query) ([]model.ChainTx, error) {
+	span, c := jtrace.T().SpanFromContext(c, "ChainRepository[Find]")
+	defer span.Finish()
+
+	txs, err := r.db.Find(c, &[]entity.ChainTx{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelChainTxListFromEntity(*txs.(*[]entity.ChainTx)), nil
+}
+
+// Update writes where the transaction is on its way to the chain.
+func (r ChainRepository) Update(c context.Context, m model.ChainTx) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainRepository[Update]")
+	defer span.Finish()
+
+	data := persist.D{
+		"status":          m.Status,
+		"tx_hash":         m.TxHash,
+		"contract":        m.Contract,
+		"token_id":        m.TokenId,
+		"error":           &sql.NullString{String: m.Error, Valid: len(m.Error) > 0},
+		"attempts":        m.Attempts,
+		"next_attempt_at": m.NextAttemptAt,
+	}
+	if _, err := r.db.Update(c, &entity.ChainTx{ID: *m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
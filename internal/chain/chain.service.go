@@ -0,0 +1,332 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/fx"
+	"log"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/chain/model"
+	collectionmodel "nft/internal/collection/model"
+	nftmodel "nft/internal/nft/model"
+	txmodel "nft/internal/transaction/model"
+	"nft/pkg/schedule"
+	"time"
+)
+
+type ChainService struct {
+	db                    contract.IPersist
+	chainClient           contract.IChainClient
+	chainRepository       contract.IChainRepository
+	nftRepository         contract.INftRepository
+	collectionRepository  contract.ICollectionRepository
+	transactionRepository contract.ITransactionRepository
+	userRepository        contract.IUserRepository
+}
+
+type ChainServiceParams struct {
+	fx.In
+	DB                    contract.IPersist
+	ChainClient           contract.IChainClient
+	ChainRepository       contract.IChainRepository
+	NftRepository         contract.INftRepository
+	CollectionRepository  contract.ICollectionRepository
+	TransactionRepository contract.ITransactionRepository
+	UserRepository        contract.IUserRepository
+}
+
+func NewChainService(params ChainServiceParams) contract.IChainService {
+	return &ChainService{
+		db:                    params.DB,
+		chainClient:           params.ChainClient,
+		chainRepository:       params.ChainRepository,
+		nftRepository:         params.NftRepository,
+		collectionRepository:  params.CollectionRepository,
+		transactionRepository: params.TransactionRepository,
+		userRepository:        params.UserRepository,
+	}
+}
+
+// QueueDeploy queues the deploy of the contract of a saved collection.
+func (s ChainService) QueueDeploy(c context.Context, m collectionmodel.Collection) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[QueueDeploy]")
+	defer span.Finish()
+
+	_, err := s.chainRepository.Add(c, model.ChainTx{
+		Kind:    model.KindDeploy,
+		Status:  model.StatusQueued,
+		AssetId: *m.ID,
+	})
+	return err
+}
+
+// QueueMint queues the mint of an approved nft to its creator.
+func (s ChainService) QueueMint(c context.Context, m nftmodel.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[QueueMint]")
+	defer span.Finish()
+
+	_, err := s.chainRepository.Add(c, model.ChainTx{
+		Kind:        model.KindMint,
+		Status:      model.StatusQueued,
+		AssetId:     *m.ID,
+		RecipientId: &m.User.ID,
+	})
+	return err
+}
+
+// QueueTransfer queues the move of the token of an nft that changed hands
+// in the transaction.
+func (s ChainService) QueueTransfer(c context.Context, m txmodel.Transaction) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[QueueTransfer]")
+	defer span.Finish()
+
+	_, err := s.chainRepository.Add(c, model.ChainTx{
+		Kind:          model.KindTransfer,
+		Status:        model.StatusQueued,
+		AssetId:       m.AssetId,
+		SenderId:      &m.SellerId,
+		RecipientId:   &m.BuyerId,
+		TransactionId: m.ID,
+	})
+	return err
+}
+
+// Process submits the queued transactions in the order they were queued,
+// then checks the receipts of the ones that were submitted. Each batch is
+// claimed first, so concurrent workers never handle the same transaction. A
+// transfer of an nft whose mint is not confirmed yet stays queued.
+func (s ChainService) Process(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[Process]")
+	defer span.Finish()
+
+	queued, err := s.claim(c, model.StatusQueued)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range queued {
+		if err := s.submit(c, tx); err != nil {
+			log.Println(err)
+		}
+	}
+
+	submitted, err := s.claim(c, model.StatusSubmitted)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range submitted {
+		if err := s.poll(c, tx); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// claim takes the due transactions of the status for the lease, skipping
+// those another worker is claiming. A transaction whose worker dies is
+// handled again once its lease runs out.
+func (s ChainService) claim(c context.Context, status model.Status) ([]model.ChainTx, error) {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[claim]")
+	defer span.Finish()
+
+	var claimed []model.ChainTx
+	err := s.db.Transaction(c, func(c context.Context) error {
+		now := time.Now()
+		txs, err := s.chainRepository.Find(c, persist.Query{
+			Conditions: persist.D{
+				"status":             status,
+				"next_attempt_at <=": now,
+			},
+			Order:      "created_at asc",
+			Limit:      config.C().Chain.BatchSize,
+			SkipLocked: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range txs {
+			txs[i].NextAttemptAt = now.Add(time.Duration(config.C().Chain.LeaseInSec) * time.Second)
+			if err := s.chainRepository.Update(c, txs[i]); err != nil {
+				return err
+			}
+		}
+
+		claimed = txs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// submit sends the transaction to the chain and releases it to be polled.
+// One that fails is retried later, and fails for good once it runs out of
+// attempts.
+func (s ChainService) submit(c context.Context, tx model.ChainTx) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[submit]")
+	defer span.Finish()
+
+	var submission model.Submission
+	var err error
+	switch tx.Kind {
+	case model.KindDeploy:
+		submission, err = s.deploy(c, tx)
+	case model.KindMint:
+		submission, err = s.mint(c, tx)
+	case model.KindTransfer:
+		submission, err = s.transfer(c, tx)
+	default:
+		err = fmt.Errorf("%w: %s", apperrors.ErrUnknownChainTxKind, tx.Kind)
+	}
+
+	// waiting on a mint is not a failure of the transfer
+	if errors.Is(err, apperrors.ErrChainAssetNotMinted) {
+		tx.NextAttemptAt = time.Now()
+		return s.chainRepository.Update(c, tx)
+	}
+
+	if err != nil {
+		tx.Attempts++
+		tx.Error = err.Error()
+		if tx.Attempts >= config.C().Chain.MaxAttempts {
+			tx.Status = model.StatusFailed
+		} else {
+			tx.NextAttemptAt = time.Now().Add(schedule.Backoff(tx.Attempts,
+				time.Duration(config.C().Chain.BackoffInSec)*time.Second,
+				time.Duration(config.C().Chain.MaxBackoffInSec)*time.Second))
+		}
+		if err := s.chainRepository.Update(c, tx); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("chain transaction %s failed attempt %d: %w", tx.ID, tx.Attempts, err)
+	}
+
+	tx.Status = model.StatusSubmitted
+	tx.TxHash = submission.TxHash
+	tx.Contract = submission.Contract
+	tx.Error = ""
+	tx.NextAttemptAt = time.Now()
+	return s.chainRepository.Update(c, tx)
+}
+
+func (s ChainService) deploy(c context.Context, tx model.ChainTx) (model.Submission, error) {
+	collection, err := s.collectionRepository.Get(c, persist.D{"id": tx.AssetId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+
+	return s.chainClient.DeployCollection(c, model.Deploy{Name: collection.Title})
+}
+
+// mint puts the nft in the contract of its collection once that is
+// deployed, in the marketplace contract otherwise.
+func (s ChainService) mint(c context.Context, tx model.ChainTx) (model.Submission, error) {
+	nft, err := s.nftRepository.Get(c, persist.D{"id": tx.AssetId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+
+	contractAddress := config.C().Chain.MarketplaceContract
+	if nft.CollectionId != nil {
+		collection, err := s.collectionRepository.Get(c, persist.D{"id": *nft.CollectionId})
+		if err != nil {
+			return model.Submission{}, err
+		}
+		if len(collection.ContractAddress) > 0 {
+			contractAddress = collection.ContractAddress
+		}
+	}
+
+	recipient, err := s.userRepository.Get(c, persist.D{"id": *tx.RecipientId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+
+	return s.chainClient.Mint(c, model.Mint{Contract: contractAddress, To: recipient.PublicKey})
+}
+
+func (s ChainService) transfer(c context.Context, tx model.ChainTx) (model.Submission, error) {
+	nft, err := s.nftRepository.Get(c, persist.D{"id": tx.AssetId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+	if len(nft.TokenId) == 0 {
+		return model.Submission{}, apperrors.ErrChainAssetNotMinted
+	}
+
+	sender, err := s.userRepository.Get(c, persist.D{"id": *tx.SenderId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+
+	recipient, err := s.userRepository.Get(c, persist.D{"id": *tx.RecipientId})
+	if err != nil {
+		return model.Submission{}, err
+	}
+
+	return s.chainClient.Transfer(c, model.Transfer{
+		Contract: nft.ContractAddress,
+		TokenId:  nft.TokenId,
+		From:     sender.PublicKey,
+		To:       recipient.PublicKey,
+	})
+}
+
+// poll records the outcome of a submitted transaction once it is mined, and
+// what it changed on the asset when it succeeded. One still pending is
+// released to be polled again; one whose receipt can't be read waits for
+// its lease to run out.
+func (s ChainService) poll(c context.Context, tx model.ChainTx) error {
+	span, c := jtrace.T().SpanFromContext(c, "ChainService[poll]")
+	defer span.Finish()
+
+	receipt, err := s.chainClient.GetReceipt(c, tx.TxHash)
+	if err != nil {
+		return err
+	}
+
+	switch receipt.Status {
+	case model.ReceiptPending:
+		tx.NextAttemptAt = time.Now()
+		return s.chainRepository.Update(c, tx)
+	case model.ReceiptFailed:
+		tx.Status = model.StatusFailed
+		tx.Error = receipt.Error
+		return s.chainRepository.Update(c, tx)
+	}
+
+	return s.db.Transaction(c, func(c context.Context) error {
+		tx.Status = model.StatusConfirmed
+		tx.TokenId = receipt.TokenId
+		if len(receipt.Contract) > 0 {
+			tx.Contract = receipt.Contract
+		}
+		if err := s.chainRepository.Update(c, tx); err != nil {
+			return err
+		}
+
+		switch tx.Kind {
+		case model.KindDeploy:
+			return s.collectionRepository.UpdateContract(c, collectionmodel.Collection{ID: &tx.AssetId, ContractAddress: tx.Contract})
+		case model.KindMint:
+			return s.nftRepository.UpdateToken(c, nftmodel.Nft{ID: &tx.AssetId, ContractAddress: tx.Contract, TokenId: tx.TokenId})
+		case model.KindTransfer:
+			if tx.TransactionId == nil {
+				return nil
+			}
+			return s.transactionRepository.UpdateChain(c, txmodel.Transaction{ID: tx.TransactionId, ContractAddress: tx.Contract, TransactionId: tx.TxHash})
+		}
+		return nil
+	})
+}
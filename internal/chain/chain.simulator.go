@@ -0,0 +1,178 @@
+package chain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	apperrors "nft/error"
+	"nft/internal/chain/model"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Simulator is an in-process chain for running the marketplace offline.
+// Submitted transactions wait in a mempool until the next block, which is
+// mined when a receipt is asked for and the block time has passed since the
+// last one.
+type Simulator struct {
+	mu        sync.Mutex
+	blockTime time.Duration
+	minedAt   time.Time
+	block     uint64
+	contracts map[string]*simContract
+	mempool   []simTx
+	receipts  map[string]model.Receipt
+}
+
+type simContract struct {
+	owners    map[string]string
+	lastToken uint64
+}
+
+type simTx struct {
+	hash  string
+	apply func() (model.Receipt, error)
+}
+
+// NewSimulator starts a chain with the given contracts already deployed.
+func NewSimulator(blockTime time.Duration, contracts ...string) *Simulator {
+	s := &Simulator{
+		blockTime: blockTime,
+		minedAt:   time.Now(),
+		contracts: make(map[string]*simContract),
+		receipts:  make(map[string]model.Receipt),
+	}
+	for _, address := range contracts {
+		s.contracts[address] = &simContract{owners: make(map[string]string)}
+	}
+	return s
+}
+
+func (s *Simulator) DeployCollection(_ context.Context, m model.Deploy) (model.Submission, error) {
+	address := "0x" + randomHex(20)
+	hash := s.submit(func() (model.Receipt, error) {
+		s.contracts[address] = &simContract{owners: make(map[string]string)}
+		return model.Receipt{Contract: address}, nil
+	})
+
+	return model.Submission{TxHash: hash, Contract: address}, nil
+}
+
+func (s *Simulator) Mint(_ context.Context, m model.Mint) (model.Submission, error) {
+	hash := s.submit(func() (model.Receipt, error) {
+		contract, ok := s.contracts[m.Contract]
+		if !ok {
+			return model.Receipt{}, apperrors.ErrChainContractNotFound
+		}
+
+		contract.lastToken++
+		tokenId := strconv.FormatUint(contract.lastToken, 10)
+		contract.owners[tokenId] = m.To
+		return model.Receipt{Contract: m.Contract, TokenId: tokenId}, nil
+	})
+
+	return model.Submission{TxHash: hash, Contract: m.Contract}, nil
+}
+
+func (s *Simulator) Transfer(_ context.Context, m model.Transfer) (model.Submission, error) {
+	hash := s.submit(func() (model.Receipt, error) {
+		contract, ok := s.contracts[m.Contract]
+		if !ok {
+			return model.Receipt{}, apperrors.ErrChainContractNotFound
+		}
+
+		owner, ok := contract.owners[m.TokenId]
+		if !ok {
+			return model.Receipt{}, apperrors.ErrChainTokenNotFound
+		}
+		if owner != m.From {
+			return model.Receipt{}, apperrors.ErrChainTokenNotOwned
+		}
+
+		contract.owners[m.TokenId] = m.To
+		return model.Receipt{Contract: m.Contract, TokenId: m.TokenId}, nil
+	})
+
+	return model.Submission{TxHash: hash, Contract: m.Contract}, nil
+}
+
+func (s *Simulator) GetReceipt(_ context.Context, txHash string) (model.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.minedAt) >= s.blockTime {
+		s.mine()
+	}
+
+	if receipt, ok := s.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	for _, tx := range s.mempool {
+		if tx.hash == txHash {
+			return model.Receipt{TxHash: txHash, Status: model.ReceiptPending}, nil
+		}
+	}
+
+	return model.Receipt{}, apperrors.ErrChainTxNotFound
+}
+
+// Mine puts the transactions waiting in the mempool in a new block right
+// away.
+func (s *Simulator) Mine() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mine()
+}
+
+// OwnerOf returns the address holding the token of the contract.
+func (s *Simulator) OwnerOf(contract string, tokenId string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.contracts[contract]
+	if !ok {
+		return "", false
+	}
+	owner, ok := c.owners[tokenId]
+	return owner, ok
+}
+
+func (s *Simulator) submit(apply func() (model.Receipt, error)) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := "0x" + randomHex(32)
+	s.mempool = append(s.mempool, simTx{hash: hash, apply: apply})
+	return hash
+}
+
+// mine applies the mempool in submission order. A failing transaction
+// changes nothing but is still mined with its error.
+func (s *Simulator) mine() {
+	s.minedAt = time.Now()
+	if len(s.mempool) == 0 {
+		return
+	}
+
+	s.block++
+	for _, tx := range s.mempool {
+		receipt, err := tx.apply()
+		receipt.TxHash = tx.hash
+		receipt.BlockNumber = s.block
+		receipt.Status = model.ReceiptSuccess
+		if err != nil {
+			receipt.Status = model.ReceiptFailed
+			receipt.Error = err.Error()
+		}
+		s.receipts[tx.hash] = receipt
+	}
+	s.mempool = nil
+}
+
+func randomHex(size int) string {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,84 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	apperrors "nft/error"
+	"nft/internal/chain/model"
+	"testing"
+	"time"
+)
+
+func TestSimulator(t *testing.T) {
+	c := context.Background()
+	s := NewSimulator(time.Hour)
+
+	deploy, err := s.DeployCollection(c, model.Deploy{Name: "collection"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint, err := s.Mint(c, model.Mint{Contract: deploy.Contract, To: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is mined before the block time passes
+	receipt, err := s.GetReceipt(c, mint.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != model.ReceiptPending {
+		t.Fatalf("receipt before mining = %+v", receipt)
+	}
+
+	s.Mine()
+
+	receipt, err = s.GetReceipt(c, deploy.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != model.ReceiptSuccess || receipt.Contract != deploy.Contract || receipt.BlockNumber != 1 {
+		t.Errorf("deploy receipt = %+v", receipt)
+	}
+
+	receipt, err = s.GetReceipt(c, mint.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != model.ReceiptSuccess || receipt.TokenId != "1" {
+		t.Fatalf("mint receipt = %+v", receipt)
+	}
+
+	transfer, _ := s.Transfer(c, model.Transfer{Contract: deploy.Contract, TokenId: "1", From: "alice", To: "bob"})
+	stolen, _ := s.Transfer(c, model.Transfer{Contract: deploy.Contract, TokenId: "1", From: "alice", To: "eve"})
+	s.Mine()
+
+	if receipt, _ := s.GetReceipt(c, transfer.TxHash); receipt.Status != model.ReceiptSuccess {
+		t.Errorf("transfer receipt = %+v", receipt)
+	}
+	if receipt, _ := s.GetReceipt(c, stolen.TxHash); receipt.Status != model.ReceiptFailed {
+		t.Errorf("transfer by previous owner receipt = %+v", receipt)
+	}
+	if owner, _ := s.OwnerOf(deploy.Contract, "1"); owner != "bob" {
+		t.Errorf("OwnerOf() = %s, want bob", owner)
+	}
+
+	if _, err := s.GetReceipt(c, "0x0"); !errors.Is(err, apperrors.ErrChainTxNotFound) {
+		t.Errorf("GetReceipt() of unknown tx error = %v", err)
+	}
+}
+
+func TestSimulatorUnknownContract(t *testing.T) {
+	c := context.Background()
+	s := NewSimulator(0, "marketplace")
+
+	missing, _ := s.Mint(c, model.Mint{Contract: "missing", To: "alice"})
+	minted, _ := s.Mint(c, model.Mint{Contract: "marketplace", To: "alice"})
+
+	if receipt, _ := s.GetReceipt(c, missing.TxHash); receipt.Status != model.ReceiptFailed {
+		t.Errorf("mint on unknown contract receipt = %+v", receipt)
+	}
+	if receipt, _ := s.GetReceipt(c, minted.TxHash); receipt.Status != model.ReceiptSuccess {
+		t.Errorf("mint on preloaded contract receipt = %+v", receipt)
+	}
+}
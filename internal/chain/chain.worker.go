@@ -0,0 +1,18 @@
+package chain
+
+import (
+	"nft/config"
+	"nft/contract"
+	"nft/pkg/schedule"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// StartChainWorker periodically submits the queued chain transactions and
+// follows up on their receipts for the lifetime of the application.
+func StartChainWorker(lc fx.Lifecycle, chainService contract.IChainService) {
+	schedule.Every(lc, "chain worker", func() time.Duration {
+		return time.Duration(config.C().Chain.WorkerIntervalInSec) * time.Second
+	}, chainService.Process)
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ChainTx struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	Kind          string     `gorm:"index"`
+	Status        string     `gorm:"index"`
+	AssetId       uuid.UUID  `gorm:"type:uuid;index"`
+	SenderId      *uuid.UUID `gorm:"type:uuid"`
+	RecipientId   *uuid.UUID `gorm:"type:uuid"`
+	TransactionId *uuid.UUID `gorm:"type:uuid"`
+	TxHash        string     `gorm:"index"`
+	Contract      string
+	TokenId       string
+	Error         *sql.NullString
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ChainTx is a change the marketplace makes on chain. It is queued with the
+// change in the database and submitted to the chain by the worker, which
+// then waits for its receipt. A worker claims it until NextAttemptAt, and a
+// submission that keeps failing fails it once it runs out of attempts.
+type ChainTx struct {
+	ID            *uuid.UUID
+	CreatedAt     time.Time
+	Kind          Kind
+	Status        Status
+	AssetId       uuid.UUID
+	SenderId      *uuid.UUID
+	RecipientId   *uuid.UUID
+	TransactionId *uuid.UUID
+	TxHash        string
+	Contract      string
+	TokenId       string
+	Error         string
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+type Kind string
+
+const (
+	KindDeploy   Kind = "deploy"
+	KindMint     Kind = "mint"
+	KindTransfer Kind = "transfer"
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusSubmitted Status = "submitted"
+	StatusConfirmed Status = "confirmed"
+	StatusFailed    Status = "failed"
+)
+
+// Deploy asks for a new contract for a collection.
+type Deploy struct {
+	Name string
+}
+
+// Mint asks for a new token of the contract, owned by the address.
+type Mint struct {
+	Contract string
+	To       string
+}
+
+// Transfer asks to move a token of the contract between addresses.
+type Transfer struct {
+	Contract string
+	TokenId  string
+	From     string
+	To       string
+}
+
+// Submission is what the chain tells right away about a transaction it
+// accepted. A deploy knows the address of its contract before it is mined.
+type Submission struct {
+	TxHash   string
+	Contract string
+}
+
+type ReceiptStatus string
+
+const (
+	ReceiptPending ReceiptStatus = "pending"
+	ReceiptSuccess ReceiptStatus = "success"
+	ReceiptFailed  ReceiptStatus = "failed"
+)
+
+// Receipt is the outcome of a transaction once it is mined. A successful
+// mint carries the id of its token.
+type Receipt struct {
+	TxHash      string
+	Status      ReceiptStatus
+	BlockNumber uint64
+	Contract    string
+	TokenId     string
+	Error       string
+}
@@ -12,8 +12,10 @@ import (
 	"nft/infra/jtrace"
 	dto "nft/internal/collection/dto"
 	model "nft/internal/collection/model"
+	nftdto "nft/internal/nft/dto"
 	usermodel "nft/internal/user/model"
 	"nft/pkg/filper"
+	"nft/pkg/validator"
 )
 
 type CollectionController struct {
@@ -37,12 +39,13 @@ func NewCollectionController(params CollectionControllerParams) contract.ICollec
 // @Accept   multipart/form-data
 // @Produce  json
 // @Router   /v1/collection [post]
-// @Param    id            formData  string   false  "Collection id. Required for updating draft"
-// @Param    title         formData  string   false  "Collection title. Not required for draft"
-// @Param    description   formData  string   false  "Collection description. Not required for draft"
-// @Param    draft         formData  boolean  true   "Collection submission type. If it's true it will be saved as draft. If it's false it will be submitted to be processed."
-// @Param    category_id   formData  array    false  "Collection category or sub category id."
-// @Param    header_image  formData  file     true   "Collection header image"
+// @Param    id               formData  string   false  "Collection id. Required for updating draft"
+// @Param    title            formData  string   false  "Collection title. Not required for draft"
+// @Param    description      formData  string   false  "Collection description. Not required for draft"
+// @Param    draft            formData  boolean  true   "Collection submission type. If it's true it will be saved as draft. If it's false it will be submitted to be processed."
+// @Param    category_id      formData  array    false  "Collection category or sub category id."
+// @Param    header_image     formData  file     true   "Collection header image"
+// @Param    royalty_percent  formData  number   false  "Creator royalty on resales, capped by the platform maximum"
 func (co CollectionController) Add(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "CollectionController[Add]")
 	defer span.Finish()
@@ -70,6 +73,8 @@ func (co CollectionController) Add(c *fiber.Ctx) error {
 	if err != nil {
 		if errors.Is(err, apperrors.ErrInvalidFileExtension) {
 			return filper.GetBadRequestError(c, err.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidRoyalty) {
+			return filper.GetBadRequestError(c, err.Error())
 		}
 		log.Println(err)
 		return filper.GetInternalError(c, "")
@@ -174,3 +179,84 @@ func (co CollectionController) Delete(c *fiber.Ctx) error {
 
 	return filper.GetSuccessResponse(c, "collection deleted successfully")
 }
+
+// SetRoyalty godoc
+// @Summary  set creator royalty of collection
+// @Tags     collection
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string       true  "collection id"
+// @Param    message  body      dto.Royalty  true  "royalty percent"
+// @Success  200      {string}  string       "royalty updated successfully"
+// @Router   /v1/collection/{id}/royalty [post]
+func (co CollectionController) SetRoyalty(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "CollectionController[SetRoyalty]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	collectionId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, "invalid collection id")
+	}
+
+	var request dto.Royalty
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	err = co.collectionService.SetRoyalty(ctx, model.Collection{ID: &collectionId, User: usermodel.User{ID: userId}, RoyaltyPercent: *request.RoyaltyPercent})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrCollectionNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrCollectionNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidRoyalty) {
+			return filper.GetBadRequestError(c, apperrors.ErrInvalidRoyalty.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "royalty updated successfully")
+}
+
+// GetNfts godoc
+// @Summary  get nfts in collection
+// @Tags     collection
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "collection id"
+// @Success  200  {object}  nftdto.NftList
+// @Router   /v1/collection/{id}/nfts [get]
+func (co CollectionController) GetNfts(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "CollectionController[GetNfts]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	collectionId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidCollectionId.Error())
+	}
+
+	nfts, err := co.collectionService.GetNfts(ctx, model.Collection{ID: &collectionId, User: usermodel.User{ID: userId}})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrCollectionNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrCollectionNotFound.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	var nftList nftdto.NftList = createNftListDtoFromModel(nfts)
+
+	return c.Status(fiber.StatusOK).JSON(nftList)
+}
@@ -3,6 +3,7 @@ package collection
 import (
 	"database/sql"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"io"
 	"mime/multipart"
 	category "nft/internal/category/model"
@@ -10,6 +11,9 @@ import (
 	entity "nft/internal/collection/entity"
 	model "nft/internal/collection/model"
 	file "nft/internal/file/model"
+	nftmapper "nft/internal/nft"
+	nftdto "nft/internal/nft/dto"
+	nftmodel "nft/internal/nft/model"
 	user "nft/internal/user/model"
 	"nft/pkg/validator"
 	"strconv"
@@ -99,6 +103,15 @@ func mapAndValidateAddCollectionForm(form *multipart.Form, userId uuid.UUID) (mo
 		}
 	}
 
+	royalty, ok := form.Value["royalty_percent"]
+	if ok {
+		percent, err := strconv.ParseFloat(royalty[0], 64)
+		if err != nil {
+			errs.AddError("royalty_percent", royalty[0], "invalid royalty percent")
+		}
+		collectionModel.RoyaltyPercent = percent
+	}
+
 	if draft && len(desc) < 1 && len(title) < 1 && collectionModel.HeaderImage == nil {
 		errs.AddError("", nil, "you need to provide a title, description or header image to save draft")
 		return model.Collection{}, errs
@@ -120,6 +133,8 @@ func MapCollectionModelToDto(m model.Collection) dto.Collection {
 	collectionDto.Title = m.Title
 	collectionDto.Description = m.Description
 	collectionDto.Status = string(m.Status)
+	collectionDto.RoyaltyPercent = m.RoyaltyPercent
+	collectionDto.ContractAddress = m.ContractAddress
 
 	return collectionDto
 }
@@ -155,6 +170,9 @@ func mapCollectionEntityToModel(collection entity.Collection) model.Collection {
 	}
 
 	m.Categories = categories
+	m.User = user.User{ID: collection.UserId}
+	m.RoyaltyPercent = collection.RoyaltyPercent
+	m.ContractAddress = collection.ContractAddress
 
 	if collection.Draft {
 		m.Status = model.CollectionStatusDraft
@@ -184,6 +202,7 @@ func mapCollectionModelToEntity(m model.Collection) entity.Collection {
 		status = true
 	}
 	collection.Draft = status
+	collection.RoyaltyPercent = m.RoyaltyPercent
 
 	if m.ID != nil {
 		collection.ID = *m.ID
@@ -211,3 +230,19 @@ func createModelCollectionListFromEntity(collections []entity.Collection) []mode
 	}
 	return nftList
 }
+
+func categoryArray(ids []uuid.UUID) pq.StringArray {
+	catIds := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		catIds[i] = id.String()
+	}
+	return catIds
+}
+
+func createNftListDtoFromModel(nfts []nftmodel.Nft) nftdto.NftList {
+	nftList := make([]nftdto.Nft, len(nfts))
+	for i := range nfts {
+		nftList[i] = nftmapper.MapNftModelToDto(nfts[i])
+	}
+	return nftdto.NftList{Nfts: nftList}
+}
@@ -2,9 +2,11 @@ package collection
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"nft/contract"
+	apperrors "nft/error"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	entity "nft/internal/collection/entity"
@@ -79,6 +81,21 @@ func (cr CollectionRepository) Get(c context.Context, conditions persist.D) (mod
 	return mapCollectionEntityToModel(*category.(*entity.Collection)), nil
 }
 
+func (cr CollectionRepository) Lock(c context.Context, conditions persist.D) (model.Collection, error) {
+	span, c := jtrace.T().SpanFromContext(c, "CollectionRepository[Lock]")
+	defer span.Finish()
+
+	collection, err := cr.db.Lock(c, &entity.Collection{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Collection{}, apperrors.ErrCollectionNotFound
+		}
+		return model.Collection{}, err
+	}
+
+	return mapCollectionEntityToModel(*collection.(*entity.Collection)), nil
+}
+
 func (cr CollectionRepository) GetAll(c context.Context, conditions persist.D) ([]model.Collection, error) {
 	span, c := jtrace.T().SpanFromContext(c, "CollectionRepository[GetAll]")
 	defer span.Finish()
@@ -96,3 +113,25 @@ func (cr CollectionRepository) HardDelete(c context.Context, id uuid.UUID) error
 	defer span.Finish()
 	return cr.db.Delete(c, &entity.Collection{ID: id})
 }
+
+func (cr CollectionRepository) UpdateRoyalty(c context.Context, m model.Collection) error {
+	span, c := jtrace.T().SpanFromContext(c, "CollectionRepository[UpdateRoyalty]")
+	defer span.Finish()
+
+	if _, err := cr.db.Update(c, &entity.Collection{ID: *m.ID}, persist.D{"royalty_percent": m.RoyaltyPercent}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateContract records the address of the contract deployed for the
+// collection.
+func (cr CollectionRepository) UpdateContract(c context.Context, m model.Collection) error {
+	span, c := jtrace.T().SpanFromContext(c, "CollectionRepository[UpdateContract]")
+	defer span.Finish()
+
+	if _, err := cr.db.Update(c, &entity.Collection{ID: *m.ID}, persist.D{"contract_address": m.ContractAddress}); err != nil {
+		return err
+	}
+	return nil
+}
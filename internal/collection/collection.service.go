@@ -3,6 +3,7 @@ package collection
 import (
 	"context"
 	"errors"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"nft/config"
 	"nft/contract"
@@ -10,26 +11,37 @@ import (
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	model "nft/internal/collection/model"
+	nftmodel "nft/internal/nft/model"
+	"nft/pkg/royalty"
 )
 
 type CollectionService struct {
+	db                   contract.IPersist
 	fileService          contract.IFileService
 	collectionRepository contract.ICollectionRepository
 	transactionService   contract.ITransactionService
+	nftService           contract.INftService
+	chainService         contract.IChainService
 }
 
 type CollectionServiceParams struct {
 	fx.In
+	DB                   contract.IPersist
 	CollectionRepository contract.ICollectionRepository
 	FileService          contract.IFileService
 	TransactionService   contract.ITransactionService
+	NftService           contract.INftService
+	ChainService         contract.IChainService
 }
 
 func NewCollectionService(params CollectionServiceParams) contract.ICollectionService {
 	return CollectionService{
+		db:                   params.DB,
 		collectionRepository: params.CollectionRepository,
 		fileService:          params.FileService,
 		transactionService:   params.TransactionService,
+		nftService:           params.NftService,
+		chainService:         params.ChainService,
 	}
 }
 
@@ -37,7 +49,12 @@ func (cs CollectionService) GetCollection(c context.Context, m model.Collection)
 	span, c := jtrace.T().SpanFromContext(c, "CollectionService[GetCollection]")
 	defer span.Finish()
 
-	collection, err := cs.collectionRepository.Get(c, persist.D{"id": m.ID, "user_id": m.User.ID})
+	conditions := persist.D{"id": m.ID}
+	if m.User.ID != uuid.Nil {
+		conditions["user_id"] = m.User.ID
+	}
+
+	collection, err := cs.collectionRepository.Get(c, conditions)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrRecordNotFound) {
 			return model.Collection{}, apperrors.ErrCollectionNotFound
@@ -65,7 +82,15 @@ func (cs CollectionService) GetAllCollections(c context.Context, query model.Que
 	span, c := jtrace.T().SpanFromContext(c, "CollectionService[GetAllCollections]")
 	defer span.Finish()
 
-	collections, err := cs.collectionRepository.GetAll(c, persist.D{"user_id": query.UserId})
+	conditions := persist.D{}
+	if query.UserId != nil {
+		conditions["user_id"] = *query.UserId
+	}
+	if len(query.CategoryIds) > 0 {
+		conditions["category_ids &&"] = categoryArray(query.CategoryIds)
+	}
+
+	collections, err := cs.collectionRepository.GetAll(c, conditions)
 	if err != nil {
 		return nil, err
 	}
@@ -91,22 +116,8 @@ func (cs CollectionService) AddCollection(c context.Context, m model.Collection)
 	span, c := jtrace.T().SpanFromContext(c, "CollectionService[AddCollection]")
 	defer span.Finish()
 
-	if m.Status == model.CollectionStatusDraft {
-		if m.ID != nil {
-			nftModel, err := cs.collectionRepository.Get(c, persist.D{"id": m.ID.String()})
-			if err != nil {
-				return model.Collection{}, apperrors.ErrCollectionDraftNotFound
-			}
-
-			if nftModel.Status != model.CollectionStatusDraft {
-				return model.Collection{}, apperrors.ErrCollectionIsNotDraft
-			}
-
-			err = cs.collectionRepository.HardDelete(c, *m.ID)
-			if err != nil {
-				return model.Collection{}, err
-			}
-		}
+	if !royalty.Valid(m.RoyaltyPercent) {
+		return model.Collection{}, apperrors.ErrInvalidRoyalty
 	}
 
 	if m.HeaderImage != nil {
@@ -118,7 +129,37 @@ func (cs CollectionService) AddCollection(c context.Context, m model.Collection)
 		m.HeaderImage.FileName = nftFileName
 	}
 
-	nftModel, err := cs.collectionRepository.Add(c, m)
+	// the draft it replaces, the collection and the deploy of its contract
+	// are stored together, so a saved collection is never left without one
+	var nftModel model.Collection
+	err := cs.db.Transaction(c, func(c context.Context) error {
+		if m.Status == model.CollectionStatusDraft && m.ID != nil {
+			draft, err := cs.collectionRepository.Get(c, persist.D{"id": m.ID.String()})
+			if err != nil {
+				return apperrors.ErrCollectionDraftNotFound
+			}
+
+			if draft.Status != model.CollectionStatusDraft {
+				return apperrors.ErrCollectionIsNotDraft
+			}
+
+			if err := cs.collectionRepository.HardDelete(c, *m.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		nftModel, err = cs.collectionRepository.Add(c, m)
+		if err != nil {
+			return err
+		}
+
+		// a saved collection gets a contract of its own for the nfts minted in it
+		if nftModel.Status == model.CollectionStatusSaved {
+			return cs.chainService.QueueDeploy(c, nftModel)
+		}
+		return nil
+	})
 	if err != nil {
 		return model.Collection{}, err
 	}
@@ -141,6 +182,8 @@ func (cs CollectionService) DeleteCollection(c context.Context, m model.Collecti
 	return cs.collectionRepository.Delete(c, m)
 }
 
+// GetOwnedCollection returns the collection currently owned by the user, who
+// must also own every nft in it.
 func (cs CollectionService) GetOwnedCollection(c context.Context, m model.Collection) (model.Collection, error) {
 	span, c := jtrace.T().SpanFromContext(c, "CollectionService[GetOwnedCollection]")
 	defer span.Finish()
@@ -153,7 +196,72 @@ func (cs CollectionService) GetOwnedCollection(c context.Context, m model.Collec
 		return model.Collection{}, err
 	}
 
-	//Todo Check all nfts are owned by the user that requested or not
+	ownerId, err := cs.transactionService.GetOwner(c, *col.ID, col.User.ID)
+	if err != nil {
+		return model.Collection{}, err
+	}
+	if ownerId != m.User.ID {
+		return model.Collection{}, apperrors.ErrCollectionNotFound
+	}
+
+	nfts, err := cs.nftService.GetCollectionNfts(c, *col.ID)
+	if err != nil {
+		return model.Collection{}, err
+	}
+
+	if len(nfts) == 0 {
+		return model.Collection{}, apperrors.ErrCollectionEmpty
+	}
+
+	for _, member := range nfts {
+		if _, err := cs.nftService.GetOwnedNft(c, nftmodel.Nft{ID: member.ID, CurrentOwner: &m.User}); err != nil {
+			if errors.Is(err, apperrors.ErrNftNotFound) {
+				return model.Collection{}, apperrors.ErrCollectionNftNotOwned
+			}
+			return model.Collection{}, err
+		}
+	}
 
 	return col, nil
 }
+
+// GetNfts returns the nfts in a collection. A draft collection is only
+// visible to its creator, saved ones to everyone so buyers can see what a
+// collection sale holds.
+func (cs CollectionService) GetNfts(c context.Context, m model.Collection) ([]nftmodel.Nft, error) {
+	span, c := jtrace.T().SpanFromContext(c, "CollectionService[GetNfts]")
+	defer span.Finish()
+
+	col, err := cs.collectionRepository.Get(c, persist.D{"id": *m.ID})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.ErrCollectionNotFound
+		}
+		return nil, err
+	}
+
+	if col.Status == model.CollectionStatusDraft && col.User.ID != m.User.ID {
+		return nil, apperrors.ErrCollectionNotFound
+	}
+
+	return cs.nftService.GetCollectionNfts(c, *m.ID)
+}
+
+// SetRoyalty changes the royalty the creator earns on resales of the collection.
+func (cs CollectionService) SetRoyalty(c context.Context, m model.Collection) error {
+	span, c := jtrace.T().SpanFromContext(c, "CollectionService[SetRoyalty]")
+	defer span.Finish()
+
+	if !royalty.Valid(m.RoyaltyPercent) {
+		return apperrors.ErrInvalidRoyalty
+	}
+
+	if _, err := cs.collectionRepository.Get(c, persist.D{"id": *m.ID, "user_id": m.User.ID}); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return apperrors.ErrCollectionNotFound
+		}
+		return err
+	}
+
+	return cs.collectionRepository.UpdateRoyalty(c, m)
+}
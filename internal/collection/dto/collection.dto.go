@@ -6,13 +6,19 @@ import (
 )
 
 type Collection struct {
-	ID          string               `json:"id,omitempty"`
-	Title       string               `json:"title,omitempty"`
-	Description string               `json:"description,omitempty"`
-	Categories  []catdto.CategoryDto `json:"categories,omitempty"`
-	User        userdto.User         `json:"user,omitempty"`
-	Status      string               `json:"status,omitempty"`
-	HeaderImage string               `json:"header_image,omitempty"`
+	ID              string               `json:"id,omitempty"`
+	Title           string               `json:"title,omitempty"`
+	Description     string               `json:"description,omitempty"`
+	Categories      []catdto.CategoryDto `json:"categories,omitempty"`
+	User            userdto.User         `json:"user,omitempty"`
+	Status          string               `json:"status,omitempty"`
+	HeaderImage     string               `json:"header_image,omitempty"`
+	RoyaltyPercent  float64              `json:"royalty_percent"`
+	ContractAddress string               `json:"contract_address,omitempty"`
+}
+
+type Royalty struct {
+	RoyaltyPercent *float64 `json:"royalty_percent" validate:"required,min=0"`
 }
 
 type CollectionList struct {
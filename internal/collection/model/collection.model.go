@@ -8,13 +8,15 @@ import (
 )
 
 type Collection struct {
-	ID          *uuid.UUID
-	Title       string
-	Description string
-	Categories  []catmodel.Category
-	User        usermodel.User
-	HeaderImage *file.Image
-	Status      CollectionStatus
+	ID              *uuid.UUID
+	Title           string
+	Description     string
+	Categories      []catmodel.Category
+	User            usermodel.User
+	HeaderImage     *file.Image
+	Status          CollectionStatus
+	RoyaltyPercent  float64
+	ContractAddress string
 }
 
 type CollectionStatus string
@@ -5,4 +5,6 @@ import "go.uber.org/fx"
 var Module = fx.Options(
 	fx.Provide(NewEmailRepository),
 	fx.Provide(NewEmailService),
+	fx.Provide(NewSmtpSender),
+	fx.Provide(fx.Annotated{Group: "outbox_handlers", Target: NewEmailHandler}),
 )
@@ -0,0 +1,42 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/fx"
+	"nft/contract"
+	"nft/infra/jtrace"
+	model "nft/internal/email/model"
+)
+
+// EmailHandler sends the messages the email service queued on the outbox.
+type EmailHandler struct {
+	mailSender contract.IMailSender
+}
+
+type EmailHandlerParams struct {
+	fx.In
+	MailSender contract.IMailSender
+}
+
+func NewEmailHandler(params EmailHandlerParams) contract.IOutboxHandler {
+	return &EmailHandler{
+		mailSender: params.MailSender,
+	}
+}
+
+func (e EmailHandler) Topic() string {
+	return model.OutboxTopic
+}
+
+func (e EmailHandler) Handle(c context.Context, payload []byte) error {
+	span, c := jtrace.T().SpanFromContext(c, "EmailHandler[Handle]")
+	defer span.Finish()
+
+	var message model.Message
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+
+	return e.mailSender.Send(c, message)
+}
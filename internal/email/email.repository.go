@@ -80,22 +80,6 @@ func (e EmailRepository) Update(c context.Context, emailModel model.Email) (mode
 	return mapEmailEntityToModel(updatedEmail.(*entity.Email)), nil
 }
 
-func (e EmailRepository) Send(c context.Context, receivers []string, message string) error {
-	span, c := jtrace.T().SpanFromContext(c, "EmailRepository[Send]")
-	defer span.Finish()
-
-	// from := config.C().Smtp.From
-	// password := config.C().Smtp.Password
-	// smtpHost := config.C().Smtp.Host
-	// smtpPort := config.C().Smtp.Port
-
-	// auth := smtp.PlainAuth("", from, password, smtpHost)
-
-	// return smtp.SendMail(smtpHost+":"+smtpPort, auth, from, receivers, []byte(message))
-
-	return nil
-}
-
 func (e EmailRepository) Exists(c context.Context, conditions persist.D) (bool, error) {
 	span, c := jtrace.T().SpanFromContext(c, "EmailRepository[Exists]")
 	defer span.Finish()
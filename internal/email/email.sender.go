@@ -0,0 +1,153 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"nft/config"
+	"nft/contract"
+	"nft/infra/jtrace"
+	model "nft/internal/email/model"
+	"strings"
+	"time"
+)
+
+const sendTimeout = 30 * time.Second
+
+// SmtpSender delivers messages through the SMTP server of config.Smtp,
+// upgrading to TLS when the server offers it and logging in with the
+// sender address when a password is set.
+type SmtpSender struct {
+	host     string
+	port     string
+	from     string
+	password string
+}
+
+func NewSmtpSender() contract.IMailSender {
+	return &SmtpSender{
+		host:     config.C().Smtp.Host,
+		port:     config.C().Smtp.Port,
+		from:     config.C().Smtp.From,
+		password: config.C().Smtp.Password,
+	}
+}
+
+func (s SmtpSender) Send(c context.Context, m model.Message) error {
+	span, _ := jtrace.T().SpanFromContext(c, "SmtpSender[Send]")
+	defer span.Finish()
+
+	// without a server, as in development, messages are only logged
+	if len(s.host) == 0 {
+		log.Printf("smtp is not configured, dropping %q to %v\n", m.Subject, m.To)
+		return nil
+	}
+
+	body, err := s.compose(m)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(s.host, s.port), sendTimeout)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(sendTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok && len(s.password) > 0 {
+		if err := client.Auth(smtp.PlainAuth("", s.from, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	for _, to := range m.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// compose writes the message as a multipart/alternative email, so clients
+// show the html part and fall back to the text one.
+func (s SmtpSender) compose(m model.Message) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", m.Text},
+		{"text/html; charset=UTF-8", m.Html},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", s.from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", m.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,200 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	model "nft/internal/email/model"
+	"nft/pkg/lang"
+	"strings"
+	"testing"
+)
+
+// fakeSmtp is a local SMTP server that accepts every message and hands
+// each one over on received.
+type fakeSmtp struct {
+	listener net.Listener
+	received chan delivery
+}
+
+type delivery struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSmtp(t *testing.T) *fakeSmtp {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeSmtp{listener: listener, received: make(chan delivery, 1)}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeSmtp) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.session(conn)
+	}
+}
+
+func (f *fakeSmtp) session(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	var d delivery
+	reply("220 fake smtp")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			d.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+			reply("250 ok")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			d.to = append(d.to, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+			reply("250 ok")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			d.data = data.String()
+			f.received <- d
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSmtpSender(t *testing.T) {
+	server := newFakeSmtp(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	sender := SmtpSender{host: host, port: port, from: "no-reply@example.com"}
+
+	tests := []struct {
+		language lang.Language
+		subject  string
+		intro    string
+		dir      string
+	}{
+		{lang.EN, "Your verification code", "Use this code to continue:", `dir="ltr"`},
+		{lang.FA, "کد تایید شما", "برای ادامه از این کد استفاده کنید:", `dir="rtl"`},
+	}
+
+	for _, tt := range tests {
+		message, err := render(model.Content{Kind: model.KindOtp, Language: tt.language, Data: model.OtpData{Code: "482913"}})
+		if err != nil {
+			t.Fatalf("render(%s) error = %v", tt.language, err)
+		}
+		message.To = []string{"user@example.com"}
+
+		if err := sender.Send(context.Background(), message); err != nil {
+			t.Fatalf("Send(%s) error = %v", tt.language, err)
+		}
+
+		d := <-server.received
+		if d.from != "no-reply@example.com" || len(d.to) != 1 || d.to[0] != "user@example.com" {
+			t.Errorf("%s: envelope from %q to %v", tt.language, d.from, d.to)
+		}
+
+		parsed, err := mail.ReadMessage(strings.NewReader(d.data))
+		if err != nil {
+			t.Fatalf("%s: ReadMessage() error = %v", tt.language, err)
+		}
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		if err != nil || subject != tt.subject {
+			t.Errorf("%s: subject = %q, %v, want %q", tt.language, subject, err, tt.subject)
+		}
+
+		_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts := multipart.NewReader(parsed.Body, params["boundary"])
+
+		text := readPart(t, parts)
+		if !strings.Contains(text, tt.intro) || !strings.Contains(text, "482913") {
+			t.Errorf("%s: text part = %q", tt.language, text)
+		}
+
+		html := readPart(t, parts)
+		if !strings.Contains(html, tt.dir) || !strings.Contains(html, "482913") {
+			t.Errorf("%s: html part = %q", tt.language, html)
+		}
+	}
+}
+
+func readPart(t *testing.T, parts *multipart.Reader) string {
+	part, err := parts.NextRawPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(quotedprintable.NewReader(part))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestRenderEveryKind(t *testing.T) {
+	contents := []model.Content{
+		{Kind: model.KindKycDecision, Data: model.KycDecisionData{Approved: false, Reason: "blurry photo"}},
+		{Kind: model.KindOfferReceived, Data: model.OfferReceivedData{Price: 12.5}},
+		{Kind: model.KindSaleSold, Data: model.SaleSoldData{Price: 40}},
+		{Kind: model.KindOutbid, Data: model.OutbidData{Price: 41}},
+		{Kind: model.KindOfferAccepted, Data: model.OfferAcceptedData{Price: 40}},
+		{Kind: model.KindSaleExpired, Data: model.SaleExpiredData{}},
+		{Kind: model.KindSaleCanceled, Data: model.SaleCanceledData{}},
+		{Kind: model.KindNftDecision, Data: model.NftDecisionData{Approved: true}},
+	}
+
+	for _, language := range []lang.Language{lang.EN, lang.FA} {
+		for _, content := range contents {
+			content.Language = language
+			message, err := render(content)
+			if err != nil {
+				t.Errorf("render(%s, %s) error = %v", content.Kind, language, err)
+				continue
+			}
+			if message.Subject == "" || message.Text == "" || message.Html == "" {
+				t.Errorf("render(%s, %s) = %+v, want every part", content.Kind, language, message)
+			}
+		}
+	}
+
+	message, _ := render(model.Content{Kind: model.KindKycDecision, Language: lang.EN, Data: model.KycDecisionData{Reason: "blurry photo"}})
+	if !strings.Contains(message.Text, "blurry photo") {
+		t.Errorf("rejection text = %q, want the reason", message.Text)
+	}
+}
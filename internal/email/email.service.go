@@ -3,31 +3,38 @@ package email
 import (
 	"context"
 	"errors"
-	"fmt"
 	"nft/contract"
 	"nft/error"
 	"nft/infra/jtrace"
+	"nft/infra/persist/type"
 	model "nft/internal/email/model"
+	"nft/pkg/lang"
 
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 )
 
 type EmailService struct {
-	otpService      contract.IOtpService
-	emailRepository contract.IEmailRepository
+	otpService             contract.IOtpService
+	emailRepository        contract.IEmailRepository
+	notificationRepository contract.INotificationRepository
+	outboxService          contract.IOutboxService
 }
 
 type EmailServiceParams struct {
 	fx.In
-	OtpService      contract.IOtpService
-	EmailRepository contract.IEmailRepository
+	OtpService             contract.IOtpService
+	EmailRepository        contract.IEmailRepository
+	NotificationRepository contract.INotificationRepository
+	OutboxService          contract.IOutboxService
 }
 
 func NewEmailService(params EmailServiceParams) contract.IEmailService {
 	return EmailService{
-		emailRepository: params.EmailRepository,
-		otpService:      params.OtpService,
+		emailRepository:        params.EmailRepository,
+		otpService:             params.OtpService,
+		notificationRepository: params.NotificationRepository,
+		outboxService:          params.OutboxService,
 	}
 }
 
@@ -77,18 +84,59 @@ func (e EmailService) SendOtpEmail(c context.Context, emailId uint) error {
 	span, c := jtrace.T().SpanFromContext(c, "EmailService[SendOtpEmail]")
 	defer span.Finish()
 
+	emailRecord, err := e.emailRepository.Get(c, persist.D{"id": emailId})
+	if err != nil {
+		return err
+	}
+
 	code, err := e.otpService.NewCode(c, emailId)
 	if err != nil {
 		return err
 	}
 
-	message := fmt.Sprintf("The code is %s", code)
+	return e.send(c, emailRecord.Email, model.Content{Kind: model.KindOtp, Data: model.OtpData{Code: code}})
+}
+
+// SendToUser emails the content to the last verified email of the user,
+// and skips users who have none. Unless the content names its language, it
+// is written in the language the user chose for emails, not the one of the
+// request, which is usually made by someone else.
+func (e EmailService) SendToUser(c context.Context, userId uuid.UUID, content model.Content) error {
+	span, c := jtrace.T().SpanFromContext(c, "EmailService[SendToUser]")
+	defer span.Finish()
+
+	emailRecord, err := e.GetLastVerifiedEmail(c, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if len(content.Language) == 0 {
+		content.Language, err = e.notificationRepository.GetLanguage(c, userId)
+		if err != nil {
+			return err
+		}
+	}
 
-	if err := e.emailRepository.Send(c, []string{}, message); err != nil {
+	return e.send(c, emailRecord.Email, content)
+}
+
+// send renders the content and queues it on the outbox, so it goes out once
+// the transaction of the caller commits.
+func (e EmailService) send(c context.Context, to string, content model.Content) error {
+	if len(content.Language) == 0 {
+		content.Language = lang.Get(c)
+	}
+
+	message, err := render(content)
+	if err != nil {
 		return err
 	}
+	message.To = []string{to}
 
-	return nil
+	return e.outboxService.Enqueue(c, model.OutboxTopic, message)
 }
 
 func (e EmailService) ApproveEmail(c context.Context, userId uuid.UUID, email string) error {
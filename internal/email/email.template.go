@@ -0,0 +1,136 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	model "nft/internal/email/model"
+	"nft/pkg/lang"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// catalog holds the strings of every message by language. A language
+// missing from it falls back to FA, the language of the platform.
+var catalog = map[lang.Language]map[string]string{
+	lang.EN: {
+		"footer":                 "NFT Marketplace",
+		"price":                  "Price:",
+		"sale":                   "Sale:",
+		"otp.subject":            "Your verification code",
+		"otp.intro":              "Use this code to continue:",
+		"otp.ignore":             "If you didn't ask for it, you can ignore this email.",
+		"kyc_decision.subject":   "Your identity verification was reviewed",
+		"kyc_decision.approved":  "Your identity is verified. You can now trade within the limits of your tier.",
+		"kyc_decision.rejected":  "We couldn't verify your identity.",
+		"kyc_decision.reason":    "Reason:",
+		"offer_received.subject": "You received an offer",
+		"offer_received.body":    "Someone made an offer on your sale.",
+		"sale_sold.subject":      "Your sale is sold",
+		"sale_sold.body":         "Your sale was settled and the proceeds were paid to your balance.",
+		"nft":                    "NFT:",
+		"outbid.subject":         "You were outbid",
+		"outbid.body":            "Someone placed a higher bid on an auction you bid on. The amount of your bid is available again.",
+		"offer_accepted.subject": "Your offer was accepted",
+		"offer_accepted.body":    "Your offer was accepted and the asset is yours.",
+		"sale_expired.subject":   "A sale expired",
+		"sale_expired.body":      "The sale ended without being sold. Its open offers were closed and their amounts released.",
+		"sale_canceled.subject":  "A sale was canceled",
+		"sale_canceled.body":     "The seller canceled the sale. Your offer on it was closed and its amount released.",
+		"nft_decision.subject":   "Your NFT was reviewed",
+		"nft_decision.approved":  "Your NFT was approved and is being minted.",
+		"nft_decision.rejected":  "Your NFT wasn't approved.",
+		"nft_decision.reason":    "Reason:",
+	},
+	lang.FA: {
+		"footer":                 "بازار NFT",
+		"price":                  "قیمت:",
+		"sale":                   "فروش:",
+		"otp.subject":            "کد تایید شما",
+		"otp.intro":              "برای ادامه از این کد استفاده کنید:",
+		"otp.ignore":             "اگر این کد را درخواست نکرده‌اید، این ایمیل را نادیده بگیرید.",
+		"kyc_decision.subject":   "احراز هویت شما بررسی شد",
+		"kyc_decision.approved":  "هویت شما تایید شد. اکنون می‌توانید در سقف سطح خود معامله کنید.",
+		"kyc_decision.rejected":  "امکان تایید هویت شما وجود نداشت.",
+		"kyc_decision.reason":    "دلیل:",
+		"offer_received.subject": "پیشنهاد جدیدی دریافت کردید",
+		"offer_received.body":    "برای فروش شما پیشنهادی ثبت شد.",
+		"sale_sold.subject":      "فروش شما انجام شد",
+		"sale_sold.body":         "فروش شما تسویه شد و مبلغ آن به موجودی شما واریز شد.",
+		"nft":                    "NFT:",
+		"outbid.subject":         "پیشنهاد بالاتری ثبت شد",
+		"outbid.body":            "در مزایده‌ای که شرکت کرده بودید پیشنهاد بالاتری ثبت شد. مبلغ پیشنهاد شما دوباره در دسترس است.",
+		"offer_accepted.subject": "پیشنهاد شما پذیرفته شد",
+		"offer_accepted.body":    "پیشنهاد شما پذیرفته شد و دارایی به شما منتقل شد.",
+		"sale_expired.subject":   "مهلت یک فروش تمام شد",
+		"sale_expired.body":      "فروش بدون خریدار به پایان رسید. پیشنهادهای باز آن بسته و مبالغشان آزاد شد.",
+		"sale_canceled.subject":  "یک فروش لغو شد",
+		"sale_canceled.body":     "فروشنده فروش را لغو کرد. پیشنهاد شما بسته و مبلغ آن آزاد شد.",
+		"nft_decision.subject":   "NFT شما بررسی شد",
+		"nft_decision.approved":  "NFT شما تایید شد و در حال ضرب است.",
+		"nft_decision.rejected":  "NFT شما تایید نشد.",
+		"nft_decision.reason":    "دلیل:",
+	},
+}
+
+// render writes the message of the content in its language, from the html
+// and text templates of its kind.
+func render(content model.Content) (model.Message, error) {
+	strs, ok := catalog[content.Language]
+	if !ok {
+		content.Language = lang.FA
+		strs = catalog[lang.FA]
+	}
+
+	funcs := map[string]any{
+		"t": func(key string) string {
+			return strs[key]
+		},
+		"lang": func() string {
+			return string(content.Language)
+		},
+		"dir": func() string {
+			if content.Language == lang.FA {
+				return "rtl"
+			}
+			return "ltr"
+		},
+		"price": func(price float64) string {
+			return strconv.FormatFloat(price, 'f', -1, 64)
+		},
+	}
+
+	htmlTemplate, err := htmltemplate.New("layout").Funcs(funcs).ParseFS(templates,
+		"templates/layout.html.tmpl",
+		fmt.Sprintf("templates/%s.html.tmpl", content.Kind))
+	if err != nil {
+		return model.Message{}, err
+	}
+
+	var html bytes.Buffer
+	if err := htmlTemplate.ExecuteTemplate(&html, "layout", content); err != nil {
+		return model.Message{}, err
+	}
+
+	textName := fmt.Sprintf("%s.txt.tmpl", content.Kind)
+	textTemplate, err := texttemplate.New(textName).Funcs(funcs).ParseFS(templates, "templates/"+textName)
+	if err != nil {
+		return model.Message{}, err
+	}
+
+	var text bytes.Buffer
+	if err := textTemplate.Execute(&text, content.Data); err != nil {
+		return model.Message{}, err
+	}
+
+	return model.Message{
+		Subject: strs[string(content.Kind)+".subject"],
+		Text:    strings.TrimSpace(text.String()),
+		Html:    html.String(),
+	}, nil
+}
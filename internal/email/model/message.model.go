@@ -0,0 +1,83 @@
+package email
+
+import (
+	"github.com/google/uuid"
+	"nft/pkg/lang"
+)
+
+// Kind names a message the platform emails, each with an html and a text
+// template of its own.
+type Kind string
+
+const (
+	KindOtp           Kind = "otp"
+	KindKycDecision   Kind = "kyc_decision"
+	KindOfferReceived Kind = "offer_received"
+	KindSaleSold      Kind = "sale_sold"
+	KindOutbid        Kind = "outbid"
+	KindOfferAccepted Kind = "offer_accepted"
+	KindSaleExpired   Kind = "sale_expired"
+	KindSaleCanceled  Kind = "sale_canceled"
+	KindNftDecision   Kind = "nft_decision"
+)
+
+// OutboxTopic is the outbox topic rendered messages are queued under.
+const OutboxTopic = "email"
+
+// Message is a rendered email, ready to be sent.
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	Html    string
+}
+
+// Content is what a message of the kind says, in the language.
+type Content struct {
+	Kind     Kind
+	Language lang.Language
+	Data     any
+}
+
+type OtpData struct {
+	Code string
+}
+
+type KycDecisionData struct {
+	Approved bool
+	Reason   string
+}
+
+type OfferReceivedData struct {
+	SaleId uuid.UUID
+	Price  float64
+}
+
+type SaleSoldData struct {
+	SaleId uuid.UUID
+	Price  float64
+}
+
+type OutbidData struct {
+	SaleId uuid.UUID
+	Price  float64
+}
+
+type OfferAcceptedData struct {
+	SaleId uuid.UUID
+	Price  float64
+}
+
+type SaleExpiredData struct {
+	SaleId uuid.UUID
+}
+
+type SaleCanceledData struct {
+	SaleId uuid.UUID
+}
+
+type NftDecisionData struct {
+	NftId    uuid.UUID
+	Approved bool
+	Reason   string
+}
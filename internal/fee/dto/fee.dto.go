@@ -0,0 +1,18 @@
+package fee
+
+type Policy struct {
+	KycDiscountPercent float64 `json:"kyc_discount_percent" validate:"min=0,max=100"`
+	Rules              []Rule  `json:"rules" validate:"required,min=1,dive"`
+}
+
+type Rule struct {
+	SaleType  string  `json:"sale_type,omitempty" validate:"omitempty,oneof=p2p auction fixed_price"`
+	AssetType string  `json:"asset_type,omitempty" validate:"omitempty,oneof=nft collection"`
+	MinFee    float64 `json:"min_fee" validate:"min=0"`
+	Tiers     []Tier  `json:"tiers" validate:"required,min=1,dive"`
+}
+
+type Tier struct {
+	FromPrice float64 `json:"from_price" validate:"min=0"`
+	Percent   float64 `json:"percent" validate:"min=0,max=100"`
+}
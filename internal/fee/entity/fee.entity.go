@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// FeePolicy is a fee schedule set by an admin. The latest one overrides the
+// schedule in the config, and older ones are kept as history.
+type FeePolicy struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	CreatedBy uuid.UUID `gorm:"type:uuid"`
+	Policy    string    `gorm:"type:jsonb"`
+}
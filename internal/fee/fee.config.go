@@ -0,0 +1,21 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/fx"
+	"nft/config"
+)
+
+// CheckConfigPolicy fails the start when the fee rules of the config, which
+// apply until an admin sets a policy, don't make a valid policy.
+func CheckConfigPolicy(lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if err := validatePolicy(mapFeeConfigToModel(config.C().Fee)); err != nil {
+				return fmt.Errorf("invalid fee rules in config: %w", err)
+			}
+			return nil
+		},
+	})
+}
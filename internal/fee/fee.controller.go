@@ -0,0 +1,88 @@
+package fee
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	dto "nft/internal/fee/dto"
+	"nft/pkg/filper"
+	"nft/pkg/validator"
+)
+
+type FeeController struct {
+	feeService contract.IFeeService
+}
+
+type FeeControllerParams struct {
+	fx.In
+	FeeService contract.IFeeService
+}
+
+func NewFeeController(params FeeControllerParams) contract.IFeeController {
+	return &FeeController{
+		feeService: params.FeeService,
+	}
+}
+
+// GetPolicy godoc
+// @Summary  get the fee schedule in effect
+// @Tags     fee
+// @Accept   json
+// @Produce  json
+// @Router   /v1/fee/policy [get]
+// @Success  200  {object}  dto.Policy
+func (f FeeController) GetPolicy(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "FeeController[GetPolicy]")
+	defer span.Finish()
+
+	policy, err := f.feeService.GetPolicy(ctx)
+	if err != nil {
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapPolicyModelToDto(policy))
+}
+
+// SetPolicy godoc
+// @Summary  override the fee schedule of the config
+// @Tags     fee
+// @Accept   json
+// @Produce  json
+// @Param    message  body  dto.Policy  true  "fee schedule"
+// @Router   /v1/admin/fee/policy [put]
+// @Success  200  {string}  string  "fee policy updated successfully"
+func (f FeeController) SetPolicy(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "FeeController[SetPolicy]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	var request dto.Policy
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	if err := f.feeService.SetPolicy(ctx, mapPolicyDtoToModel(request), userId); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidFeePolicy) {
+			return filper.GetBadRequestError(c, "tiers should start at zero and be in ascending price order")
+		}
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "fee policy updated successfully")
+}
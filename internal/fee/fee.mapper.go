@@ -0,0 +1,76 @@
+package fee
+
+import (
+	"encoding/json"
+	"nft/config"
+	dto "nft/internal/fee/dto"
+	entity "nft/internal/fee/entity"
+	"nft/internal/fee/model"
+)
+
+func mapFeeConfigToModel(fee config.Fee) model.Policy {
+	policy := model.Policy{KycDiscountPercent: fee.KycDiscountPercent}
+	for _, rule := range fee.Rules {
+		tiers := make([]model.Tier, len(rule.Tiers))
+		for i, tier := range rule.Tiers {
+			tiers[i] = model.Tier{FromPrice: tier.FromPrice, Percent: tier.Percent}
+		}
+		policy.Rules = append(policy.Rules, model.Rule{
+			SaleType:  rule.SaleType,
+			AssetType: rule.AssetType,
+			MinFee:    rule.MinFee,
+			Tiers:     tiers,
+		})
+	}
+	return policy
+}
+
+func mapPolicyEntityToModel(e entity.FeePolicy) (model.Policy, error) {
+	var policy model.Policy
+	if err := json.Unmarshal([]byte(e.Policy), &policy); err != nil {
+		return model.Policy{}, err
+	}
+	return policy, nil
+}
+
+func mapPolicyModelToEntity(m model.Policy) (entity.FeePolicy, error) {
+	policy, err := json.Marshal(m)
+	if err != nil {
+		return entity.FeePolicy{}, err
+	}
+	return entity.FeePolicy{Policy: string(policy)}, nil
+}
+
+func mapPolicyDtoToModel(request dto.Policy) model.Policy {
+	policy := model.Policy{KycDiscountPercent: request.KycDiscountPercent}
+	for _, rule := range request.Rules {
+		tiers := make([]model.Tier, len(rule.Tiers))
+		for i, tier := range rule.Tiers {
+			tiers[i] = model.Tier{FromPrice: tier.FromPrice, Percent: tier.Percent}
+		}
+		policy.Rules = append(policy.Rules, model.Rule{
+			SaleType:  rule.SaleType,
+			AssetType: rule.AssetType,
+			MinFee:    rule.MinFee,
+			Tiers:     tiers,
+		})
+	}
+	return policy
+}
+
+func mapPolicyModelToDto(m model.Policy) dto.Policy {
+	policy := dto.Policy{KycDiscountPercent: m.KycDiscountPercent, Rules: make([]dto.Rule, len(m.Rules))}
+	for i, rule := range m.Rules {
+		tiers := make([]dto.Tier, len(rule.Tiers))
+		for j, tier := range rule.Tiers {
+			tiers[j] = dto.Tier{FromPrice: tier.FromPrice, Percent: tier.Percent}
+		}
+		policy.Rules[i] = dto.Rule{
+			SaleType:  rule.SaleType,
+			AssetType: rule.AssetType,
+			MinFee:    rule.MinFee,
+			Tiers:     tiers,
+		}
+	}
+	return policy
+}
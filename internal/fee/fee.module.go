@@ -0,0 +1,9 @@
+package fee
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewFeeController),
+	fx.Provide(NewFeeService),
+	fx.Provide(NewFeeRepository),
+)
@@ -0,0 +1,89 @@
+package fee
+
+import (
+	apperrors "nft/error"
+	"nft/internal/fee/model"
+)
+
+// feeOf computes the fee of a sale under the policy. The tier percent is
+// discounted for KYC-approved sellers, then the minimum fee of the rule
+// applies, and the fee never exceeds the price.
+func feeOf(policy model.Policy, q model.Quote, kycApproved bool) (float64, error) {
+	rule, ok := matchRule(policy.Rules, q.SaleType, q.AssetType)
+	if !ok {
+		return 0, apperrors.ErrNoFeeRule
+	}
+
+	var percent float64
+	for _, tier := range rule.Tiers {
+		if q.Price >= tier.FromPrice {
+			percent = tier.Percent
+		}
+	}
+
+	fee := q.Price * percent / 100
+	if kycApproved {
+		fee -= fee * policy.KycDiscountPercent / 100
+	}
+	if fee < rule.MinFee {
+		fee = rule.MinFee
+	}
+	if fee > q.Price {
+		fee = q.Price
+	}
+
+	return fee, nil
+}
+
+// matchRule returns the most specific rule for the sale. A rule naming both
+// the sale type and the asset type beats one naming either of them, which
+// beats the catch-all.
+func matchRule(rules []model.Rule, saleType, assetType string) (model.Rule, bool) {
+	best, bestScore := model.Rule{}, -1
+	for _, rule := range rules {
+		score := 0
+		if rule.SaleType != "" {
+			if rule.SaleType != saleType {
+				continue
+			}
+			score++
+		}
+		if rule.AssetType != "" {
+			if rule.AssetType != assetType {
+				continue
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// validatePolicy checks that every rule has tiers in ascending price order
+// starting at zero, and that all percents are within 0 and 100.
+func validatePolicy(policy model.Policy) error {
+	if len(policy.Rules) == 0 {
+		return apperrors.ErrInvalidFeePolicy
+	}
+	if policy.KycDiscountPercent < 0 || policy.KycDiscountPercent > 100 {
+		return apperrors.ErrInvalidFeePolicy
+	}
+
+	for _, rule := range policy.Rules {
+		if rule.MinFee < 0 || len(rule.Tiers) == 0 || rule.Tiers[0].FromPrice != 0 {
+			return apperrors.ErrInvalidFeePolicy
+		}
+		for i, tier := range rule.Tiers {
+			if tier.Percent < 0 || tier.Percent > 100 {
+				return apperrors.ErrInvalidFeePolicy
+			}
+			if i > 0 && tier.FromPrice <= rule.Tiers[i-1].FromPrice {
+				return apperrors.ErrInvalidFeePolicy
+			}
+		}
+	}
+
+	return nil
+}
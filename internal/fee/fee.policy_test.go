@@ -0,0 +1,73 @@
+package fee
+
+import (
+	"errors"
+	apperrors "nft/error"
+	"nft/internal/fee/model"
+	"testing"
+)
+
+func TestFeeOf(t *testing.T) {
+	policy := model.Policy{
+		KycDiscountPercent: 20,
+		Rules: []model.Rule{
+			{MinFee: 1, Tiers: []model.Tier{{FromPrice: 0, Percent: 2.5}, {FromPrice: 1000, Percent: 2}}},
+			{SaleType: "auction", Tiers: []model.Tier{{FromPrice: 0, Percent: 3}}},
+			{SaleType: "auction", AssetType: "collection", Tiers: []model.Tier{{FromPrice: 0, Percent: 4}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		quote       model.Quote
+		kycApproved bool
+		want        float64
+	}{
+		{"first tier", model.Quote{SaleType: "p2p", AssetType: "nft", Price: 200}, false, 5},
+		{"second tier", model.Quote{SaleType: "p2p", AssetType: "nft", Price: 1000}, false, 20},
+		{"kyc discount", model.Quote{SaleType: "p2p", AssetType: "nft", Price: 1000}, true, 16},
+		{"minimum fee", model.Quote{SaleType: "p2p", AssetType: "nft", Price: 10}, false, 1},
+		{"capped at price", model.Quote{SaleType: "p2p", AssetType: "nft", Price: 0.5}, false, 0.5},
+		{"sale type rule", model.Quote{SaleType: "auction", AssetType: "nft", Price: 100}, false, 3},
+		{"most specific rule", model.Quote{SaleType: "auction", AssetType: "collection", Price: 100}, false, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := feeOf(policy, tt.quote, tt.kycApproved)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("feeOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := feeOf(model.Policy{Rules: policy.Rules[1:]}, model.Quote{SaleType: "p2p", Price: 10}, false); !errors.Is(err, apperrors.ErrNoFeeRule) {
+		t.Errorf("feeOf() error = %v, want %v", err, apperrors.ErrNoFeeRule)
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  model.Policy
+		wantErr bool
+	}{
+		{"valid", model.Policy{Rules: []model.Rule{{Tiers: []model.Tier{{FromPrice: 0, Percent: 2}, {FromPrice: 100, Percent: 1}}}}}, false},
+		{"no rules", model.Policy{}, true},
+		{"no tiers", model.Policy{Rules: []model.Rule{{}}}, true},
+		{"first tier above zero", model.Policy{Rules: []model.Rule{{Tiers: []model.Tier{{FromPrice: 10, Percent: 2}}}}}, true},
+		{"unordered tiers", model.Policy{Rules: []model.Rule{{Tiers: []model.Tier{{FromPrice: 0, Percent: 2}, {FromPrice: 0, Percent: 1}}}}}, true},
+		{"percent above hundred", model.Policy{Rules: []model.Rule{{Tiers: []model.Tier{{FromPrice: 0, Percent: 120}}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("validatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
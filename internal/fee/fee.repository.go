@@ -0,0 +1,61 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	entity "nft/internal/fee/entity"
+	"nft/internal/fee/model"
+)
+
+type FeeRepository struct {
+	db contract.IPersist
+}
+
+type FeeRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewFeeRepository(params FeeRepositoryParams) contract.IFeeRepository {
+	return &FeeRepository{
+		db: params.DB,
+	}
+}
+
+func (f FeeRepository) Last(c context.Context) (model.Policy, error) {
+	span, c := jtrace.T().SpanFromContext(c, "FeeRepository[Last]")
+	defer span.Finish()
+
+	policy, err := f.db.Last(c, &entity.FeePolicy{}, persist.D{})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Policy{}, apperrors.ErrFeePolicyNotFound
+		}
+		return model.Policy{}, err
+	}
+
+	return mapPolicyEntityToModel(*policy.(*entity.FeePolicy))
+}
+
+func (f FeeRepository) Add(c context.Context, m model.Policy, createdBy uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "FeeRepository[Add]")
+	defer span.Finish()
+
+	policyEntity, err := mapPolicyModelToEntity(m)
+	if err != nil {
+		return err
+	}
+	policyEntity.ID = uuid.New()
+	policyEntity.CreatedBy = createdBy
+
+	if _, err := f.db.Create(c, &policyEntity); err != nil {
+		return err
+	}
+	return nil
+}
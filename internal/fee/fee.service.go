@@ -0,0 +1,78 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/fee/model"
+)
+
+type FeeService struct {
+	feeRepository contract.IFeeRepository
+	kycService    contract.IKycService
+}
+
+type FeeServiceParams struct {
+	fx.In
+	FeeRepository contract.IFeeRepository
+	KycService    contract.IKycService
+}
+
+func NewFeeService(params FeeServiceParams) contract.IFeeService {
+	return &FeeService{
+		feeRepository: params.FeeRepository,
+		kycService:    params.KycService,
+	}
+}
+
+// GetPolicy returns the fee schedule in effect: the latest one set by an
+// admin, or the one in the config if no admin has set any.
+func (f FeeService) GetPolicy(c context.Context) (model.Policy, error) {
+	span, c := jtrace.T().SpanFromContext(c, "FeeService[GetPolicy]")
+	defer span.Finish()
+
+	policy, err := f.feeRepository.Last(c)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrFeePolicyNotFound) {
+			return mapFeeConfigToModel(config.C().Fee), nil
+		}
+		return model.Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (f FeeService) SetPolicy(c context.Context, m model.Policy, adminId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "FeeService[SetPolicy]")
+	defer span.Finish()
+
+	if err := validatePolicy(m); err != nil {
+		return err
+	}
+
+	return f.feeRepository.Add(c, m, adminId)
+}
+
+// Quote computes the platform fee the seller pays when the sale settles at
+// the quoted price.
+func (f FeeService) Quote(c context.Context, q model.Quote) (float64, error) {
+	span, c := jtrace.T().SpanFromContext(c, "FeeService[Quote]")
+	defer span.Finish()
+
+	policy, err := f.GetPolicy(c)
+	if err != nil {
+		return 0, err
+	}
+
+	kycApproved, err := f.kycService.IsApproved(c, q.SellerId)
+	if err != nil {
+		return 0, err
+	}
+
+	return feeOf(policy, q, kycApproved)
+}
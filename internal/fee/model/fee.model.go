@@ -0,0 +1,30 @@
+package model
+
+import "github.com/google/uuid"
+
+// Policy is the fee schedule of the marketplace. A rule with an empty sale
+// type or asset type matches any, and the most specific matching rule wins.
+type Policy struct {
+	KycDiscountPercent float64
+	Rules              []Rule
+}
+
+type Rule struct {
+	SaleType  string
+	AssetType string
+	MinFee    float64
+	Tiers     []Tier
+}
+
+// Tier sets the fee percent of prices from FromPrice up to the next tier.
+type Tier struct {
+	FromPrice float64
+	Percent   float64
+}
+
+type Quote struct {
+	SaleType  string
+	AssetType string
+	Price     float64
+	SellerId  uuid.UUID
+}
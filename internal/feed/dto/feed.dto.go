@@ -0,0 +1,27 @@
+package dto
+
+import "encoding/json"
+
+// Command is what a client sends over the socket to change what it
+// listens to. A sale subscription needs the sale id; the account one is
+// always the account of the token.
+type Command struct {
+	Action string `json:"action" validate:"required,oneof=subscribe unsubscribe"`
+	Topic  string `json:"topic" validate:"required,oneof=sale account"`
+	SaleId string `json:"sale_id" validate:"omitempty,uuid"`
+}
+
+// Reply answers a command, with the error it failed with if any.
+type Reply struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+	SaleId string `json:"sale_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Event is a marketplace event pushed to the client, its data as published
+// on the subject.
+type Event struct {
+	Subject string          `json:"subject"`
+	Data    json.RawMessage `json:"data"`
+}
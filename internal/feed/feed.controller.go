@@ -0,0 +1,188 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/feed/dto"
+	"nft/internal/feed/model"
+	"nft/pkg/filper"
+	"nft/pkg/validator"
+	"sync"
+	"time"
+)
+
+const (
+	// pingInterval is how often the connection is pinged, well within
+	// pongWait so a live client always answers in time.
+	pingInterval = 30 * time.Second
+	pongWait     = 60 * time.Second
+	writeWait    = 10 * time.Second
+)
+
+type FeedController struct {
+	feedService contract.IFeedService
+}
+
+type FeedControllerParams struct {
+	fx.In
+	FeedService contract.IFeedService
+}
+
+func NewFeedController(params FeedControllerParams) contract.IFeedController {
+	return &FeedController{
+		feedService: params.FeedService,
+	}
+}
+
+// Upgrade godoc
+// @Summary  open the real-time feed of sale and account activity over a websocket
+// @Tags     feed
+// @Param    token  query  string  false  "jwt, for clients that can't set the authorization header"
+// @Success  101
+// @Router   /v1/feed [get]
+func (f FeedController) Upgrade(c *fiber.Ctx) error {
+	span, _ := jtrace.T().SpanFromContext(c.Context(), "FeedController[Upgrade]")
+	defer span.Finish()
+
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Next()
+}
+
+// Connect serves a client of the feed. Clients send commands to subscribe
+// to sales or to their account, and are pushed the events of their topics
+// until they disconnect.
+func (f FeedController) Connect(conn *websocket.Conn) {
+	ctx := context.Background()
+	userId := conn.Locals("user_id").(uuid.UUID)
+
+	client := f.feedService.Connect(ctx, userId)
+
+	// replies and events are written from different goroutines
+	var mu sync.Mutex
+	write := func(v any) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(v)
+	}
+
+	// the connection is released once Connect returns, so it waits for
+	// the pushing to end, which disconnecting the client does
+	pushed := make(chan struct{})
+	go func() {
+		defer close(pushed)
+		f.push(conn, client, write)
+	}()
+	defer func() {
+		f.feedService.Disconnect(ctx, client.ID)
+		<-pushed
+	}()
+
+	if err := conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Println(err)
+			}
+			return
+		}
+
+		var reply any
+		var command dto.Command
+		if err := json.Unmarshal(message, &command); err != nil {
+			reply = fiber.Map{"message": "invalid body data"}
+		} else {
+			reply = f.handle(ctx, client, command)
+		}
+
+		if err := write(reply); err != nil {
+			return
+		}
+	}
+}
+
+// handle runs the command of the client and returns the reply to it.
+func (f FeedController) handle(c context.Context, client model.Client, command dto.Command) any {
+	span, c := jtrace.T().SpanFromContext(c, "FeedController[handle]")
+	defer span.Finish()
+
+	errRes := validator.Validate(command)
+	if len(errRes.Errors) > 0 {
+		return errRes
+	}
+
+	subscription := model.Subscription{Kind: model.SubscriptionKind(command.Topic)}
+	if len(command.SaleId) > 0 {
+		saleId := uuid.MustParse(command.SaleId)
+		subscription.SaleId = &saleId
+	}
+
+	var err error
+	if command.Action == "subscribe" {
+		err = f.feedService.Subscribe(c, client.ID, subscription)
+	} else {
+		err = f.feedService.Unsubscribe(c, client.ID, subscription)
+	}
+
+	reply := dto.Reply{Action: command.Action, Topic: command.Topic, SaleId: command.SaleId}
+	if err != nil {
+		if errors.Is(err, apperrors.ErrSaleNotFound) || errors.Is(err, apperrors.ErrFeedSaleIdRequired) {
+			reply.Error = err.Error()
+		} else {
+			log.Println(err)
+			reply.Error = "internal error"
+		}
+	}
+	return reply
+}
+
+// push writes the events of the client to the connection and keeps it
+// alive with pings. Once the feed disconnects the client, the connection
+// is closed too, which ends the read loop of Connect.
+func (f FeedController) push(conn *websocket.Conn, client model.Client, write func(v any) error) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	defer conn.Close()
+
+	for {
+		select {
+		case m, ok := <-client.Messages:
+			if !ok {
+				return
+			}
+			if err := write(dto.Event{Subject: m.Subject, Data: m.Data}); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package feed
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewFeedController),
+	fx.Provide(NewFeedService),
+)
@@ -0,0 +1,207 @@
+package feed
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	persist "nft/infra/persist/type"
+	"nft/internal/feed/model"
+	"sync"
+)
+
+// bufferSize is how many messages a client may fall behind by before it
+// is disconnected, so a slow client can't hold up the others.
+const bufferSize = 64
+
+type client struct {
+	userId   uuid.UUID
+	messages chan model.Message
+	topics   map[model.Topic]struct{}
+}
+
+type FeedService struct {
+	saleRepository contract.ISaleRepository
+
+	mu      *sync.RWMutex
+	clients map[uuid.UUID]*client
+	topics  map[model.Topic]map[uuid.UUID]*client
+}
+
+type FeedServiceParams struct {
+	fx.In
+	SaleRepository contract.ISaleRepository
+}
+
+func NewFeedService(params FeedServiceParams) contract.IFeedService {
+	return &FeedService{
+		saleRepository: params.SaleRepository,
+		mu:             &sync.RWMutex{},
+		clients:        map[uuid.UUID]*client{},
+		topics:         map[model.Topic]map[uuid.UUID]*client{},
+	}
+}
+
+func (f FeedService) Connect(c context.Context, userId uuid.UUID) model.Client {
+	span, _ := jtrace.T().SpanFromContext(c, "FeedService[Connect]")
+	defer span.Finish()
+
+	cl := &client{
+		userId:   userId,
+		messages: make(chan model.Message, bufferSize),
+		topics:   map[model.Topic]struct{}{},
+	}
+	id := uuid.New()
+
+	f.mu.Lock()
+	f.clients[id] = cl
+	f.mu.Unlock()
+
+	return model.Client{ID: id, UserId: userId, Messages: cl.messages}
+}
+
+func (f FeedService) Disconnect(c context.Context, clientId uuid.UUID) {
+	span, _ := jtrace.T().SpanFromContext(c, "FeedService[Disconnect]")
+	defer span.Finish()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.remove(clientId)
+}
+
+// Subscribe adds the topic of the subscription to the client. Only sales
+// that exist can be followed.
+func (f FeedService) Subscribe(c context.Context, clientId uuid.UUID, m model.Subscription) error {
+	span, c := jtrace.T().SpanFromContext(c, "FeedService[Subscribe]")
+	defer span.Finish()
+
+	topic, err := f.topic(clientId, m)
+	if err != nil {
+		return err
+	}
+
+	if m.Kind == model.SubscriptionKindSale {
+		if _, err := f.saleRepository.Get(c, persist.D{"id": *m.SaleId}); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cl, ok := f.clients[clientId]
+	if !ok {
+		return apperrors.ErrFeedClientNotFound
+	}
+
+	cl.topics[topic] = struct{}{}
+	if f.topics[topic] == nil {
+		f.topics[topic] = map[uuid.UUID]*client{}
+	}
+	f.topics[topic][clientId] = cl
+
+	return nil
+}
+
+func (f FeedService) Unsubscribe(c context.Context, clientId uuid.UUID, m model.Subscription) error {
+	span, _ := jtrace.T().SpanFromContext(c, "FeedService[Unsubscribe]")
+	defer span.Finish()
+
+	topic, err := f.topic(clientId, m)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cl, ok := f.clients[clientId]
+	if !ok {
+		return apperrors.ErrFeedClientNotFound
+	}
+
+	delete(cl.topics, topic)
+	f.leave(topic, clientId)
+
+	return nil
+}
+
+// Dispatch pushes a published event to every client subscribed to one of
+// its topics. A client that can't keep up is disconnected rather than
+// waited for.
+func (f FeedService) Dispatch(subject string, data []byte) {
+	topics, err := eventTopics(data)
+	if err != nil {
+		log.Printf("feed can't route %s: %v\n", subject, err)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// a client following both the sale and its account gets the event once
+	sent := map[uuid.UUID]struct{}{}
+	for _, topic := range topics {
+		for id, cl := range f.topics[topic] {
+			if _, ok := sent[id]; ok {
+				continue
+			}
+			sent[id] = struct{}{}
+
+			select {
+			case cl.messages <- model.Message{Subject: subject, Data: data}:
+			default:
+				log.Printf("feed client %s fell behind, disconnecting it\n", id)
+				f.remove(id)
+			}
+		}
+	}
+}
+
+// topic resolves the subscription of the client. The account topic is
+// always the account of the client itself.
+func (f FeedService) topic(clientId uuid.UUID, m model.Subscription) (model.Topic, error) {
+	switch m.Kind {
+	case model.SubscriptionKindSale:
+		if m.SaleId == nil {
+			return "", apperrors.ErrFeedSaleIdRequired
+		}
+		return model.SaleTopic(*m.SaleId), nil
+	default:
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+
+		cl, ok := f.clients[clientId]
+		if !ok {
+			return "", apperrors.ErrFeedClientNotFound
+		}
+		return model.AccountTopic(cl.userId), nil
+	}
+}
+
+// remove drops the client from every topic and closes its messages. The
+// caller must hold the lock.
+func (f FeedService) remove(clientId uuid.UUID) {
+	cl, ok := f.clients[clientId]
+	if !ok {
+		return
+	}
+
+	for topic := range cl.topics {
+		f.leave(topic, clientId)
+	}
+	delete(f.clients, clientId)
+	close(cl.messages)
+}
+
+// leave drops the client from the topic. The caller must hold the lock.
+func (f FeedService) leave(topic model.Topic, clientId uuid.UUID) {
+	delete(f.topics[topic], clientId)
+	if len(f.topics[topic]) == 0 {
+		delete(f.topics, topic)
+	}
+}
@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	eventmodel "nft/infra/eventbus/model"
+	"nft/internal/feed/model"
+	"testing"
+)
+
+func TestDispatch(t *testing.T) {
+	c := context.Background()
+	feed := NewFeedService(FeedServiceParams{})
+	sellerId, otherId := uuid.New(), uuid.New()
+
+	seller := feed.Connect(c, sellerId)
+	other := feed.Connect(c, otherId)
+	for _, cl := range []model.Client{seller, other} {
+		if err := feed.Subscribe(c, cl.ID, model.Subscription{Kind: model.SubscriptionKindAccount}); err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+	}
+
+	data, _ := json.Marshal(eventmodel.SaleCanceled{SaleId: uuid.New(), SellerId: sellerId})
+	feed.Dispatch(eventmodel.SubjectSaleCanceled, data)
+
+	select {
+	case m := <-seller.Messages:
+		if m.Subject != eventmodel.SubjectSaleCanceled {
+			t.Errorf("seller got %s, want %s", m.Subject, eventmodel.SubjectSaleCanceled)
+		}
+	default:
+		t.Error("seller didn't get the event of their account")
+	}
+	select {
+	case m := <-other.Messages:
+		t.Errorf("another account got %s", m.Subject)
+	default:
+	}
+
+	// a client that falls behind is disconnected
+	for i := 0; i <= bufferSize; i++ {
+		feed.Dispatch(eventmodel.SubjectSaleCanceled, data)
+	}
+	for range seller.Messages {
+	}
+
+	feed.Disconnect(c, other.ID)
+	if _, ok := <-other.Messages; ok {
+		t.Error("Disconnect() left the messages of the client open")
+	}
+}
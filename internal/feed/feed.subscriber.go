@@ -0,0 +1,54 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"nft/contract"
+	eventmodel "nft/infra/eventbus/model"
+	"nft/internal/feed/model"
+
+	"go.uber.org/fx"
+)
+
+// StartFeed subscribes the feed to every marketplace event for the
+// lifetime of the application.
+func StartFeed(lc fx.Lifecycle, eventBus contract.IEventBus, feedService contract.IFeedService) {
+	var unsubscribe func() error
+	lc.Append(fx.Hook{
+		OnStart: func(c context.Context) error {
+			var err error
+			unsubscribe, err = eventBus.Subscribe(eventmodel.Prefix+">", feedService.Dispatch)
+			return err
+		},
+		OnStop: func(c context.Context) error {
+			return unsubscribe()
+		},
+	})
+}
+
+// eventTopics returns the topics an event is routed to: the topic of its
+// sale, and the account topic of every user it involves.
+func eventTopics(data []byte) ([]model.Topic, error) {
+	var refs struct {
+		SaleId    *uuid.UUID `json:"sale_id"`
+		SellerId  *uuid.UUID `json:"seller_id"`
+		BuyerId   *uuid.UUID `json:"buyer_id"`
+		CreatorId *uuid.UUID `json:"creator_id"`
+	}
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, err
+	}
+
+	var topics []model.Topic
+	if refs.SaleId != nil {
+		topics = append(topics, model.SaleTopic(*refs.SaleId))
+	}
+	for _, userId := range []*uuid.UUID{refs.SellerId, refs.BuyerId, refs.CreatorId} {
+		if userId != nil {
+			topics = append(topics, model.AccountTopic(*userId))
+		}
+	}
+
+	return topics, nil
+}
@@ -0,0 +1,40 @@
+package feed
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	eventmodel "nft/infra/eventbus/model"
+	"nft/internal/feed/model"
+	"reflect"
+	"testing"
+)
+
+func TestEventTopics(t *testing.T) {
+	saleId, sellerId, buyerId := uuid.New(), uuid.New(), uuid.New()
+
+	data, _ := json.Marshal(eventmodel.OfferMade{OfferId: uuid.New(), SaleId: saleId, SellerId: sellerId, BuyerId: buyerId})
+	topics, err := eventTopics(data)
+	if err != nil {
+		t.Fatalf("eventTopics() error = %v", err)
+	}
+	want := []model.Topic{model.SaleTopic(saleId), model.AccountTopic(sellerId), model.AccountTopic(buyerId)}
+	if !reflect.DeepEqual(topics, want) {
+		t.Errorf("eventTopics(offer made) = %v, want %v", topics, want)
+	}
+
+	data, _ = json.Marshal(eventmodel.NftCreated{NftId: uuid.New(), CreatorId: sellerId})
+	topics, _ = eventTopics(data)
+	if want := []model.Topic{model.AccountTopic(sellerId)}; !reflect.DeepEqual(topics, want) {
+		t.Errorf("eventTopics(nft created) = %v, want %v", topics, want)
+	}
+
+	data, _ = json.Marshal(eventmodel.SaleExtended{SaleId: saleId, SellerId: sellerId})
+	topics, _ = eventTopics(data)
+	if want := []model.Topic{model.SaleTopic(saleId), model.AccountTopic(sellerId)}; !reflect.DeepEqual(topics, want) {
+		t.Errorf("eventTopics(sale extended) = %v, want %v", topics, want)
+	}
+
+	if _, err := eventTopics([]byte("not json")); err == nil {
+		t.Error("eventTopics() routed an event that isn't json")
+	}
+}
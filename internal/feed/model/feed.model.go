@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// Client is a connection to the feed. It receives the events of the topics
+// it subscribed to on Messages, which is closed once it is disconnected.
+type Client struct {
+	ID       uuid.UUID
+	UserId   uuid.UUID
+	Messages <-chan Message
+}
+
+// Message carries a marketplace event to a client, as it was published.
+type Message struct {
+	Subject string
+	Data    []byte
+}
+
+// Subscription names what a client listens to: the activity of a sale, or
+// of its own account.
+type Subscription struct {
+	Kind   SubscriptionKind
+	SaleId *uuid.UUID
+}
+
+type SubscriptionKind string
+
+const (
+	SubscriptionKindSale    SubscriptionKind = "sale"
+	SubscriptionKindAccount SubscriptionKind = "account"
+)
+
+// Topic is where events are routed, one per sale and one per account.
+type Topic string
+
+func SaleTopic(saleId uuid.UUID) Topic {
+	return Topic(fmt.Sprintf("sale:%s", saleId))
+}
+
+func AccountTopic(userId uuid.UUID) Topic {
+	return Topic(fmt.Sprintf("account:%s", userId))
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
 	"go.uber.org/fx"
 )
 
@@ -32,6 +33,11 @@ func (j JwtMiddleware) Handle(c *fiber.Ctx) error {
 	defer span.Finish()
 
 	token := c.Get("authorization")
+	// browsers can't set headers on a websocket handshake, so it brings the
+	// token in the query instead
+	if token == "" && websocket.IsWebSocketUpgrade(c) && c.Query("token") != "" {
+		token = "Bearer " + c.Query("token")
+	}
 	if token == "" {
 		return filper.GetUnAuthError(c, "no authorization token provided")
 	}
@@ -7,21 +7,47 @@ type Kyc struct {
 	IdCardImageUrl  string    `json:"id_card_image_url"`
 	PortraitImage   string    `json:"portrait_image"`
 	Status          KycStatus `json:"status"`
+	ReviewerId      string    `json:"reviewer_id,omitempty"`
+	ReviewerNote    string    `json:"reviewer_note,omitempty"`
 	RejectionReason string    `json:"rejection_reason,omitempty"`
+	Tier            int       `json:"tier,omitempty"`
 }
 
 type KycStatus string
 
 const (
+	KYCStatusSubmitted KycStatus = "submitted"
+	KYCStatusInReview  KycStatus = "in_review"
+	KYCStatusNeedsInfo KycStatus = "needs_info"
 	KYCStatusApproved  KycStatus = "approved"
 	KYCStatusRejected  KycStatus = "rejected"
-	KYCStatusUndefined KycStatus = "undefined"
 )
 
 type KycList struct {
 	KYCList []Kyc `json:"kyc_list"`
 }
 
+type ApproveAppeal struct {
+	Tier int    `json:"tier" validate:"omitempty,min=1"`
+	Note string `json:"note"`
+}
+
 type RejectAppeal struct {
 	Message string `json:"message"`
 }
+
+type RequestInfo struct {
+	Note string `json:"note" validate:"required"`
+}
+
+type Decision struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	ActorId   string `json:"actor_id"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type DecisionList struct {
+	Decisions []Decision `json:"decisions"`
+}
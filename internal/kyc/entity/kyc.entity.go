@@ -12,10 +12,28 @@ type Kyc struct {
 	UpdatedAt *time.Time
 	DeletedAt *time.Time
 
+	Status          string     `gorm:"index"`
+	ReviewerId      *uuid.UUID `gorm:"type:uuid"`
+	ReviewerNote    *sql.NullString
 	ApprovedBy      *uuid.UUID `gorm:"type:uuid"`
 	RejectedBy      *uuid.UUID `gorm:"type:uuid"`
-	UserId          uuid.UUID  `gorm:"type:uuid"`
+	UserId          uuid.UUID  `gorm:"type:uuid;index"`
 	RejectionReason *sql.NullString
 	IdCardImage     string
 	PortraitImage   string
+	Tier            int
+}
+
+// KycDecision is written once per status change of a case and never updated.
+type KycDecision struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	KycId      uuid.UUID `gorm:"type:uuid;index"`
+	FromStatus string
+	ToStatus   string
+	ActorId    uuid.UUID `gorm:"type:uuid"`
+	Note       *sql.NullString
 }
@@ -9,6 +9,7 @@ import (
 	dto "nft/internal/kyc/dto"
 	kyc "nft/internal/kyc/model"
 	"nft/pkg/filper"
+	"nft/pkg/validator"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -73,7 +74,7 @@ func (k KycController) Appeal(c *fiber.Ctx) error {
 
 	appeal, err := k.kycService.Appeal(ctx, kycModel)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrInvalidFileExtension) {
+		if errors.Is(err, apperrors.ErrInvalidFileExtension) || errors.Is(err, apperrors.ErrOpenAppealExists) {
 			return filper.GetBadRequestError(c, err.Error())
 		}
 		return filper.GetInternalError(c, "")
@@ -82,13 +83,146 @@ func (k KycController) Appeal(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(mapKycModelToDto(appeal))
 }
 
+// Resubmit godoc
+// @Summary  replace the documents of a Kyc appeal the reviewer asked more information for
+// @Tags     kyc
+// @Accept   multipart/form-data
+// @Produce  json
+// @Param    id        path      string  true  "appeal id"
+// @Param    id_card   formData  file    true  "Image of user's id card"
+// @Param    portrait  formData  file    true  "Image of user holding his id card are other things request by business"
+// @Success  200       {object}  dto.Kyc
+// @Router   /v1/kyc/{id}/documents [put]
+func (k KycController) Resubmit(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[Resubmit]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	appealId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidAppealId.Error())
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		if errors.Is(err, fasthttp.ErrNoMultipartForm) {
+			return filper.GetBadRequestError(c, "you to provide multipart form request body")
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	idCard := form.File["id_card"]
+	if len(idCard) < 1 {
+		return filper.GetBadRequestError(c, "you need to provide id card image")
+	}
+
+	portrait := form.File["portrait"]
+	if len(portrait) < 1 {
+		return filper.GetBadRequestError(c, "you need to provide an image of user holding his id card")
+	}
+
+	kycModel, err := createKycModel(idCard[0], portrait[0], userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+	kycModel.ID = appealId
+
+	appeal, err := k.kycService.Resubmit(ctx, kycModel)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrAppealNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrAppealNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidFileExtension) || errors.Is(err, apperrors.ErrInvalidAppealTransition) {
+			return filper.GetBadRequestError(c, err.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapKycModelToDto(appeal))
+}
+
+// Assign godoc
+// @Summary  take a submitted Kyc appeal into review
+// @Tags     kyc
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "appeal id that will be reviewed"
+// @Success  200  {string}  string  "appeal assigned successfully"
+// @Router   /v1/kyc/{id}/assign [post]
+func (k KycController) Assign(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[Assign]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	appealId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidAppealId.Error())
+	}
+
+	err = k.kycService.Assign(ctx, kyc.Kyc{ID: appealId, ReviewerId: &userId})
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "appeal assigned successfully")
+}
+
+// RequestInfo godoc
+// @Summary  ask the owner of a Kyc appeal in review for more information
+// @Tags     kyc
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string           true  "appeal id"
+// @Param    message  body      dto.RequestInfo  true  "what the owner needs to provide"
+// @Success  200      {string}  string           "information requested successfully"
+// @Router   /v1/kyc/{id}/request-info [post]
+func (k KycController) RequestInfo(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[RequestInfo]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	appealId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidAppealId.Error())
+	}
+
+	var request dto.RequestInfo
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	err = k.kycService.RequestInfo(ctx, kyc.Kyc{ID: appealId, ReviewerId: &userId, ReviewerNote: request.Note})
+	if err != nil {
+		return reviewError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "information requested successfully")
+}
+
 // Approve godoc
 // @Summary  approve Kyc appeal
 // @Tags     kyc
 // @Accept   json
 // @Produce  json
-// @Param    id   path      int     true  "appeal id that will be approved"
-// @Success  200  {string}  string  "appeal approved successfully"
+// @Param    id       path      string             true   "appeal id that will be approved"
+// @Param    message  body      dto.ApproveAppeal  false  "trading tier granted, 1 by default, and an optional note"
+// @Success  200      {string}  string             "appeal approved successfully"
 // @Router   /v1/kyc/{id}/approve [post]
 func (k KycController) Approve(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[Approve]")
@@ -104,12 +238,21 @@ func (k KycController) Approve(c *fiber.Ctx) error {
 		return filper.GetBadRequestError(c, "invalid appeal id")
 	}
 
-	err = k.kycService.Approve(ctx, kyc.Kyc{ID: appealId, ApprovedBy: &userId})
-	if err != nil {
-		if errors.Is(err, apperrors.ErrAppealNotFound) {
-			return filper.GetNotFoundError(c, "appeal not found")
+	var request dto.ApproveAppeal
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return filper.GetBadRequestError(c, "invalid body data")
 		}
-		return filper.GetInternalError(c, "")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	err = k.kycService.Approve(ctx, kyc.Kyc{ID: appealId, ApprovedBy: &userId, Tier: request.Tier, ReviewerNote: request.Note})
+	if err != nil {
+		return reviewError(c, err)
 	}
 
 	return filper.GetSuccessResponse(c, "appeal approved successfully")
@@ -120,7 +263,7 @@ func (k KycController) Approve(c *fiber.Ctx) error {
 // @Tags     kyc
 // @Accept   json
 // @Produce  json
-// @Param    id       path      int               true  "appeal id that will be rejected"
+// @Param    id       path      string            true  "appeal id that will be rejected"
 // @Param    message  body      dto.RejectAppeal  true  "optional rejection message"
 // @Success  200      {string}  string            "appeal rejected successfully"
 // @Router   /v1/kyc/{id}/reject [post]
@@ -149,10 +292,7 @@ func (k KycController) Reject(c *fiber.Ctx) error {
 
 	err = k.kycService.Reject(ctx, kyc.Kyc{ID: appealId, RejectedBy: &userId, RejectionReason: request.Message})
 	if err != nil {
-		if errors.Is(err, apperrors.ErrAppealNotFound) {
-			return filper.GetNotFoundError(c, "appeal not found")
-		}
-		return filper.GetInternalError(c, "")
+		return reviewError(c, err)
 	}
 
 	return filper.GetSuccessResponse(c, "appeal rejected successfully")
@@ -194,7 +334,7 @@ func (k KycController) GetAppeal(c *fiber.Ctx) error {
 }
 
 // GetAllAppeals godoc
-// @Summary  get all Kyc appeals
+// @Summary  get all Kyc appeals of the user
 // @Tags     kyc
 // @Accept   json
 // @Produce  json
@@ -216,3 +356,63 @@ func (k KycController) GetAllAppeals(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(createKycListDtoFromModel(appeal))
 }
+
+// GetCases godoc
+// @Summary  get Kyc appeals waiting for reviewers, oldest first
+// @Tags     kyc
+// @Accept   json
+// @Produce  json
+// @Param    status  query     string  false  "submitted, in_review, needs_info, approved or rejected; every open appeal by default"
+// @Success  200     {object}  dto.KycList
+// @Router   /v1/kyc/cases [get]
+func (k KycController) GetCases(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[GetCases]")
+	defer span.Finish()
+
+	cases, err := k.kycService.GetCases(ctx, kyc.KycStatus(c.Query("status")))
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createKycListDtoFromModel(cases))
+}
+
+// GetDecisions godoc
+// @Summary  get decisions made on Kyc appeal, oldest first
+// @Tags     kyc
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "appeal id"
+// @Success  200  {object}  dto.DecisionList
+// @Router   /v1/kyc/{id}/decisions [get]
+func (k KycController) GetDecisions(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "KycController[GetDecisions]")
+	defer span.Finish()
+
+	appealId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidAppealId.Error())
+	}
+
+	decisions, err := k.kycService.GetDecisions(ctx, appealId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrAppealNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrAppealNotFound.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createDecisionListDtoFromModel(decisions))
+}
+
+// reviewError maps the errors of a review action to a response.
+func reviewError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, apperrors.ErrAppealNotFound) {
+		return filper.GetNotFoundError(c, apperrors.ErrAppealNotFound.Error())
+	} else if errors.Is(err, apperrors.ErrInvalidAppealTransition) {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidAppealTransition.Error())
+	} else if errors.Is(err, apperrors.ErrNotAppealReviewer) {
+		return filper.GetForbiddenError(c, apperrors.ErrNotAppealReviewer.Error())
+	}
+	return filper.GetInternalError(c, "")
+}
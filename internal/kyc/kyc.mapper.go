@@ -24,7 +24,7 @@ func createKycModel(idCard *multipart.FileHeader, portrait *multipart.FileHeader
 		return model.Kyc{}, err
 	}
 
-	portraitFile, err := idCard.Open()
+	portraitFile, err := portrait.Open()
 	if err != nil {
 		return model.Kyc{}, err
 	}
@@ -48,32 +48,21 @@ func createKycModel(idCard *multipart.FileHeader, portrait *multipart.FileHeader
 }
 
 func mapKycModelToDto(res model.Kyc) dto.Kyc {
-	var status dto.KycStatus
-	approved, rejected := false, false
-
-	if res.ApprovedBy != nil {
-		approved = *res.ApprovedBy != uuid.Nil
-	}
-
-	if res.RejectedBy != nil {
-		rejected = *res.RejectedBy != uuid.Nil
-	}
-
-	if approved {
-		status = dto.KYCStatusApproved
-	} else if rejected {
-		status = dto.KYCStatusRejected
-	} else {
-		status = dto.KYCStatusUndefined
-	}
-
-	return dto.Kyc{
+	kyc := dto.Kyc{
 		ID:              res.ID,
 		IdCardImageUrl:  res.IdCardImage.FileUrl,
 		PortraitImage:   res.PortraitImage.FileUrl,
-		Status:          status,
+		Status:          dto.KycStatus(res.Status),
+		ReviewerNote:    res.ReviewerNote,
 		RejectionReason: res.RejectionReason,
+		Tier:            res.Tier,
 	}
+
+	if res.ReviewerId != nil {
+		kyc.ReviewerId = res.ReviewerId.String()
+	}
+
+	return kyc
 }
 
 func createKycListDtoFromModel(kycList []model.Kyc) dto.KycList {
@@ -91,24 +80,34 @@ func createKycListDtoFromModel(kycList []model.Kyc) dto.KycList {
 func mapKycModelToEntity(kyc model.Kyc) entity.Kyc {
 	return entity.Kyc{
 		ID:              kyc.ID,
+		Status:          string(kyc.Status),
+		ReviewerId:      kyc.ReviewerId,
+		ReviewerNote:    &sql.NullString{String: kyc.ReviewerNote, Valid: len(kyc.ReviewerNote) > 0},
 		ApprovedBy:      kyc.ApprovedBy,
 		RejectedBy:      kyc.RejectedBy,
 		UserId:          kyc.UserId,
 		RejectionReason: &sql.NullString{String: kyc.RejectionReason, Valid: len(kyc.RejectionReason) > 0},
 		IdCardImage:     kyc.IdCardImage.FileName,
 		PortraitImage:   kyc.PortraitImage.FileName,
+		Tier:            kyc.Tier,
 	}
 }
 
 func mapKycEntityToModel(kyc *entity.Kyc) model.Kyc {
 
-	var rejectionReason string
+	var rejectionReason, reviewerNote string
 	if kyc.RejectionReason != nil {
 		rejectionReason = kyc.RejectionReason.String
 	}
+	if kyc.ReviewerNote != nil {
+		reviewerNote = kyc.ReviewerNote.String
+	}
 
 	return model.Kyc{
 		ID:              kyc.ID,
+		Status:          model.KycStatus(kyc.Status),
+		ReviewerId:      kyc.ReviewerId,
+		ReviewerNote:    reviewerNote,
 		ApprovedBy:      kyc.ApprovedBy,
 		RejectedBy:      kyc.RejectedBy,
 		UserId:          kyc.UserId,
@@ -119,6 +118,7 @@ func mapKycEntityToModel(kyc *entity.Kyc) model.Kyc {
 		PortraitImage: file.Image{
 			FileName: kyc.PortraitImage,
 		},
+		Tier: kyc.Tier,
 	}
 }
 
@@ -131,3 +131,57 @@ func createModelKycList(kycs *[]entity.Kyc) []model.Kyc {
 
 	return kycList
 }
+
+func mapDecisionModelToEntity(m model.Decision) entity.KycDecision {
+	decision := entity.KycDecision{
+		KycId:      m.KycId,
+		FromStatus: string(m.From),
+		ToStatus:   string(m.To),
+		ActorId:    m.ActorId,
+	}
+
+	if len(m.Note) > 0 {
+		decision.Note = &sql.NullString{String: m.Note, Valid: true}
+	}
+
+	return decision
+}
+
+func mapDecisionEntityToModel(e entity.KycDecision) model.Decision {
+	decision := model.Decision{
+		ID:        e.ID,
+		CreatedAt: e.CreatedAt,
+		KycId:     e.KycId,
+		From:      model.KycStatus(e.FromStatus),
+		To:        model.KycStatus(e.ToStatus),
+		ActorId:   e.ActorId,
+	}
+
+	if e.Note != nil {
+		decision.Note = e.Note.String
+	}
+
+	return decision
+}
+
+func createModelDecisionListFromEntity(decisions []entity.KycDecision) []model.Decision {
+	decisionList := make([]model.Decision, len(decisions))
+	for i := range decisions {
+		decisionList[i] = mapDecisionEntityToModel(decisions[i])
+	}
+	return decisionList
+}
+
+func createDecisionListDtoFromModel(decisions []model.Decision) dto.DecisionList {
+	decisionList := make([]dto.Decision, len(decisions))
+	for i, decision := range decisions {
+		decisionList[i] = dto.Decision{
+			From:      string(decision.From),
+			To:        string(decision.To),
+			ActorId:   decision.ActorId.String(),
+			Note:      decision.Note,
+			CreatedAt: decision.CreatedAt.Unix(),
+		}
+	}
+	return dto.DecisionList{Decisions: decisionList}
+}
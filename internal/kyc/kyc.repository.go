@@ -2,7 +2,9 @@ package kyc
 
 import (
 	"context"
+	"errors"
 	"nft/contract"
+	apperrors "nft/error"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	"time"
@@ -102,3 +104,98 @@ func (k KycRepository) GetAll(c context.Context, conditions persist.D) ([]model.
 
 	return createModelKycList(kycList.(*[]entity.Kyc)), nil
 }
+
+func (k KycRepository) Find(c context.Context, query persist.Query) ([]model.Kyc, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[Find]")
+	defer span.Finish()
+
+	kycList, err := k.db.Find(c, &[]entity.Kyc{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelKycList(kycList.(*[]entity.Kyc)), nil
+}
+
+func (k KycRepository) Lock(c context.Context, conditions persist.D) (model.Kyc, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[Lock]")
+	defer span.Finish()
+
+	kyc, err := k.db.Lock(c, &entity.Kyc{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Kyc{}, apperrors.ErrAppealNotFound
+		}
+		return model.Kyc{}, err
+	}
+
+	return mapKycEntityToModel(kyc.(*entity.Kyc)), nil
+}
+
+// UpdateStatus writes the status along with the review columns, which are
+// cleared when the model leaves them unset.
+func (k KycRepository) UpdateStatus(c context.Context, m model.Kyc) error {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[UpdateStatus]")
+	defer span.Finish()
+
+	data := persist.D{
+		"status":           m.Status,
+		"reviewer_id":      m.ReviewerId,
+		"reviewer_note":    nil,
+		"approved_by":      m.ApprovedBy,
+		"rejected_by":      m.RejectedBy,
+		"rejection_reason": nil,
+		"tier":             m.Tier,
+	}
+	if len(m.ReviewerNote) > 0 {
+		data["reviewer_note"] = m.ReviewerNote
+	}
+	if len(m.RejectionReason) > 0 {
+		data["rejection_reason"] = m.RejectionReason
+	}
+
+	if _, err := k.db.Update(c, &entity.Kyc{ID: m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateDocuments replaces the stored file names of both images.
+func (k KycRepository) UpdateDocuments(c context.Context, m model.Kyc) error {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[UpdateDocuments]")
+	defer span.Finish()
+
+	data := persist.D{"id_card_image": m.IdCardImage.FileName, "portrait_image": m.PortraitImage.FileName}
+	if _, err := k.db.Update(c, &entity.Kyc{ID: m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (k KycRepository) AddDecision(c context.Context, m model.Decision) error {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[AddDecision]")
+	defer span.Finish()
+
+	decisionEntity := mapDecisionModelToEntity(m)
+	decisionEntity.ID = uuid.New()
+
+	if _, err := k.db.Create(c, &decisionEntity); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (k KycRepository) GetDecisions(c context.Context, kycId uuid.UUID) ([]model.Decision, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycRepository[GetDecisions]")
+	defer span.Finish()
+
+	decisions, err := k.db.Find(c, &[]entity.KycDecision{}, persist.Query{
+		Conditions: persist.D{"kyc_id": kycId},
+		Order:      "created_at asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelDecisionListFromEntity(*decisions.(*[]entity.KycDecision)), nil
+}
@@ -10,112 +10,270 @@ import (
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	model "nft/internal/kyc/model"
+	notificationmodel "nft/internal/notification/model"
 
 	"go.uber.org/fx"
 )
 
 type KycService struct {
-	fileService   contract.IFileService
-	kycRepository contract.IKycRepository
+	db                  contract.IPersist
+	fileService         contract.IFileService
+	kycRepository       contract.IKycRepository
+	userRepository      contract.IUserRepository
+	notificationService contract.INotificationService
 }
 
 type KycServiceParams struct {
 	fx.In
-	FileService   contract.IFileService
-	KYCRepository contract.IKycRepository
+	DB                  contract.IPersist
+	FileService         contract.IFileService
+	KYCRepository       contract.IKycRepository
+	UserRepository      contract.IUserRepository
+	NotificationService contract.INotificationService
 }
 
 func NewKYCService(params KycServiceParams) contract.IKycService {
 	return KycService{
-		fileService:   params.FileService,
-		kycRepository: params.KYCRepository,
+		db:                  params.DB,
+		fileService:         params.FileService,
+		kycRepository:       params.KYCRepository,
+		userRepository:      params.UserRepository,
+		notificationService: params.NotificationService,
 	}
 }
 
+// Appeal opens a new case for the user, who must not have another one open.
 func (k KycService) Appeal(c context.Context, m model.Kyc) (model.Kyc, error) {
 	span, c := jtrace.T().SpanFromContext(c, "KycService[Appeal]")
 	defer span.Finish()
 
-	m.IdCardImage.Bucket = config.C().Storage.Buckets.KYC
-	idCardFileName, err := k.fileService.UploadImage(c, m.IdCardImage)
-	if err != nil {
+	if err := k.ensureNoOpenAppeal(c, m.UserId); err != nil {
 		return model.Kyc{}, err
 	}
 
-	m.PortraitImage.Bucket = config.C().Storage.Buckets.KYC
-	portraitFileName, err := k.fileService.UploadImage(c, m.PortraitImage)
-	if err != nil {
+	if err := k.uploadImages(c, &m); err != nil {
 		return model.Kyc{}, err
 	}
-	m.IdCardImage.FileName = idCardFileName
-	m.PortraitImage.FileName = portraitFileName
 
-	kyc, err := k.kycRepository.Add(c, m)
+	var kyc model.Kyc
+	err := k.db.Transaction(c, func(c context.Context) error {
+		// the user is locked so concurrent appeals are checked one after
+		// the other and only the first one is added
+		if _, err := k.userRepository.Lock(c, persist.D{"id": m.UserId}); err != nil {
+			return err
+		}
+
+		if err := k.ensureNoOpenAppeal(c, m.UserId); err != nil {
+			return err
+		}
+
+		m.Status = model.KycStatusSubmitted
+		var err error
+		kyc, err = k.kycRepository.Add(c, m)
+		if err != nil {
+			return err
+		}
+
+		return k.kycRepository.AddDecision(c, model.Decision{
+			KycId:   kyc.ID,
+			To:      model.KycStatusSubmitted,
+			ActorId: m.UserId,
+		})
+	})
 	if err != nil {
 		return model.Kyc{}, err
 	}
 
-	m.IdCardImage.Bucket = config.C().Storage.Buckets.KYC
-	idCardUrl, err := k.fileService.GetImageUrl(c, m.IdCardImage)
-	if err != nil {
+	return k.withImageUrls(c, kyc)
+}
+
+// Resubmit replaces the documents of a case the reviewer asked more
+// information for and puts it back in the queue.
+func (k KycService) Resubmit(c context.Context, m model.Kyc) (model.Kyc, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[Resubmit]")
+	defer span.Finish()
+
+	if err := k.uploadImages(c, &m); err != nil {
 		return model.Kyc{}, err
 	}
 
-	m.PortraitImage.Bucket = config.C().Storage.Buckets.KYC
-	portraitUrl, err := k.fileService.GetImageUrl(c, m.PortraitImage)
+	var kyc model.Kyc
+	err := k.db.Transaction(c, func(c context.Context) error {
+		var err error
+		kyc, err = k.kycRepository.Lock(c, persist.D{"id": m.ID, "user_id": m.UserId})
+		if err != nil {
+			return err
+		}
+
+		kyc.ReviewerNote = ""
+		if err := k.transition(c, &kyc, model.KycStatusSubmitted, m.UserId, ""); err != nil {
+			return err
+		}
+
+		kyc.IdCardImage = m.IdCardImage
+		kyc.PortraitImage = m.PortraitImage
+		return k.kycRepository.UpdateDocuments(c, kyc)
+	})
 	if err != nil {
 		return model.Kyc{}, err
 	}
 
-	kyc.IdCardImage.FileUrl = idCardUrl
-	kyc.PortraitImage.FileUrl = portraitUrl
+	return k.withImageUrls(c, kyc)
+}
+
+// Assign takes a submitted case into review by the given reviewer.
+func (k KycService) Assign(c context.Context, m model.Kyc) error {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[Assign]")
+	defer span.Finish()
 
-	return kyc, nil
+	return k.db.Transaction(c, func(c context.Context) error {
+		kyc, err := k.kycRepository.Lock(c, persist.D{"id": m.ID})
+		if err != nil {
+			return err
+		}
+
+		kyc.ReviewerId = m.ReviewerId
+		return k.transition(c, &kyc, model.KycStatusInReview, *m.ReviewerId, "")
+	})
+}
+
+// RequestInfo sends a case in review back to its owner with the reviewer's
+// note on what is missing.
+func (k KycService) RequestInfo(c context.Context, m model.Kyc) error {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[RequestInfo]")
+	defer span.Finish()
+
+	return k.db.Transaction(c, func(c context.Context) error {
+		kyc, err := k.kycRepository.Lock(c, persist.D{"id": m.ID})
+		if err != nil {
+			return err
+		}
+
+		if err := assigned(kyc, *m.ReviewerId); err != nil {
+			return err
+		}
+
+		kyc.ReviewerNote = m.ReviewerNote
+		return k.transition(c, &kyc, model.KycStatusNeedsInfo, *m.ReviewerId, m.ReviewerNote)
+	})
 }
 
 func (k KycService) Approve(c context.Context, m model.Kyc) error {
 	span, c := jtrace.T().SpanFromContext(c, "KycService[Approve]")
 	defer span.Finish()
 
-	kycModel, err := k.kycRepository.Get(c, persist.D{"id": m.ID})
-	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrAppealNotFound
+	return k.db.Transaction(c, func(c context.Context) error {
+		kyc, err := k.kycRepository.Lock(c, persist.D{"id": m.ID})
+		if err != nil {
+			return err
 		}
-		return err
-	}
-	kycModel.ApprovedBy = m.ApprovedBy
-	kycModel.RejectedBy = &uuid.Nil
-	kycModel.RejectionReason = ""
 
-	if _, err := k.kycRepository.Update(c, kycModel); err != nil {
-		return err
-	}
+		if err := assigned(kyc, *m.ApprovedBy); err != nil {
+			return err
+		}
 
-	return nil
+		kyc.ApprovedBy = m.ApprovedBy
+		kyc.RejectedBy = nil
+		kyc.RejectionReason = ""
+		kyc.ReviewerNote = m.ReviewerNote
+		kyc.Tier = m.Tier
+		if kyc.Tier < 1 {
+			kyc.Tier = 1
+		}
+
+		if err := k.transition(c, &kyc, model.KycStatusApproved, *m.ApprovedBy, m.ReviewerNote); err != nil {
+			return err
+		}
+
+		return k.notifyDecision(c, kyc)
+	})
 }
 
 func (k KycService) Reject(c context.Context, m model.Kyc) error {
 	span, c := jtrace.T().SpanFromContext(c, "KycService[Reject]")
 	defer span.Finish()
 
-	kycModel, err := k.kycRepository.Get(c, persist.D{"id": m.ID})
-	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrAppealNotFound
+	return k.db.Transaction(c, func(c context.Context) error {
+		kyc, err := k.kycRepository.Lock(c, persist.D{"id": m.ID})
+		if err != nil {
+			return err
 		}
-		return err
+
+		if err := assigned(kyc, *m.RejectedBy); err != nil {
+			return err
+		}
+
+		kyc.RejectedBy = m.RejectedBy
+		kyc.RejectionReason = m.RejectionReason
+		kyc.ApprovedBy = nil
+		kyc.ReviewerNote = ""
+
+		if err := k.transition(c, &kyc, model.KycStatusRejected, *m.RejectedBy, m.RejectionReason); err != nil {
+			return err
+		}
+
+		return k.notifyDecision(c, kyc)
+	})
+}
+
+// notifyDecision tells the user the decision on their case, in the
+// transaction that records it.
+func (k KycService) notifyDecision(c context.Context, m model.Kyc) error {
+	kind := notificationmodel.KindKycRejected
+	if m.Status == model.KycStatusApproved {
+		kind = notificationmodel.KindKycApproved
 	}
-	kycModel.RejectedBy = m.RejectedBy
-	kycModel.RejectionReason = m.RejectionReason
-	kycModel.ApprovedBy = &uuid.Nil
-	if _, err := k.kycRepository.Update(c, kycModel); err != nil {
+
+	return k.notificationService.Notify(c, notificationmodel.Notification{
+		UserId: m.UserId,
+		Kind:   kind,
+		Reason: m.RejectionReason,
+	})
+}
+
+// transition moves the case to the given status and records the decision.
+func (k KycService) transition(c context.Context, m *model.Kyc, to model.KycStatus, actorId uuid.UUID, note string) error {
+	from := m.Status
+	if !canTransition(from, to) {
+		return apperrors.ErrInvalidAppealTransition
+	}
+
+	m.Status = to
+	if err := k.kycRepository.UpdateStatus(c, *m); err != nil {
 		return err
 	}
 
+	return k.kycRepository.AddDecision(c, model.Decision{
+		KycId:   m.ID,
+		From:    from,
+		To:      to,
+		ActorId: actorId,
+		Note:    note,
+	})
+}
+
+// assigned reports an error unless the case is in review by the reviewer.
+func assigned(m model.Kyc, reviewerId uuid.UUID) error {
+	if m.Status != model.KycStatusInReview {
+		return apperrors.ErrInvalidAppealTransition
+	}
+	if m.ReviewerId == nil || *m.ReviewerId != reviewerId {
+		return apperrors.ErrNotAppealReviewer
+	}
 	return nil
 }
 
+func (k KycService) ensureNoOpenAppeal(c context.Context, userId uuid.UUID) error {
+	err := k.kycRepository.Exists(c, persist.D{"user_id": userId, "status in": openStatuses})
+	if err == nil {
+		return apperrors.ErrOpenAppealExists
+	}
+	if errors.Is(err, apperrors.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
 func (k KycService) GetAppeal(c context.Context, m model.Kyc) (model.Kyc, error) {
 	span, c := jtrace.T().SpanFromContext(c, "KycService[GetAppeal]")
 	defer span.Finish()
@@ -128,6 +286,76 @@ func (k KycService) GetAppeal(c context.Context, m model.Kyc) (model.Kyc, error)
 		return model.Kyc{}, err
 	}
 
+	return k.withImageUrls(c, appeal)
+}
+
+// GetAllAppeals returns the cases of the user.
+func (k KycService) GetAllAppeals(c context.Context, m model.Kyc) ([]model.Kyc, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[GetAllAppeals]")
+	defer span.Finish()
+
+	appeals, err := k.kycRepository.GetAll(c, persist.D{"user_id": m.UserId})
+	if err != nil {
+		return nil, err
+	}
+
+	return k.withImageUrlsList(c, appeals)
+}
+
+// GetCases returns the cases in the given status for reviewers, oldest
+// first. Without a status every open case is returned.
+func (k KycService) GetCases(c context.Context, status model.KycStatus) ([]model.Kyc, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[GetCases]")
+	defer span.Finish()
+
+	conditions := persist.D{"status in": openStatuses}
+	if len(status) > 0 {
+		conditions = persist.D{"status": status}
+	}
+
+	cases, err := k.kycRepository.Find(c, persist.Query{Conditions: conditions, Order: "created_at asc"})
+	if err != nil {
+		return nil, err
+	}
+
+	return k.withImageUrlsList(c, cases)
+}
+
+// GetDecisions returns the decisions made on a case, oldest first.
+func (k KycService) GetDecisions(c context.Context, kycId uuid.UUID) ([]model.Decision, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[GetDecisions]")
+	defer span.Finish()
+
+	if err := k.kycRepository.Exists(c, persist.D{"id": kycId}); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return nil, apperrors.ErrAppealNotFound
+		}
+		return nil, err
+	}
+
+	return k.kycRepository.GetDecisions(c, kycId)
+}
+
+func (k KycService) uploadImages(c context.Context, m *model.Kyc) error {
+	m.IdCardImage.Bucket = config.C().Storage.Buckets.KYC
+	idCardFileName, err := k.fileService.UploadImage(c, m.IdCardImage)
+	if err != nil {
+		return err
+	}
+
+	m.PortraitImage.Bucket = config.C().Storage.Buckets.KYC
+	portraitFileName, err := k.fileService.UploadImage(c, m.PortraitImage)
+	if err != nil {
+		return err
+	}
+
+	m.IdCardImage.FileName = idCardFileName
+	m.PortraitImage.FileName = portraitFileName
+
+	return nil
+}
+
+func (k KycService) withImageUrls(c context.Context, appeal model.Kyc) (model.Kyc, error) {
 	appeal.IdCardImage.Bucket = config.C().Storage.Buckets.KYC
 	idCardUrl, err := k.fileService.GetImageUrl(c, appeal.IdCardImage)
 	if err != nil {
@@ -146,31 +374,48 @@ func (k KycService) GetAppeal(c context.Context, m model.Kyc) (model.Kyc, error)
 	return appeal, nil
 }
 
-func (k KycService) GetAllAppeals(c context.Context, m model.Kyc) ([]model.Kyc, error) {
-	span, c := jtrace.T().SpanFromContext(c, "KycService[GetAllAppeals]")
+func (k KycService) withImageUrlsList(c context.Context, appeals []model.Kyc) ([]model.Kyc, error) {
+	for i := range appeals {
+		appeal, err := k.withImageUrls(c, appeals[i])
+		if err != nil {
+			return nil, err
+		}
+		appeals[i] = appeal
+	}
+
+	return appeals, nil
+}
+
+// IsApproved reports whether the user has an approved KYC appeal.
+func (k KycService) IsApproved(c context.Context, userId uuid.UUID) (bool, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[IsApproved]")
 	defer span.Finish()
 
-	appeals, err := k.kycRepository.GetAll(c, persist.D{})
+	tier, err := k.GetTier(c, userId)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	for i, appeal := range appeals {
-		appeal.IdCardImage.Bucket = config.C().Storage.Buckets.KYC
-		idCardUrl, err := k.fileService.GetImageUrl(c, appeal.IdCardImage)
-		if err != nil {
-			return nil, err
-		}
+	return tier > 0, nil
+}
 
-		appeal.PortraitImage.Bucket = config.C().Storage.Buckets.KYC
-		portraitUrl, err := k.fileService.GetImageUrl(c, appeal.PortraitImage)
-		if err != nil {
-			return nil, err
-		}
+// GetTier returns the highest tier the user's approved appeals reached, zero
+// if none is approved.
+func (k KycService) GetTier(c context.Context, userId uuid.UUID) (int, error) {
+	span, c := jtrace.T().SpanFromContext(c, "KycService[GetTier]")
+	defer span.Finish()
 
-		appeals[i].IdCardImage.FileUrl = idCardUrl
-		appeals[i].PortraitImage.FileUrl = portraitUrl
+	appeals, err := k.kycRepository.GetAll(c, persist.D{"user_id": userId, "status": model.KycStatusApproved})
+	if err != nil {
+		return 0, err
 	}
 
-	return appeals, nil
+	tier := 0
+	for _, appeal := range appeals {
+		if appeal.Tier > tier {
+			tier = appeal.Tier
+		}
+	}
+
+	return tier, nil
 }
@@ -0,0 +1,25 @@
+package kyc
+
+import model "nft/internal/kyc/model"
+
+// kycTransitions lists the statuses a case may move to from each status. A
+// case waiting for information goes back to the queue when its owner
+// resubmits documents; approved and rejected cases are closed.
+var kycTransitions = map[model.KycStatus][]model.KycStatus{
+	model.KycStatusSubmitted: {model.KycStatusInReview},
+	model.KycStatusInReview:  {model.KycStatusNeedsInfo, model.KycStatusApproved, model.KycStatusRejected},
+	model.KycStatusNeedsInfo: {model.KycStatusSubmitted},
+}
+
+// openStatuses are the statuses of a case that is not decided yet. A user
+// may have only one case in any of them.
+var openStatuses = []model.KycStatus{model.KycStatusSubmitted, model.KycStatusInReview, model.KycStatusNeedsInfo}
+
+func canTransition(from, to model.KycStatus) bool {
+	for _, status := range kycTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package kyc
+
+import (
+	model "nft/internal/kyc/model"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to model.KycStatus
+		want     bool
+	}{
+		{model.KycStatusSubmitted, model.KycStatusInReview, true},
+		{model.KycStatusInReview, model.KycStatusNeedsInfo, true},
+		{model.KycStatusInReview, model.KycStatusApproved, true},
+		{model.KycStatusInReview, model.KycStatusRejected, true},
+		{model.KycStatusNeedsInfo, model.KycStatusSubmitted, true},
+		{model.KycStatusSubmitted, model.KycStatusApproved, false},
+		{model.KycStatusNeedsInfo, model.KycStatusApproved, false},
+		{model.KycStatusApproved, model.KycStatusRejected, false},
+		{model.KycStatusRejected, model.KycStatusSubmitted, false},
+		{model.KycStatusInReview, model.KycStatusInReview, false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -2,16 +2,43 @@ package kyc
 
 import (
 	file "nft/internal/file/model"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type KycStatus string
+
+const (
+	KycStatusSubmitted KycStatus = "submitted"
+	KycStatusInReview  KycStatus = "in_review"
+	KycStatusNeedsInfo KycStatus = "needs_info"
+	KycStatusApproved  KycStatus = "approved"
+	KycStatusRejected  KycStatus = "rejected"
+)
+
 type Kyc struct {
 	ID              uuid.UUID
+	Status          KycStatus
+	ReviewerId      *uuid.UUID
+	ReviewerNote    string
 	ApprovedBy      *uuid.UUID
 	RejectedBy      *uuid.UUID
 	RejectionReason string
 	IdCardImage     file.Image
 	PortraitImage   file.Image
 	UserId          uuid.UUID
+	Tier            int
+}
+
+// Decision is one status change of a kyc case. From is empty for the
+// submission that opened the case.
+type Decision struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	KycId     uuid.UUID
+	From      KycStatus
+	To        KycStatus
+	ActorId   uuid.UUID
+	Note      string
 }
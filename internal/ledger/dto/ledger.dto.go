@@ -0,0 +1,7 @@
+package ledger
+
+type Balance struct {
+	Available   float64 `json:"available"`
+	Held        float64 `json:"held"`
+	Withdrawing float64 `json:"withdrawing"`
+}
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Account struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId  uuid.UUID   `gorm:"type:uuid;uniqueIndex:idx_account_owner"`
+	Type    AccountType `gorm:"uniqueIndex:idx_account_owner"`
+	Balance int64
+}
+
+type AccountType string
+
+const (
+	AccountTypeAvailable AccountType = "available"
+	AccountTypeEscrow    AccountType = "escrow"
+	AccountTypeDeposit   AccountType = "deposit"
+	AccountTypeFee       AccountType = "fee"
+)
+
+type Entry struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	JournalId     uuid.UUID  `gorm:"type:uuid;index"`
+	AccountId     uuid.UUID  `gorm:"type:uuid;index"`
+	TransactionId *uuid.UUID `gorm:"type:uuid;index"`
+	Kind          EntryKind
+	Amount        int64
+	Reference     string
+}
+
+type EntryKind string
+
+const (
+	EntryKindDeposit EntryKind = "deposit"
+	EntryKindHold    EntryKind = "hold"
+	EntryKindRelease EntryKind = "release"
+	EntryKindPayout  EntryKind = "payout"
+	EntryKindRoyalty EntryKind = "royalty"
+	EntryKindFee     EntryKind = "fee"
+)
+
+type Hold struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId  uuid.UUID `gorm:"type:uuid;"`
+	OfferId uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	Amount  int64
+	Status  HoldStatus
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusHeld     HoldStatus = "held"
+	HoldStatusReleased HoldStatus = "released"
+	HoldStatusCaptured HoldStatus = "captured"
+)
+
+type Deposit struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId uuid.UUID `gorm:"type:uuid;"`
+	TxId   string    `gorm:"uniqueIndex"`
+	Amount int64
+}
+
+type DepositCursor struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	Address     string    `gorm:"uniqueIndex"`
+	UserId      uuid.UUID `gorm:"type:uuid;index"`
+	BlockHeight int64
+}
@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/pkg/filper"
+)
+
+type LedgerController struct {
+	ledgerService contract.ILedgerService
+}
+
+type LedgerControllerParams struct {
+	fx.In
+	LedgerService contract.ILedgerService
+}
+
+func NewLedgerController(params LedgerControllerParams) contract.ILedgerController {
+	return &LedgerController{
+		ledgerService: params.LedgerService,
+	}
+}
+
+// GetBalance godoc
+// @Summary  get available and held balance of user
+// @Tags     ledger
+// @Accept   json
+// @Produce  json
+// @Router   /v1/ledger/balance [get]
+// @Success  200  {object}  dto.Balance
+func (l LedgerController) GetBalance(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "LedgerController[GetBalance]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	balance, err := l.ledgerService.GetBalance(ctx, userId)
+	if err != nil {
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapBalanceModelToDto(balance))
+}
+
+// SyncDeposits godoc
+// @Summary  credit confirmed payments received on user wallet
+// @Tags     ledger
+// @Accept   json
+// @Produce  json
+// @Router   /v1/ledger/deposits/sync [post]
+// @Success  200  {object}  dto.Balance
+func (l LedgerController) SyncDeposits(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "LedgerController[SyncDeposits]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	if err := l.ledgerService.SyncDeposits(ctx, userId); err != nil {
+		if errors.Is(err, apperrors.ErrWalletNotAvailable) {
+			return filper.GetBadRequestError(c, apperrors.ErrWalletNotAvailable.Error())
+		}
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	balance, err := l.ledgerService.GetBalance(ctx, userId)
+	if err != nil {
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapBalanceModelToDto(balance))
+}
@@ -0,0 +1,60 @@
+package ledger
+
+import (
+	"github.com/google/uuid"
+	apperrors "nft/error"
+	"nft/internal/ledger/model"
+	"nft/pkg/money"
+	"sort"
+)
+
+// journal is a set of postings written together under one reference.
+type journal struct {
+	reference     string
+	transactionId *uuid.UUID
+	postings      []posting
+}
+
+// posting is one leg of a journal: a signed amount moved into an account.
+type posting struct {
+	account uuid.UUID
+	kind    model.EntryKind
+	amount  money.Amount
+}
+
+// checkJournal verifies that the legs of a journal cancel out, which is what
+// keeps the sum of every balance in the ledger at zero.
+func checkJournal(postings []posting) error {
+	var sum money.Amount
+	for _, p := range postings {
+		sum += p.amount
+	}
+	if sum != 0 {
+		return apperrors.ErrUnbalancedJournal
+	}
+	return nil
+}
+
+// lockOrder returns the distinct accounts of a journal sorted by id, the
+// order in which they are locked so concurrent journals can't deadlock.
+func lockOrder(postings []posting) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, p := range postings {
+		if seen[p.account] {
+			continue
+		}
+		seen[p.account] = true
+		ids = append(ids, p.account)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+// mayOverdraw reports whether an account is allowed a negative balance.
+// Only platform accounts that mirror money outside the ledger are.
+func mayOverdraw(account model.Account) bool {
+	return account.Type == model.AccountTypeDeposit
+}
@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	apperrors "nft/error"
+	"testing"
+)
+
+func TestCheckJournal(t *testing.T) {
+	a, b, fee := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		postings []posting
+		wantErr  error
+	}{
+		{"transfer", []posting{{account: a, amount: -10}, {account: b, amount: 10}}, nil},
+		{"split", []posting{{account: a, amount: -100}, {account: b, amount: 33}, {account: b, amount: 33}, {account: fee, amount: 34}}, nil},
+		{"unbalanced", []posting{{account: a, amount: -10}, {account: b, amount: 9}}, apperrors.ErrUnbalancedJournal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkJournal(tt.postings); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkJournal() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLockOrder(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	ids := lockOrder([]posting{{account: a, amount: -1}, {account: b, amount: 1}, {account: a, amount: -1}, {account: b, amount: 1}})
+	if len(ids) != 2 {
+		t.Fatalf("lockOrder() returned %d accounts, want 2", len(ids))
+	}
+
+	reversed := lockOrder([]posting{{account: b, amount: 1}, {account: a, amount: -1}})
+	if ids[0] != reversed[0] || ids[1] != reversed[1] {
+		t.Errorf("lockOrder() depends on posting order: %v, %v", ids, reversed)
+	}
+}
@@ -0,0 +1,104 @@
+package ledger
+
+import (
+	dto "nft/internal/ledger/dto"
+	entity "nft/internal/ledger/entity"
+	"nft/internal/ledger/model"
+	"nft/pkg/money"
+)
+
+func mapAccountEntityToModel(e entity.Account) model.Account {
+	return model.Account{
+		ID:      e.ID,
+		UserId:  e.UserId,
+		Type:    model.AccountType(e.Type),
+		Balance: money.Amount(e.Balance),
+	}
+}
+
+func mapAccountModelToEntity(m model.Account) entity.Account {
+	return entity.Account{
+		ID:      m.ID,
+		UserId:  m.UserId,
+		Type:    entity.AccountType(m.Type),
+		Balance: int64(m.Balance),
+	}
+}
+
+func mapEntryModelToEntity(m model.Entry) entity.Entry {
+	return entity.Entry{
+		ID:            m.ID,
+		JournalId:     m.JournalId,
+		AccountId:     m.AccountId,
+		TransactionId: m.TransactionId,
+		Kind:          entity.EntryKind(m.Kind),
+		Amount:        int64(m.Amount),
+		Reference:     m.Reference,
+	}
+}
+
+func mapEntryEntityToModel(e entity.Entry) model.Entry {
+	return model.Entry{
+		ID:            e.ID,
+		CreatedAt:     e.CreatedAt,
+		JournalId:     e.JournalId,
+		AccountId:     e.AccountId,
+		TransactionId: e.TransactionId,
+		Kind:          model.EntryKind(e.Kind),
+		Amount:        money.Amount(e.Amount),
+		Reference:     e.Reference,
+	}
+}
+
+func createModelEntryListFromEntity(entries []entity.Entry) []model.Entry {
+	entryList := make([]model.Entry, len(entries))
+	for i := range entries {
+		entryList[i] = mapEntryEntityToModel(entries[i])
+	}
+	return entryList
+}
+
+func mapHoldEntityToModel(e entity.Hold) model.Hold {
+	return model.Hold{
+		ID:      e.ID,
+		UserId:  e.UserId,
+		OfferId: e.OfferId,
+		Amount:  money.Amount(e.Amount),
+		Status:  model.HoldStatus(e.Status),
+	}
+}
+
+func mapHoldModelToEntity(m model.Hold) entity.Hold {
+	return entity.Hold{
+		ID:      m.ID,
+		UserId:  m.UserId,
+		OfferId: m.OfferId,
+		Amount:  int64(m.Amount),
+		Status:  entity.HoldStatus(m.Status),
+	}
+}
+
+func mapDepositModelToEntity(m model.Deposit) entity.Deposit {
+	return entity.Deposit{
+		ID:     m.ID,
+		UserId: m.UserId,
+		TxId:   m.TxId,
+		Amount: int64(m.Amount),
+	}
+}
+
+func mapBalanceModelToDto(m model.Balance) dto.Balance {
+	return dto.Balance{
+		Available:   m.Available.Float(),
+		Held:        m.Held.Float(),
+		Withdrawing: m.Withdrawing.Float(),
+	}
+}
+
+func mapDepositCursorEntityToModel(e entity.DepositCursor) model.DepositCursor {
+	return model.DepositCursor{
+		Address:     e.Address,
+		UserId:      e.UserId,
+		BlockHeight: e.BlockHeight,
+	}
+}
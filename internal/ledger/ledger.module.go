@@ -0,0 +1,9 @@
+package ledger
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewLedgerController),
+	fx.Provide(NewLedgerService),
+	fx.Provide(NewLedgerRepository),
+)
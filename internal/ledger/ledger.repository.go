@@ -0,0 +1,231 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	entity "nft/internal/ledger/entity"
+	"nft/internal/ledger/model"
+)
+
+type LedgerRepository struct {
+	db contract.IPersist
+}
+
+type LedgerRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewLedgerRepository(params LedgerRepositoryParams) contract.ILedgerRepository {
+	return &LedgerRepository{
+		db: params.DB,
+	}
+}
+
+func (l LedgerRepository) GetAccount(c context.Context, conditions persist.D) (model.Account, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[GetAccount]")
+	defer span.Finish()
+
+	account, err := l.db.Get(c, &entity.Account{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Account{}, apperrors.ErrAccountNotFound
+		}
+		return model.Account{}, err
+	}
+
+	return mapAccountEntityToModel(*account.(*entity.Account)), nil
+}
+
+func (l LedgerRepository) LockAccount(c context.Context, conditions persist.D) (model.Account, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[LockAccount]")
+	defer span.Finish()
+
+	account, err := l.db.Lock(c, &entity.Account{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Account{}, apperrors.ErrAccountNotFound
+		}
+		return model.Account{}, err
+	}
+
+	return mapAccountEntityToModel(*account.(*entity.Account)), nil
+}
+
+func (l LedgerRepository) AddAccount(c context.Context, m model.Account) (model.Account, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[AddAccount]")
+	defer span.Finish()
+
+	accountEntity := mapAccountModelToEntity(m)
+	accountEntity.ID = uuid.New()
+
+	account, err := l.db.Create(c, &accountEntity)
+	if err != nil {
+		return model.Account{}, err
+	}
+
+	return mapAccountEntityToModel(*account.(*entity.Account)), nil
+}
+
+func (l LedgerRepository) UpdateBalance(c context.Context, m model.Account) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[UpdateBalance]")
+	defer span.Finish()
+
+	if _, err := l.db.Update(c, &entity.Account{ID: m.ID}, persist.D{"balance": int64(m.Balance)}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l LedgerRepository) AddEntry(c context.Context, m model.Entry) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[AddEntry]")
+	defer span.Finish()
+
+	entryEntity := mapEntryModelToEntity(m)
+	entryEntity.ID = uuid.New()
+
+	if _, err := l.db.Create(c, &entryEntity); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l LedgerRepository) GetEntries(c context.Context, conditions persist.D) ([]model.Entry, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[GetEntries]")
+	defer span.Finish()
+
+	entries, err := l.db.GetAll(c, &[]entity.Entry{}, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelEntryListFromEntity(*entries.(*[]entity.Entry)), nil
+}
+
+func (l LedgerRepository) GetHold(c context.Context, conditions persist.D) (model.Hold, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[GetHold]")
+	defer span.Finish()
+
+	hold, err := l.db.Get(c, &entity.Hold{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Hold{}, apperrors.ErrHoldNotFound
+		}
+		return model.Hold{}, err
+	}
+
+	return mapHoldEntityToModel(*hold.(*entity.Hold)), nil
+}
+
+func (l LedgerRepository) LockHold(c context.Context, conditions persist.D) (model.Hold, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[LockHold]")
+	defer span.Finish()
+
+	hold, err := l.db.Lock(c, &entity.Hold{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Hold{}, apperrors.ErrHoldNotFound
+		}
+		return model.Hold{}, err
+	}
+
+	return mapHoldEntityToModel(*hold.(*entity.Hold)), nil
+}
+
+func (l LedgerRepository) AddHold(c context.Context, m model.Hold) (model.Hold, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[AddHold]")
+	defer span.Finish()
+
+	holdEntity := mapHoldModelToEntity(m)
+	holdEntity.ID = uuid.New()
+
+	hold, err := l.db.Create(c, &holdEntity)
+	if err != nil {
+		return model.Hold{}, err
+	}
+
+	return mapHoldEntityToModel(*hold.(*entity.Hold)), nil
+}
+
+func (l LedgerRepository) UpdateHoldStatus(c context.Context, m model.Hold) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[UpdateHoldStatus]")
+	defer span.Finish()
+
+	if _, err := l.db.Update(c, &entity.Hold{ID: m.ID}, persist.D{"status": m.Status}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l LedgerRepository) DepositExists(c context.Context, conditions persist.D) (bool, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[DepositExists]")
+	defer span.Finish()
+
+	if _, err := l.db.Get(c, &entity.Deposit{}, conditions); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (l LedgerRepository) AddDeposit(c context.Context, m model.Deposit) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[AddDeposit]")
+	defer span.Finish()
+
+	depositEntity := mapDepositModelToEntity(m)
+	depositEntity.ID = uuid.New()
+
+	if _, err := l.db.Create(c, &depositEntity); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetCursor returns the deposit cursor of the address, at height zero when
+// the address was never watched.
+func (l LedgerRepository) GetCursor(c context.Context, address string) (model.DepositCursor, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[GetCursor]")
+	defer span.Finish()
+
+	cursor, err := l.db.Get(c, &entity.DepositCursor{}, persist.D{"address": address})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.DepositCursor{Address: address}, nil
+		}
+		return model.DepositCursor{}, err
+	}
+
+	return mapDepositCursorEntityToModel(*cursor.(*entity.DepositCursor)), nil
+}
+
+func (l LedgerRepository) SaveCursor(c context.Context, m model.DepositCursor) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerRepository[SaveCursor]")
+	defer span.Finish()
+
+	cursor, err := l.db.Get(c, &entity.DepositCursor{}, persist.D{"address": m.Address})
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrRecordNotFound) {
+			return err
+		}
+
+		_, err := l.db.Create(c, &entity.DepositCursor{
+			ID:          uuid.New(),
+			Address:     m.Address,
+			UserId:      m.UserId,
+			BlockHeight: m.BlockHeight,
+		})
+		return err
+	}
+
+	_, err = l.db.Update(c, &entity.DepositCursor{ID: cursor.(*entity.DepositCursor).ID}, persist.D{"block_height": m.BlockHeight})
+	return err
+}
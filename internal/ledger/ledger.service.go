@@ -0,0 +1,490 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/ledger/model"
+	talanmodel "nft/internal/talan/model"
+	"nft/pkg/money"
+)
+
+type LedgerService struct {
+	db               contract.IPersist
+	ledgerRepository contract.ILedgerRepository
+	userService      contract.IUserService
+	userRepository   contract.IUserRepository
+	talanService     contract.ITalanService
+	depositListeners []contract.IDepositListener
+}
+
+type LedgerServiceParams struct {
+	fx.In
+	DB               contract.IPersist
+	LedgerRepository contract.ILedgerRepository
+	UserService      contract.IUserService
+	UserRepository   contract.IUserRepository
+	TalanService     contract.ITalanService
+	DepositListeners []contract.IDepositListener `group:"deposit_listeners"`
+}
+
+func NewLedgerService(params LedgerServiceParams) contract.ILedgerService {
+	return &LedgerService{
+		db:               params.DB,
+		ledgerRepository: params.LedgerRepository,
+		userService:      params.UserService,
+		userRepository:   params.UserRepository,
+		talanService:     params.TalanService,
+		depositListeners: params.DepositListeners,
+	}
+}
+
+func (l LedgerService) GetBalance(c context.Context, userId uuid.UUID) (model.Balance, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[GetBalance]")
+	defer span.Finish()
+
+	available, err := l.account(c, userId, model.AccountTypeAvailable)
+	if err != nil {
+		return model.Balance{}, err
+	}
+
+	escrow, err := l.account(c, userId, model.AccountTypeEscrow)
+	if err != nil {
+		return model.Balance{}, err
+	}
+
+	withdrawal, err := l.account(c, userId, model.AccountTypeWithdrawal)
+	if err != nil {
+		return model.Balance{}, err
+	}
+
+	return model.Balance{Available: available.Balance, Held: escrow.Balance, Withdrawing: withdrawal.Balance}, nil
+}
+
+// SyncDeposits credits the confirmed payments received on the user's Talan
+// address that haven't been credited yet.
+func (l LedgerService) SyncDeposits(c context.Context, userId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[SyncDeposits]")
+	defer span.Finish()
+
+	user, err := l.userService.GetUser(c, persist.D{"id": userId})
+	if err != nil {
+		return err
+	}
+
+	if user.PublicKey == "" {
+		return apperrors.ErrWalletNotAvailable
+	}
+
+	txs, err := l.talanService.GetTransactions(c, talanmodel.Talan{Address: talanmodel.Address{PublicAddress: user.PublicKey}})
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if err := l.CreditDeposit(c, userId, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WatchDeposits credits the confirmed payments received on the wallets of
+// all users. Each address is only read past its cursor.
+func (l LedgerService) WatchDeposits(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[WatchDeposits]")
+	defer span.Finish()
+
+	users, err := l.userRepository.GetAll(c)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.PublicKey == "" {
+			continue
+		}
+		if err := l.watchAddress(c, user.ID, user.PublicKey); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+func (l LedgerService) watchAddress(c context.Context, userId uuid.UUID, address string) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[watchAddress]")
+	defer span.Finish()
+
+	cursor, err := l.ledgerRepository.GetCursor(c, address)
+	if err != nil {
+		return err
+	}
+
+	txs, err := l.talanService.GetTransactions(c, talanmodel.Talan{Address: talanmodel.Address{PublicAddress: address}})
+	if err != nil {
+		return err
+	}
+
+	confirmations := int64(config.C().Ledger.DepositConfirmations)
+	for _, tx := range txs {
+		if tx.BlockHeight > 0 && tx.BlockHeight <= cursor.BlockHeight {
+			continue
+		}
+		if err := l.CreditDeposit(c, userId, tx); err != nil {
+			return err
+		}
+	}
+
+	next := advanceCursor(cursor.BlockHeight, txs, confirmations)
+	if next == cursor.BlockHeight {
+		return nil
+	}
+
+	return l.ledgerRepository.SaveCursor(c, model.DepositCursor{Address: address, UserId: userId, BlockHeight: next})
+}
+
+// CreditDeposit moves a received Talan payment into the user's available
+// balance. It is keyed by the Talan tx id, so crediting the same payment
+// again is a no-op, and it skips payments that aren't confirmed yet. The
+// deposit listeners are told once the credit is committed.
+func (l LedgerService) CreditDeposit(c context.Context, userId uuid.UUID, tx talanmodel.Transaction) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[CreditDeposit]")
+	defer span.Finish()
+
+	if tx.Type != talanmodel.TransactionTypeReceive || tx.Amount <= 0 {
+		return nil
+	}
+
+	if tx.Confirmations < int64(config.C().Ledger.DepositConfirmations) {
+		return nil
+	}
+
+	amount := money.FromFloat(tx.Amount)
+	credited := false
+	err := l.db.Transaction(c, func(c context.Context) error {
+		exists, err := l.ledgerRepository.DepositExists(c, persist.D{"tx_id": tx.ID})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		deposit, err := l.account(c, uuid.Nil, model.AccountTypeDeposit)
+		if err != nil {
+			return err
+		}
+
+		available, err := l.account(c, userId, model.AccountTypeAvailable)
+		if err != nil {
+			return err
+		}
+
+		if err := l.ledgerRepository.AddDeposit(c, model.Deposit{UserId: userId, TxId: tx.ID, Amount: amount}); err != nil {
+			return err
+		}
+
+		credited = true
+		return l.post(c, journal{
+			reference: fmt.Sprintf("deposit:%s", tx.ID),
+			postings: []posting{
+				{account: deposit.ID, kind: model.EntryKindDeposit, amount: -amount},
+				{account: available.ID, kind: model.EntryKindDeposit, amount: amount},
+			},
+		})
+	})
+	if err != nil || !credited {
+		return err
+	}
+
+	event := model.DepositEvent{UserId: userId, TxId: tx.ID, Amount: amount, BlockHeight: tx.BlockHeight}
+	for _, listener := range l.depositListeners {
+		if err := listener.OnDeposit(c, event); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// PlaceHold escrows the amount of an offer out of the buyer's available
+// balance, failing with ErrInsufficientFunds if the buyer can't cover it.
+func (l LedgerService) PlaceHold(c context.Context, m model.Hold) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[PlaceHold]")
+	defer span.Finish()
+
+	return l.db.Transaction(c, func(c context.Context) error {
+		available, err := l.account(c, m.UserId, model.AccountTypeAvailable)
+		if err != nil {
+			return err
+		}
+
+		escrow, err := l.account(c, m.UserId, model.AccountTypeEscrow)
+		if err != nil {
+			return err
+		}
+
+		m.Status = model.HoldStatusHeld
+		if _, err := l.ledgerRepository.AddHold(c, m); err != nil {
+			return err
+		}
+
+		return l.post(c, journal{
+			reference: fmt.Sprintf("hold:%s", m.OfferId),
+			postings: []posting{
+				{account: available.ID, kind: model.EntryKindHold, amount: -m.Amount},
+				{account: escrow.ID, kind: model.EntryKindHold, amount: m.Amount},
+			},
+		})
+	})
+}
+
+// ReleaseHold returns the escrowed amount of an offer to the buyer. Offers
+// without an active hold are left alone, so it is safe to call on every
+// offer that stops competing.
+func (l LedgerService) ReleaseHold(c context.Context, offerId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[ReleaseHold]")
+	defer span.Finish()
+
+	return l.db.Transaction(c, func(c context.Context) error {
+		hold, err := l.ledgerRepository.LockHold(c, persist.D{"offer_id": offerId, "status": model.HoldStatusHeld})
+		if err != nil {
+			if errors.Is(err, apperrors.ErrHoldNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		escrow, err := l.account(c, hold.UserId, model.AccountTypeEscrow)
+		if err != nil {
+			return err
+		}
+
+		available, err := l.account(c, hold.UserId, model.AccountTypeAvailable)
+		if err != nil {
+			return err
+		}
+
+		hold.Status = model.HoldStatusReleased
+		if err := l.ledgerRepository.UpdateHoldStatus(c, hold); err != nil {
+			return err
+		}
+
+		return l.post(c, journal{
+			reference: fmt.Sprintf("release:%s", offerId),
+			postings: []posting{
+				{account: escrow.ID, kind: model.EntryKindRelease, amount: -hold.Amount},
+				{account: available.ID, kind: model.EntryKindRelease, amount: hold.Amount},
+			},
+		})
+	})
+}
+
+// CaptureHold pays the escrowed amount of an accepted offer out to the
+// payees of the settlement. Each payout becomes its own ledger line linked
+// to the settlement transaction, and together they must add up to the hold.
+func (l LedgerService) CaptureHold(c context.Context, m model.Capture) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[CaptureHold]")
+	defer span.Finish()
+
+	return l.db.Transaction(c, func(c context.Context) error {
+		hold, err := l.ledgerRepository.LockHold(c, persist.D{"offer_id": m.OfferId, "status": model.HoldStatusHeld})
+		if err != nil {
+			return err
+		}
+
+		escrow, err := l.account(c, hold.UserId, model.AccountTypeEscrow)
+		if err != nil {
+			return err
+		}
+
+		postings := []posting{{account: escrow.ID, kind: model.EntryKindPayout, amount: -hold.Amount}}
+		for _, payout := range m.Payouts {
+			if payout.Amount == 0 {
+				continue
+			}
+
+			accountType := model.AccountTypeAvailable
+			if payout.UserId == uuid.Nil {
+				accountType = model.AccountTypeFee
+			}
+
+			payee, err := l.account(c, payout.UserId, accountType)
+			if err != nil {
+				return err
+			}
+			postings = append(postings, posting{account: payee.ID, kind: payout.Kind, amount: payout.Amount})
+		}
+
+		hold.Status = model.HoldStatusCaptured
+		if err := l.ledgerRepository.UpdateHoldStatus(c, hold); err != nil {
+			return err
+		}
+
+		return l.post(c, journal{
+			reference:     fmt.Sprintf("capture:%s", m.OfferId),
+			transactionId: &m.TransactionId,
+			postings:      postings,
+		})
+	})
+}
+
+// GetSettledFee returns what the platform took as fee when the transaction
+// settled, which is nothing when no fee entry was posted for it.
+func (l LedgerService) GetSettledFee(c context.Context, transactionId uuid.UUID) (money.Amount, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[GetSettledFee]")
+	defer span.Finish()
+
+	entries, err := l.ledgerRepository.GetEntries(c, persist.D{"transaction_id": transactionId, "kind": model.EntryKindFee})
+	if err != nil {
+		return 0, err
+	}
+
+	var fee money.Amount
+	for _, entry := range entries {
+		fee += entry.Amount
+	}
+	return fee, nil
+}
+
+// HoldWithdrawal sets the amount of a confirmed withdrawal aside, out of
+// the reach of offers, until it is sent or fails.
+func (l LedgerService) HoldWithdrawal(c context.Context, m model.Withdrawal) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[HoldWithdrawal]")
+	defer span.Finish()
+
+	return l.moveWithdrawal(c, m, model.AccountTypeAvailable, model.AccountTypeWithdrawal,
+		model.EntryKindWithdrawal, fmt.Sprintf("withdrawal:%s", m.ID))
+}
+
+// SettleWithdrawal takes the amount of a sent withdrawal off the platform.
+func (l LedgerService) SettleWithdrawal(c context.Context, m model.Withdrawal) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[SettleWithdrawal]")
+	defer span.Finish()
+
+	return l.db.Transaction(c, func(c context.Context) error {
+		withdrawal, err := l.account(c, m.UserId, model.AccountTypeWithdrawal)
+		if err != nil {
+			return err
+		}
+
+		deposit, err := l.account(c, uuid.Nil, model.AccountTypeDeposit)
+		if err != nil {
+			return err
+		}
+
+		return l.post(c, journal{
+			reference: fmt.Sprintf("withdrawal-sent:%s", m.ID),
+			postings: []posting{
+				{account: withdrawal.ID, kind: model.EntryKindWithdrawal, amount: -m.Amount},
+				{account: deposit.ID, kind: model.EntryKindWithdrawal, amount: m.Amount},
+			},
+		})
+	})
+}
+
+// RefundWithdrawal returns the amount of a failed withdrawal to the user.
+func (l LedgerService) RefundWithdrawal(c context.Context, m model.Withdrawal) error {
+	span, c := jtrace.T().SpanFromContext(c, "LedgerService[RefundWithdrawal]")
+	defer span.Finish()
+
+	return l.moveWithdrawal(c, m, model.AccountTypeWithdrawal, model.AccountTypeAvailable,
+		model.EntryKindRelease, fmt.Sprintf("withdrawal-refund:%s", m.ID))
+}
+
+// moveWithdrawal moves the amount of a withdrawal between two accounts of
+// its user.
+func (l LedgerService) moveWithdrawal(c context.Context, m model.Withdrawal, from, to model.AccountType, kind model.EntryKind, reference string) error {
+	return l.db.Transaction(c, func(c context.Context) error {
+		source, err := l.account(c, m.UserId, from)
+		if err != nil {
+			return err
+		}
+
+		destination, err := l.account(c, m.UserId, to)
+		if err != nil {
+			return err
+		}
+
+		return l.post(c, journal{
+			reference: reference,
+			postings: []posting{
+				{account: source.ID, kind: kind, amount: -m.Amount},
+				{account: destination.ID, kind: kind, amount: m.Amount},
+			},
+		})
+	})
+}
+
+// account returns the account of the given type owned by the user, opening
+// it on first use.
+func (l LedgerService) account(c context.Context, userId uuid.UUID, accountType model.AccountType) (model.Account, error) {
+	account, err := l.ledgerRepository.GetAccount(c, persist.D{"user_id": userId, "type": accountType})
+	if err == nil {
+		return account, nil
+	}
+	if !errors.Is(err, apperrors.ErrAccountNotFound) {
+		return model.Account{}, err
+	}
+
+	return l.ledgerRepository.AddAccount(c, model.Account{UserId: userId, Type: accountType})
+}
+
+// post writes a balanced journal. Every account it touches is locked for
+// the rest of the transaction, and none but the platform accounts may end
+// up with a negative balance.
+func (l LedgerService) post(c context.Context, j journal) error {
+	if err := checkJournal(j.postings); err != nil {
+		return err
+	}
+
+	return l.db.Transaction(c, func(c context.Context) error {
+		accounts := make(map[uuid.UUID]model.Account)
+		for _, id := range lockOrder(j.postings) {
+			account, err := l.ledgerRepository.LockAccount(c, persist.D{"id": id})
+			if err != nil {
+				return err
+			}
+			accounts[id] = account
+		}
+
+		journalId := uuid.New()
+		for _, p := range j.postings {
+			account := accounts[p.account]
+			account.Balance += p.amount
+			accounts[p.account] = account
+
+			if err := l.ledgerRepository.AddEntry(c, model.Entry{
+				JournalId:     journalId,
+				AccountId:     p.account,
+				TransactionId: j.transactionId,
+				Kind:          p.kind,
+				Amount:        p.amount,
+				Reference:     j.reference,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, account := range accounts {
+			if account.Balance < 0 && !mayOverdraw(account) {
+				return apperrors.ErrInsufficientFunds
+			}
+			if err := l.ledgerRepository.UpdateBalance(c, account); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package ledger
+
+import (
+	"nft/config"
+	"nft/contract"
+	talanmodel "nft/internal/talan/model"
+	"nft/pkg/schedule"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// StartDepositWatcher periodically credits the payments received on the
+// wallets of all users for the lifetime of the application.
+func StartDepositWatcher(lc fx.Lifecycle, ledgerService contract.ILedgerService) {
+	schedule.Every(lc, "deposit watcher", func() time.Duration {
+		return time.Duration(config.C().Ledger.WatcherIntervalInSec) * time.Second
+	}, ledgerService.WatchDeposits)
+}
+
+// advanceCursor returns how far the cursor of an address may move after
+// its transactions were credited. It stops below the lowest payment still
+// waiting for confirmations, which has to be read again. Payments not in a
+// block yet will land above every block seen, so they don't hold it back.
+func advanceCursor(cursor int64, txs []talanmodel.Transaction, confirmations int64) int64 {
+	next := cursor
+	waiting := int64(-1)
+
+	for _, tx := range txs {
+		if tx.BlockHeight <= cursor {
+			continue
+		}
+		if tx.Type == talanmodel.TransactionTypeReceive && tx.Confirmations < confirmations {
+			if waiting < 0 || tx.BlockHeight < waiting {
+				waiting = tx.BlockHeight
+			}
+			continue
+		}
+		if tx.BlockHeight > next {
+			next = tx.BlockHeight
+		}
+	}
+
+	if waiting >= 0 && next >= waiting {
+		next = waiting - 1
+	}
+	return next
+}
@@ -0,0 +1,252 @@
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/persist/type"
+	"nft/internal/ledger/model"
+	"nft/internal/talan"
+	talanmodel "nft/internal/talan/model"
+	usermodel "nft/internal/user/model"
+	"nft/pkg/money"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAdvanceCursor(t *testing.T) {
+	confirmed := func(height int64) talanmodel.Transaction {
+		return talanmodel.Transaction{BlockHeight: height, Confirmations: 6, Type: talanmodel.TransactionTypeReceive}
+	}
+	waiting := func(height int64) talanmodel.Transaction {
+		return talanmodel.Transaction{BlockHeight: height, Confirmations: 1, Type: talanmodel.TransactionTypeReceive}
+	}
+	sent := func(height int64) talanmodel.Transaction {
+		return talanmodel.Transaction{BlockHeight: height, Confirmations: 1, Type: talanmodel.TransactionTypeSend}
+	}
+
+	tests := []struct {
+		name   string
+		cursor int64
+		txs    []talanmodel.Transaction
+		want   int64
+	}{
+		{"no transactions", 5, nil, 5},
+		{"all confirmed", 0, []talanmodel.Transaction{confirmed(3), confirmed(7)}, 7},
+		{"stops below waiting payment", 0, []talanmodel.Transaction{confirmed(3), waiting(5), confirmed(9)}, 4},
+		{"sends don't wait", 0, []talanmodel.Transaction{confirmed(3), sent(8)}, 8},
+		{"mempool doesn't hold back", 2, []talanmodel.Transaction{waiting(0), confirmed(4)}, 4},
+		{"old transactions ignored", 10, []talanmodel.Transaction{waiting(4), confirmed(8)}, 10},
+	}
+
+	for _, tt := range tests {
+		if got := advanceCursor(tt.cursor, tt.txs, 6); got != tt.want {
+			t.Errorf("%s: advanceCursor() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWatchDeposits(t *testing.T) {
+	userId := uuid.New()
+	talanServer := newFakeTalan()
+	defer talanServer.Close()
+
+	config.C().Talan = config.Talan{
+		BaseUrl:      talanServer.URL,
+		Address:      "address",
+		Transactions: "transactions",
+		Balance:      "balance",
+		Generate:     "generate",
+	}
+	config.C().Ledger.DepositConfirmations = 3
+
+	talanServer.set("wallet", []map[string]any{
+		{"txId": "a", "blockHeight": 10, "amount": 10, "confirmations": 5, "type": "receive"},
+		{"txId": "b", "blockHeight": 11, "amount": 3, "confirmations": 4, "type": "send"},
+		{"txId": "c", "blockHeight": 12, "amount": 5, "confirmations": 1, "type": "receive"},
+	})
+
+	ledgerRepository := newMemLedger()
+	listener := &recordingListener{}
+	service := LedgerService{
+		db:               fakeDB{},
+		ledgerRepository: ledgerRepository,
+		userRepository:   fakeUsers{users: []usermodel.User{{ID: userId, PublicKey: "wallet"}, {ID: uuid.New()}}},
+		talanService:     talan.NewTalanService(talan.TalanServiceParams{TalanRepository: talan.NewTalanRepository(talan.TalanRepositoryParams{})}),
+		depositListeners: []contract.IDepositListener{listener},
+	}
+
+	c := context.Background()
+	if err := service.WatchDeposits(c); err != nil {
+		t.Fatal(err)
+	}
+	if got := ledgerRepository.available(userId); got != money.FromFloat(10) {
+		t.Errorf("available after first run = %v, want 10", got)
+	}
+	if got := ledgerRepository.cursors["wallet"].BlockHeight; got != 11 {
+		t.Errorf("cursor after first run = %d, want 11", got)
+	}
+
+	talanServer.set("wallet", []map[string]any{
+		{"txId": "a", "blockHeight": 10, "amount": 10, "confirmations": 7, "type": "receive"},
+		{"txId": "b", "blockHeight": 11, "amount": 3, "confirmations": 6, "type": "send"},
+		{"txId": "c", "blockHeight": 12, "amount": 5, "confirmations": 3, "type": "receive"},
+	})
+	for i := 0; i < 2; i++ {
+		if err := service.WatchDeposits(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := ledgerRepository.available(userId); got != money.FromFloat(15) {
+		t.Errorf("available after confirmation = %v, want 15", got)
+	}
+	if got := ledgerRepository.cursors["wallet"].BlockHeight; got != 12 {
+		t.Errorf("cursor after confirmation = %d, want 12", got)
+	}
+	if len(listener.events) != 2 || listener.events[0].TxId != "a" || listener.events[1].TxId != "c" {
+		t.Errorf("deposit events = %+v", listener.events)
+	}
+}
+
+// fakeTalan serves the transactions of addresses like the Talan API does.
+type fakeTalan struct {
+	*httptest.Server
+	mu  sync.Mutex
+	txs map[string][]map[string]any
+}
+
+func newFakeTalan() *fakeTalan {
+	f := &fakeTalan{txs: make(map[string][]map[string]any)}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) != 3 || parts[0] != "address" || parts[2] != "transactions" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"statusCode": 404, "error": "not found"})
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"statusCode": 200, "data": f.txs[parts[1]]})
+	}))
+	return f
+}
+
+func (f *fakeTalan) set(address string, txs []map[string]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.txs[address] = txs
+}
+
+type fakeDB struct {
+	contract.IPersist
+}
+
+func (fakeDB) Transaction(c context.Context, fn func(c context.Context) error) error {
+	return fn(c)
+}
+
+type fakeUsers struct {
+	contract.IUserRepository
+	users []usermodel.User
+}
+
+func (f fakeUsers) GetAll(context.Context) ([]usermodel.User, error) {
+	return f.users, nil
+}
+
+type recordingListener struct {
+	events []model.DepositEvent
+}
+
+func (r *recordingListener) OnDeposit(_ context.Context, m model.DepositEvent) error {
+	r.events = append(r.events, m)
+	return nil
+}
+
+// memLedger keeps the accounts, deposits and cursors the deposit watcher
+// touches in memory.
+type memLedger struct {
+	contract.ILedgerRepository
+	accounts map[uuid.UUID]model.Account
+	deposits map[string]bool
+	cursors  map[string]model.DepositCursor
+}
+
+func newMemLedger() *memLedger {
+	return &memLedger{
+		accounts: make(map[uuid.UUID]model.Account),
+		deposits: make(map[string]bool),
+		cursors:  make(map[string]model.DepositCursor),
+	}
+}
+
+func (m *memLedger) available(userId uuid.UUID) money.Amount {
+	for _, account := range m.accounts {
+		if account.UserId == userId && account.Type == model.AccountTypeAvailable {
+			return account.Balance
+		}
+	}
+	return 0
+}
+
+func (m *memLedger) GetAccount(_ context.Context, conditions persist.D) (model.Account, error) {
+	for _, account := range m.accounts {
+		if account.UserId == conditions["user_id"] && account.Type == conditions["type"] {
+			return account, nil
+		}
+	}
+	return model.Account{}, apperrors.ErrAccountNotFound
+}
+
+func (m *memLedger) LockAccount(_ context.Context, conditions persist.D) (model.Account, error) {
+	account, ok := m.accounts[conditions["id"].(uuid.UUID)]
+	if !ok {
+		return model.Account{}, apperrors.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (m *memLedger) AddAccount(_ context.Context, account model.Account) (model.Account, error) {
+	account.ID = uuid.New()
+	m.accounts[account.ID] = account
+	return account, nil
+}
+
+func (m *memLedger) UpdateBalance(_ context.Context, account model.Account) error {
+	m.accounts[account.ID] = account
+	return nil
+}
+
+func (m *memLedger) AddEntry(context.Context, model.Entry) error {
+	return nil
+}
+
+func (m *memLedger) DepositExists(_ context.Context, conditions persist.D) (bool, error) {
+	return m.deposits[conditions["tx_id"].(string)], nil
+}
+
+func (m *memLedger) AddDeposit(_ context.Context, deposit model.Deposit) error {
+	m.deposits[deposit.TxId] = true
+	return nil
+}
+
+func (m *memLedger) GetCursor(_ context.Context, address string) (model.DepositCursor, error) {
+	if cursor, ok := m.cursors[address]; ok {
+		return cursor, nil
+	}
+	return model.DepositCursor{Address: address}, nil
+}
+
+func (m *memLedger) SaveCursor(_ context.Context, cursor model.DepositCursor) error {
+	m.cursors[cursor.Address] = cursor
+	return nil
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"nft/pkg/money"
+	"time"
+)
+
+// Account holds the funds of one owner for one purpose. Platform accounts
+// such as the deposit account are owned by uuid.Nil.
+type Account struct {
+	ID      uuid.UUID
+	UserId  uuid.UUID
+	Type    AccountType
+	Balance money.Amount
+}
+
+type AccountType string
+
+const (
+	AccountTypeAvailable AccountType = "available"
+	AccountTypeEscrow    AccountType = "escrow"
+	// AccountTypeWithdrawal holds what the user asked to withdraw until it
+	// is sent or the withdrawal fails.
+	AccountTypeWithdrawal AccountType = "withdrawal"
+	// AccountTypeDeposit is the counterparty of every deposit and sent
+	// withdrawal, so its balance is the negative of all the money held on
+	// the platform.
+	AccountTypeDeposit AccountType = "deposit"
+	// AccountTypeFee collects the platform fees taken at settlement.
+	AccountTypeFee AccountType = "fee"
+)
+
+type Entry struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	JournalId     uuid.UUID
+	AccountId     uuid.UUID
+	TransactionId *uuid.UUID
+	Kind          EntryKind
+	Amount        money.Amount
+	Reference     string
+}
+
+type EntryKind string
+
+const (
+	EntryKindDeposit EntryKind = "deposit"
+	EntryKindHold    EntryKind = "hold"
+	EntryKindRelease EntryKind = "release"
+	EntryKindPayout  EntryKind = "payout"
+	EntryKindRoyalty EntryKind = "royalty"
+	EntryKindFee     EntryKind = "fee"
+	// EntryKindWithdrawal moves money towards the outside: into the
+	// withdrawal account of the user, and from there out of the platform.
+	EntryKindWithdrawal EntryKind = "withdrawal"
+)
+
+type Hold struct {
+	ID      uuid.UUID
+	UserId  uuid.UUID
+	OfferId uuid.UUID
+	Amount  money.Amount
+	Status  HoldStatus
+}
+
+type HoldStatus string
+
+const (
+	HoldStatusHeld     HoldStatus = "held"
+	HoldStatusReleased HoldStatus = "released"
+	HoldStatusCaptured HoldStatus = "captured"
+)
+
+// Capture pays an escrowed offer out once its sale settles. The payouts
+// split the held amount between the seller, the creator and the platform,
+// which is addressed by uuid.Nil.
+type Capture struct {
+	OfferId       uuid.UUID
+	TransactionId uuid.UUID
+	Payouts       []Payout
+}
+
+type Payout struct {
+	UserId uuid.UUID
+	Kind   EntryKind
+	Amount money.Amount
+}
+
+type Deposit struct {
+	ID     uuid.UUID
+	UserId uuid.UUID
+	TxId   string
+	Amount money.Amount
+}
+
+// DepositEvent tells that a payment received on the user's wallet was
+// credited to the user.
+type DepositEvent struct {
+	UserId      uuid.UUID
+	TxId        string
+	Amount      money.Amount
+	BlockHeight int64
+}
+
+// DepositCursor is the block height up to which every payment received on
+// the address is credited, or will never be.
+type DepositCursor struct {
+	Address     string
+	UserId      uuid.UUID
+	BlockHeight int64
+}
+
+// Withdrawal is the money a withdrawal of the user takes out of the
+// platform.
+type Withdrawal struct {
+	ID     uuid.UUID
+	UserId uuid.UUID
+	Amount money.Amount
+}
+
+type Balance struct {
+	Available   money.Amount
+	Held        money.Amount
+	Withdrawing money.Amount
+}
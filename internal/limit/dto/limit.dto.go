@@ -0,0 +1,11 @@
+package dto
+
+type Allowance struct {
+	Tier             int     `json:"tier"`
+	DailyLimit       float64 `json:"daily_limit"`
+	DailyUsed        float64 `json:"daily_used"`
+	DailyRemaining   float64 `json:"daily_remaining"`
+	MonthlyLimit     float64 `json:"monthly_limit"`
+	MonthlyUsed      float64 `json:"monthly_used"`
+	MonthlyRemaining float64 `json:"monthly_remaining"`
+}
@@ -0,0 +1,45 @@
+package limit
+
+import (
+	"math"
+	"nft/config"
+	"nft/internal/limit/model"
+	"time"
+)
+
+const (
+	dailyWindow   = 24 * time.Hour
+	monthlyWindow = 30 * 24 * time.Hour
+)
+
+// tierLimits returns the limits of the highest configured tier at or below
+// the level. A user without an approved KYC, or below every tier, may not
+// trade at all.
+func tierLimits(tiers []config.KycTier, level int) (config.KycTier, bool) {
+	var limits config.KycTier
+	found := false
+	for _, tier := range tiers {
+		if tier.Level <= level && (!found || tier.Level > limits.Level) {
+			limits = tier
+			found = true
+		}
+	}
+	return limits, found
+}
+
+func allowance(tier int, limits config.KycTier, dailyUsed, monthlyUsed float64) model.Allowance {
+	return model.Allowance{
+		Tier:             tier,
+		DailyLimit:       limits.DailyVolume,
+		DailyUsed:        dailyUsed,
+		DailyRemaining:   math.Max(limits.DailyVolume-dailyUsed, 0),
+		MonthlyLimit:     limits.MonthlyVolume,
+		MonthlyUsed:      monthlyUsed,
+		MonthlyRemaining: math.Max(limits.MonthlyVolume-monthlyUsed, 0),
+	}
+}
+
+// fits reports whether a trade of the amount stays within both windows.
+func fits(a model.Allowance, amount float64) bool {
+	return amount <= a.DailyRemaining && amount <= a.MonthlyRemaining
+}
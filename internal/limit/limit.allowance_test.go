@@ -0,0 +1,47 @@
+package limit
+
+import (
+	"nft/config"
+	"testing"
+)
+
+func TestTierLimits(t *testing.T) {
+	tiers := []config.KycTier{
+		{Level: 1, DailyVolume: 1000, MonthlyVolume: 10000},
+		{Level: 3, DailyVolume: 100000, MonthlyVolume: 1000000},
+	}
+
+	if _, ok := tierLimits(tiers, 0); ok {
+		t.Error("tierLimits() found limits for an unverified user")
+	}
+
+	limits, ok := tierLimits(tiers, 2)
+	if !ok || limits.Level != 1 {
+		t.Errorf("tierLimits(2) = %+v, %v, want level 1", limits, ok)
+	}
+
+	limits, ok = tierLimits(tiers, 5)
+	if !ok || limits.Level != 3 {
+		t.Errorf("tierLimits(5) = %+v, %v, want level 3", limits, ok)
+	}
+}
+
+func TestAllowance(t *testing.T) {
+	limits := config.KycTier{Level: 1, DailyVolume: 1000, MonthlyVolume: 10000}
+
+	a := allowance(1, limits, 400, 9800)
+	if a.DailyRemaining != 600 || a.MonthlyRemaining != 200 {
+		t.Errorf("allowance() remaining = %v daily, %v monthly, want 600 and 200", a.DailyRemaining, a.MonthlyRemaining)
+	}
+	if !fits(a, 200) {
+		t.Error("fits() = false for a trade within both windows")
+	}
+	if fits(a, 300) {
+		t.Error("fits() = true for a trade over the monthly remaining")
+	}
+
+	a = allowance(1, limits, 1500, 1500)
+	if a.DailyRemaining != 0 {
+		t.Errorf("allowance() daily remaining = %v, want 0 once over the limit", a.DailyRemaining)
+	}
+}
@@ -0,0 +1,51 @@
+package limit
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/contract"
+	"nft/infra/jtrace"
+	"nft/pkg/filper"
+)
+
+type LimitController struct {
+	limitService contract.ILimitService
+}
+
+type LimitControllerParams struct {
+	fx.In
+	LimitService contract.ILimitService
+}
+
+func NewLimitController(params LimitControllerParams) contract.ILimitController {
+	return &LimitController{
+		limitService: params.LimitService,
+	}
+}
+
+// GetAllowance godoc
+// @Summary  get trading volume left under the limits of user's kyc tier
+// @Tags     limit
+// @Accept   json
+// @Produce  json
+// @Router   /v1/limit/allowance [get]
+// @Success  200  {object}  dto.Allowance
+func (l LimitController) GetAllowance(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "LimitController[GetAllowance]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	allowance, err := l.limitService.GetAllowance(ctx, userId)
+	if err != nil {
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapAllowanceModelToDto(allowance))
+}
@@ -0,0 +1,18 @@
+package limit
+
+import (
+	"nft/internal/limit/dto"
+	"nft/internal/limit/model"
+)
+
+func mapAllowanceModelToDto(m model.Allowance) dto.Allowance {
+	return dto.Allowance{
+		Tier:             m.Tier,
+		DailyLimit:       m.DailyLimit,
+		DailyUsed:        m.DailyUsed,
+		DailyRemaining:   m.DailyRemaining,
+		MonthlyLimit:     m.MonthlyLimit,
+		MonthlyUsed:      m.MonthlyUsed,
+		MonthlyRemaining: m.MonthlyRemaining,
+	}
+}
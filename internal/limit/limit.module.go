@@ -0,0 +1,8 @@
+package limit
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewLimitController),
+	fx.Provide(NewLimitService),
+)
@@ -0,0 +1,147 @@
+package limit
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/limit/model"
+	"time"
+)
+
+type LimitService struct {
+	userRepository        contract.IUserRepository
+	kycService            contract.IKycService
+	offerRepository       contract.IOfferRepository
+	transactionRepository contract.ITransactionRepository
+}
+
+type LimitServiceParams struct {
+	fx.In
+	UserRepository        contract.IUserRepository
+	KycService            contract.IKycService
+	OfferRepository       contract.IOfferRepository
+	TransactionRepository contract.ITransactionRepository
+}
+
+func NewLimitService(params LimitServiceParams) contract.ILimitService {
+	return &LimitService{
+		userRepository:        params.UserRepository,
+		kycService:            params.KycService,
+		offerRepository:       params.OfferRepository,
+		transactionRepository: params.TransactionRepository,
+	}
+}
+
+func (l LimitService) GetAllowance(c context.Context, userId uuid.UUID) (model.Allowance, error) {
+	span, c := jtrace.T().SpanFromContext(c, "LimitService[GetAllowance]")
+	defer span.Finish()
+
+	tier, err := l.kycService.GetTier(c, userId)
+	if err != nil {
+		return model.Allowance{}, err
+	}
+
+	limits, ok := tierLimits(config.C().Kyc.Tiers, tier)
+	if !ok {
+		return model.Allowance{Tier: tier}, nil
+	}
+
+	dailyUsed, monthlyUsed, err := l.volume(c, userId)
+	if err != nil {
+		return model.Allowance{}, err
+	}
+
+	return allowance(tier, limits, dailyUsed, monthlyUsed), nil
+}
+
+// Check returns an error unless the user may trade the amount: list a sale at
+// it as min price, or offer, bid or pay it. The user row is locked first, so
+// concurrent trades of the same user are checked one after the other. The
+// caller must run it in the database transaction that records the trade.
+func (l LimitService) Check(c context.Context, userId uuid.UUID, amount float64) error {
+	span, c := jtrace.T().SpanFromContext(c, "LimitService[Check]")
+	defer span.Finish()
+
+	if _, err := l.userRepository.Lock(c, persist.D{"id": userId}); err != nil {
+		return err
+	}
+
+	a, err := l.GetAllowance(c, userId)
+	if err != nil {
+		return err
+	}
+
+	if a.Tier == 0 {
+		return apperrors.ErrKycRequired
+	}
+
+	if !fits(a, amount) {
+		return apperrors.ErrTradingLimitExceeded
+	}
+
+	return nil
+}
+
+// volume sums what the user traded in the daily and monthly windows: its
+// offers that are still open or were accepted, and the prices its own sales
+// were settled at.
+func (l LimitService) volume(c context.Context, userId uuid.UUID) (float64, float64, error) {
+	now := time.Now()
+	dayStart, monthStart := now.Add(-dailyWindow), now.Add(-monthlyWindow)
+
+	var daily, monthly float64
+	add := func(price float64, at time.Time) {
+		monthly += price
+		if !at.Before(dayStart) {
+			daily += price
+		}
+	}
+
+	offers, err := l.offerRepository.GetAll(c, persist.D{
+		"user_id":       userId,
+		"rejected_at":   nil,
+		"created_at >=": monthStart,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, offer := range offers {
+		add(offer.Price, offer.CreatedAt)
+	}
+
+	sales, err := l.transactionRepository.GetAll(c, persist.D{
+		"seller_id":     userId,
+		"created_at >=": monthStart,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(sales) == 0 {
+		return daily, monthly, nil
+	}
+
+	soldAt := make(map[uuid.UUID]time.Time, len(sales))
+	offerIds := make([]uuid.UUID, len(sales))
+	for i, sale := range sales {
+		soldAt[sale.OfferId] = sale.CreatedAt
+		offerIds[i] = sale.OfferId
+	}
+
+	acceptedOffers, err := l.offerRepository.GetAll(c, persist.D{"id in": offerIds})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, offer := range acceptedOffers {
+		add(offer.Price, soldAt[*offer.ID])
+	}
+
+	return daily, monthly, nil
+}
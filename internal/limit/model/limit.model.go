@@ -0,0 +1,14 @@
+package model
+
+// Allowance is how much trading volume a user has left under the limits of
+// its KYC tier. Volumes count both what the user bought, or is bidding, and
+// what it sold.
+type Allowance struct {
+	Tier             int
+	DailyLimit       float64
+	DailyUsed        float64
+	DailyRemaining   float64
+	MonthlyLimit     float64
+	MonthlyUsed      float64
+	MonthlyRemaining float64
+}
@@ -12,10 +12,56 @@ type Nft struct {
 	Categories      []catdto.CategoryDto `json:"categories,omitempty"`
 	User            userdto.User         `json:"user,omitempty"`
 	Status          string               `json:"status,omitempty"`
+	CollectionId    string               `json:"collection_id,omitempty"`
 	NftImageUrl     string               `json:"nft_image_url,omitempty"`
 	RejectionReason string               `json:"rejection_reason,omitempty"`
+	RoyaltyPercent  float64              `json:"royalty_percent"`
+	ContractAddress string               `json:"contract_address,omitempty"`
+	TokenId         string               `json:"token_id,omitempty"`
+}
+
+type Royalty struct {
+	RoyaltyPercent *float64 `json:"royalty_percent" validate:"required,min=0"`
+}
+
+type SetCollection struct {
+	CollectionId string `json:"collection_id" validate:"required,uuid"`
 }
 
 type NftList struct {
 	Nfts []Nft `json:"nfts"`
 }
+
+type StatusChange struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	ActorId   string `json:"actor_id"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type StatusHistory struct {
+	Changes []StatusChange `json:"changes"`
+}
+
+type HistoryRequest struct {
+	Offset int `query:"offset" validate:"omitempty,min=0"`
+	Limit  int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type HistoryEvent struct {
+	Kind           string  `json:"kind"`
+	ActorId        string  `json:"actor_id"`
+	CounterpartyId string  `json:"counterparty_id,omitempty"`
+	Price          float64 `json:"price,omitempty"`
+	SaleId         string  `json:"sale_id,omitempty"`
+	OfferId        string  `json:"offer_id,omitempty"`
+	TransactionId  string  `json:"transaction_id,omitempty"`
+	CreatedAt      int64   `json:"created_at"`
+}
+
+type HistoryPage struct {
+	Events     []HistoryEvent `json:"events"`
+	Total      int            `json:"total"`
+	NextOffset int            `json:"next_offset,omitempty"`
+}
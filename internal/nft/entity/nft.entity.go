@@ -16,10 +16,43 @@ type Nft struct {
 	ApprovedBy      *uuid.UUID `gorm:"type:uuid"`
 	RejectedBy      *uuid.UUID `gorm:"type:uuid"`
 	RejectionReason *sql.NullString
-	UserId          uuid.UUID `gorm:"type:uuid"`
+	UserId          uuid.UUID  `gorm:"type:uuid"`
+	CollectionId    *uuid.UUID `gorm:"type:uuid;index"`
 	NftImage        *sql.NullString
 	Title           *sql.NullString
 	Description     *sql.NullString
 	CategoryIds     pq.StringArray `gorm:"type:text[]"`
-	Draft           bool
+	Status          string         `gorm:"index"`
+	RoyaltyPercent  float64
+	ContractAddress string
+	TokenId         string
+}
+
+type NftStatusChange struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	NftId      uuid.UUID `gorm:"type:uuid;index"`
+	FromStatus string
+	ToStatus   string
+	ActorId    uuid.UUID `gorm:"type:uuid"`
+	Reason     *sql.NullString
+}
+
+// NftHistoryEvent is a row of the nft_history_events view, which gathers the
+// provenance of every nft from the tables it is recorded in.
+type NftHistoryEvent struct {
+	ID             uuid.UUID `gorm:"type:uuid"`
+	NftId          uuid.UUID `gorm:"type:uuid"`
+	At             time.Time
+	Ord            int
+	Kind           string
+	ActorId        uuid.UUID  `gorm:"type:uuid"`
+	CounterpartyId *uuid.UUID `gorm:"type:uuid"`
+	Price          float64
+	SaleId         *uuid.UUID `gorm:"type:uuid"`
+	OfferId        *uuid.UUID `gorm:"type:uuid"`
+	TransactionId  *uuid.UUID `gorm:"type:uuid"`
 }
@@ -0,0 +1,45 @@
+package nft
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type HistoryEventKind string
+
+const (
+	HistoryEventMint            HistoryEventKind = "mint"
+	HistoryEventListed          HistoryEventKind = "listed"
+	HistoryEventListingCanceled HistoryEventKind = "listing_canceled"
+	HistoryEventListingExpired  HistoryEventKind = "listing_expired"
+	HistoryEventOffer           HistoryEventKind = "offer"
+	HistoryEventOfferRejected   HistoryEventKind = "offer_rejected"
+	HistoryEventSold            HistoryEventKind = "sold"
+	HistoryEventTransferred     HistoryEventKind = "transferred"
+)
+
+// HistoryEvent is one step in the life of an nft. The actor is who took the
+// step; the counterparty, when there is one, is who the step was taken
+// towards, like the seller of a sale an offer was made to.
+type HistoryEvent struct {
+	At             time.Time
+	Kind           HistoryEventKind
+	ActorId        uuid.UUID
+	CounterpartyId *uuid.UUID
+	Price          float64
+	SaleId         *uuid.UUID
+	OfferId        *uuid.UUID
+	TransactionId  *uuid.UUID
+}
+
+type HistoryQuery struct {
+	NftId  uuid.UUID
+	Offset int
+	Limit  int
+}
+
+type HistoryPage struct {
+	Events     []HistoryEvent
+	Total      int
+	NextOffset int
+}
@@ -5,13 +5,14 @@ import (
 	catmodel "nft/internal/category/model"
 	file "nft/internal/file/model"
 	user "nft/internal/user/model"
+	"time"
 )
 
 type Nft struct {
-	ID          *uuid.UUID
-	Title       string
-	Description string
-	//CollectionId *uuid.UUID
+	ID              *uuid.UUID
+	Title           string
+	Description     string
+	CollectionId    *uuid.UUID
 	Categories      []catmodel.Category
 	User            user.User
 	CurrentOwner    *user.User
@@ -20,14 +21,29 @@ type Nft struct {
 	RejectedBy      *user.User
 	RejectionReason string
 	ApprovedBy      *user.User
+	RoyaltyPercent  float64
+	ContractAddress string
+	TokenId         string
 }
 
 type NftStatus string
 
 const (
 	NftStatusDraft     NftStatus = "Draft"
-	NftStatusPending             = "Pending"
-	NftStatusRejected            = "Rejected"
-	NftStatusApproved            = "Approved"
-	NftStatusProcessed           = "Processed"
+	NftStatusPending   NftStatus = "Pending"
+	NftStatusRejected  NftStatus = "Rejected"
+	NftStatusApproved  NftStatus = "Approved"
+	NftStatusProcessed NftStatus = "Processed"
 )
+
+// StatusChange is one transition of an nft in its lifecycle. From is empty
+// for the change that created the nft.
+type StatusChange struct {
+	ID        *uuid.UUID
+	CreatedAt time.Time
+	NftId     uuid.UUID
+	From      NftStatus
+	To        NftStatus
+	Actor     user.User
+	Reason    string
+}
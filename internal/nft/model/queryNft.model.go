@@ -0,0 +1,8 @@
+package nft
+
+import "github.com/google/uuid"
+
+type QueryNft struct {
+	UserId      *uuid.UUID
+	CategoryIds []uuid.UUID
+}
@@ -13,6 +13,7 @@ import (
 	model "nft/internal/nft/model"
 	usermodel "nft/internal/user/model"
 	"nft/pkg/filper"
+	"nft/pkg/validator"
 )
 
 type NftController struct {
@@ -36,13 +37,14 @@ func NewNftController(params NftControllerParams) contract.INftController {
 // @Accept   multipart/form-data
 // @Produce  json
 // @Router   /v1/nft [post]
-// @Param    id             formData  string   false  "Nft id. Required for updating draft"
-// @Param    title          formData  string   false  "Nft title. Not required for draft"
-// @Param    description    formData  string   false  "Nft description. Not required for draft"
-// @Param    draft          formData  boolean  true   "Nft submission type. If it's true it will be saved as draft. If it's false it will be submitted to be processed."
-// @Param    category_id    formData  array    false  "Nft category or sub category id."
-// @Param    collection_id  formData  string   false  "Nft related collection id"
-// @Param    nft_image      formData  file     true   "Nft image"
+// @Param    id               formData  string   false  "Nft id. Required for updating draft"
+// @Param    title            formData  string   false  "Nft title. Not required for draft"
+// @Param    description      formData  string   false  "Nft description. Not required for draft"
+// @Param    draft            formData  boolean  true   "Nft submission type. If it's true it will be saved as draft. If it's false it will be submitted to be processed."
+// @Param    category_id      formData  array    false  "Nft category or sub category id."
+// @Param    collection_id    formData  string   false  "Nft related collection id"
+// @Param    royalty_percent  formData  number   false  "Creator royalty on resales, capped by the platform maximum"
+// @Param    nft_image        formData  file     true   "Nft image"
 func (n NftController) Create(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[Create]")
 	defer span.Finish()
@@ -70,6 +72,12 @@ func (n NftController) Create(c *fiber.Ctx) error {
 	if err != nil {
 		if errors.Is(err, apperrors.ErrInvalidFileExtension) {
 			return filper.GetBadRequestError(c, err.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidRoyalty) {
+			return filper.GetBadRequestError(c, err.Error())
+		} else if errors.Is(err, apperrors.ErrCollectionNotFound) {
+			return filper.GetNotFoundError(c, err.Error())
+		} else if errors.Is(err, apperrors.ErrCollectionOnSale) {
+			return filper.GetBadRequestError(c, err.Error())
 		}
 		return filper.GetInternalError(c, "")
 	}
@@ -207,10 +215,10 @@ func (n NftController) Reject(c *fiber.Ctx) error {
 	if err != nil {
 		if errors.Is(err, apperrors.ErrNftNotFound) {
 			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrNftNotSubmittedForReview) {
+			return filper.GetBadRequestError(c, apperrors.ErrNftNotSubmittedForReview.Error())
 		}
 		return filper.GetInternalError(c, "")
-	} else if errors.Is(err, apperrors.ErrNftNotSubmittedForReview) {
-		return filper.GetBadRequestError(c, apperrors.ErrNftNotSubmittedForReview.Error())
 	}
 
 	return filper.GetSuccessResponse(c, "nft rejected successfully")
@@ -247,3 +255,237 @@ func (n NftController) DeleteDraft(c *fiber.Ctx) error {
 
 	return filper.GetSuccessResponse(c, "draft deleted successfully")
 }
+
+// SetRoyalty godoc
+// @Summary  set creator royalty of nft
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string       true  "nft id"
+// @Param    message  body      dto.Royalty  true  "royalty percent"
+// @Success  200      {string}  string       "royalty updated successfully"
+// @Router   /v1/nft/{id}/royalty [post]
+func (n NftController) SetRoyalty(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[SetRoyalty]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	var request dto.Royalty
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	err = n.nftService.SetRoyalty(ctx, model.Nft{ID: &nftId, User: usermodel.User{ID: userId}, RoyaltyPercent: *request.RoyaltyPercent})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNftNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidRoyalty) {
+			return filper.GetBadRequestError(c, apperrors.ErrInvalidRoyalty.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "royalty updated successfully")
+}
+
+// Submit godoc
+// @Summary  submit draft or rejected nft for review
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "nft id that will be submitted"
+// @Success  200  {string}  string  "nft submitted successfully"
+// @Router   /v1/nft/{id}/submit [post]
+func (n NftController) Submit(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[Submit]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	err = n.nftService.Submit(ctx, model.Nft{ID: &nftId, User: usermodel.User{ID: userId}})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNftNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrNftIncomplete) {
+			return filper.GetBadRequestError(c, apperrors.ErrNftIncomplete.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidNftTransition) {
+			return filper.GetBadRequestError(c, apperrors.ErrInvalidNftTransition.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "nft submitted successfully")
+}
+
+// GetStatusHistory godoc
+// @Summary  get status changes of nft, oldest first
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "nft id"
+// @Success  200  {object}  dto.StatusHistory
+// @Router   /v1/nft/{id}/status-history [get]
+func (n NftController) GetStatusHistory(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[GetStatusHistory]")
+	defer span.Finish()
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	changes, err := n.nftService.GetStatusHistory(ctx, nftId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNftNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createStatusHistoryDtoFromModel(changes))
+}
+
+// GetHistory godoc
+// @Summary  get provenance of nft, oldest first
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id      path      string  true   "nft id"
+// @Param    offset  query     int     false  "next_offset of the previous page"
+// @Param    limit   query     int     false  "page size, at most 100"
+// @Success  200     {object}  dto.HistoryPage
+// @Router   /v1/nft/{id}/history [get]
+func (n NftController) GetHistory(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[GetHistory]")
+	defer span.Finish()
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	var request dto.HistoryRequest
+	if err := c.QueryParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid query params")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	page, err := n.nftService.GetHistory(ctx, model.HistoryQuery{NftId: nftId, Offset: request.Offset, Limit: request.Limit})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNftNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapHistoryPageModelToDto(page))
+}
+
+// SetCollection godoc
+// @Summary  move nft into one of your collections
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string             true  "nft id"
+// @Param    message  body      dto.SetCollection  true  "collection the nft moves into"
+// @Success  200      {string}  string             "nft moved successfully"
+// @Router   /v1/nft/{id}/collection [put]
+func (n NftController) SetCollection(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[SetCollection]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	var request dto.SetCollection
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	collectionId := uuid.MustParse(request.CollectionId)
+	err = n.nftService.SetCollection(ctx, model.Nft{ID: &nftId, CurrentOwner: &usermodel.User{ID: userId}, CollectionId: &collectionId})
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "nft moved successfully")
+}
+
+// RemoveFromCollection godoc
+// @Summary  take nft out of its collection
+// @Tags     nft
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "nft id"
+// @Success  200  {string}  string  "nft removed from collection successfully"
+// @Router   /v1/nft/{id}/collection [delete]
+func (n NftController) RemoveFromCollection(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NftController[RemoveFromCollection]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	err = n.nftService.SetCollection(ctx, model.Nft{ID: &nftId, CurrentOwner: &usermodel.User{ID: userId}})
+	if err != nil {
+		return collectionError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "nft removed from collection successfully")
+}
+
+// collectionError maps the errors of moving an nft between collections to a
+// response.
+func collectionError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, apperrors.ErrNftNotFound) || errors.Is(err, apperrors.ErrCollectionNotFound) {
+		return filper.GetNotFoundError(c, err.Error())
+	} else if errors.Is(err, apperrors.ErrCollectionOnSale) || errors.Is(err, apperrors.ErrNftMoved) {
+		return filper.GetBadRequestError(c, err.Error())
+	}
+	return filper.GetInternalError(c, "")
+}
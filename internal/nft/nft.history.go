@@ -0,0 +1,37 @@
+package nft
+
+import model "nft/internal/nft/model"
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// historyWindow clamps the offset and limit a client asked for. Events only
+// ever join the end of the history, so an offset keeps pointing at the same
+// event while a client pages through it.
+func historyWindow(offset int, limit int) (int, int) {
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, limit
+}
+
+// historyPage wraps the events read at the offset out of the total. The next
+// offset is left zero on the last page.
+func historyPage(events []model.HistoryEvent, total int, offset int) model.HistoryPage {
+	page := model.HistoryPage{Events: events, Total: total}
+	if page.Events == nil {
+		page.Events = []model.HistoryEvent{}
+	}
+	if end := offset + len(events); end < total {
+		page.NextOffset = end
+	}
+	return page
+}
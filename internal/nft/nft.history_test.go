@@ -0,0 +1,46 @@
+package nft
+
+import (
+	model "nft/internal/nft/model"
+	"testing"
+)
+
+func TestHistoryWindow(t *testing.T) {
+	tests := []struct {
+		name                  string
+		offset, limit         int
+		wantOffset, wantLimit int
+	}{
+		{"defaults", -5, 0, 0, defaultHistoryLimit},
+		{"within bounds", 40, 10, 40, 10},
+		{"limit capped", 0, 1000, 0, maxHistoryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, limit := historyWindow(tt.offset, tt.limit)
+			if offset != tt.wantOffset || limit != tt.wantLimit {
+				t.Errorf("historyWindow() = %d, %d, want %d, %d", offset, limit, tt.wantOffset, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestHistoryPage(t *testing.T) {
+	events := make([]model.HistoryEvent, 3)
+
+	page := historyPage(events, 4, 0)
+	if page.Total != 4 || page.NextOffset != 3 || len(page.Events) != 3 {
+		t.Errorf("first page = %+v", page)
+	}
+
+	last := historyPage(events[:1], 4, 3)
+	if last.NextOffset != 0 || len(last.Events) != 1 {
+		t.Errorf("last page = %+v", last)
+	}
+
+	past := historyPage(nil, 4, 10)
+	if past.Events == nil || len(past.Events) != 0 || past.NextOffset != 0 {
+		t.Errorf("page past the end = %+v", past)
+	}
+}
@@ -3,6 +3,7 @@ package nft
 import (
 	"database/sql"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"io"
 	"mime/multipart"
 	category "nft/internal/category/model"
@@ -82,14 +83,14 @@ func mapAndValidateCreateNftForm(form *multipart.Form, userId uuid.UUID) (model.
 		}
 	}
 
-	//collectionId, ok := form.Message["collection_id"]
-	//if ok {
-	//	nftModel.CollectionId = collectionId[0]
-	//} else {
-	//	if !draft {
-	//		errs = append(errs, validator.ErrorResponse{Field: "collection_id", Message: "unable to get collection_id from multipart form"})
-	//	}
-	//}
+	collectionId, ok := form.Value["collection_id"]
+	if ok && len(collectionId[0]) > 0 {
+		colId, err := uuid.Parse(collectionId[0])
+		if err != nil {
+			errs.AddError("collection_id", collectionId[0], "invalid collection id")
+		}
+		nftModel.CollectionId = &colId
+	}
 
 	categoryIds, ok := form.Value["category_id"]
 	if ok {
@@ -108,6 +109,15 @@ func mapAndValidateCreateNftForm(form *multipart.Form, userId uuid.UUID) (model.
 		}
 	}
 
+	royalty, ok := form.Value["royalty_percent"]
+	if ok {
+		percent, err := strconv.ParseFloat(royalty[0], 64)
+		if err != nil {
+			errs.AddError("royalty_percent", royalty[0], "invalid royalty percent")
+		}
+		nftModel.RoyaltyPercent = percent
+	}
+
 	if draft && len(desc) < 1 && len(title) < 1 && nftModel.NftImage == nil {
 		errs.AddError("", nil, "you need to provide a title, description or image to save draft")
 		return model.Nft{}, errs
@@ -130,6 +140,13 @@ func MapNftModelToDto(m model.Nft) dto.Nft {
 	nftDto.Description = m.Description
 	nftDto.Status = string(m.Status)
 	nftDto.RejectionReason = m.RejectionReason
+	nftDto.RoyaltyPercent = m.RoyaltyPercent
+	nftDto.ContractAddress = m.ContractAddress
+	nftDto.TokenId = m.TokenId
+
+	if m.CollectionId != nil {
+		nftDto.CollectionId = m.CollectionId.String()
+	}
 
 	return nftDto
 }
@@ -144,11 +161,9 @@ func mapNftModelToEntity(m model.Nft) entity.Nft {
 	}
 	nftEntity.CategoryIds = catIds
 
-	status := false
-	if m.Status == model.NftStatusDraft {
-		status = true
-	}
-	nftEntity.Draft = status
+	nftEntity.Status = string(m.Status)
+	nftEntity.RoyaltyPercent = m.RoyaltyPercent
+	nftEntity.CollectionId = m.CollectionId
 
 	if m.ID != nil {
 		nftEntity.ID = *m.ID
@@ -180,7 +195,6 @@ func mapNftModelToEntity(m model.Nft) entity.Nft {
 
 func mapNftEntityToModel(nft entity.Nft) model.Nft {
 	var nftModel model.Nft
-	var status model.NftStatus
 
 	categories := make([]category.Category, len(nft.CategoryIds))
 	for i, id := range nft.CategoryIds {
@@ -188,14 +202,16 @@ func mapNftEntityToModel(nft entity.Nft) model.Nft {
 		categories[i] = category.Category{ID: catId}
 	}
 
-	if nft.Draft {
-		status = model.NftStatusDraft
-	} else if nft.ApprovedBy != nil {
-		status = model.NftStatusApproved
-	} else if nft.RejectedBy != nil {
-		status = model.NftStatusRejected
-	} else {
-		status = model.NftStatusPending
+	if nft.ApprovedBy != nil {
+		nftModel.ApprovedBy = &user.User{ID: *nft.ApprovedBy}
+	}
+
+	if nft.RejectedBy != nil {
+		nftModel.RejectedBy = &user.User{ID: *nft.RejectedBy}
+	}
+
+	if nft.RejectionReason != nil {
+		nftModel.RejectionReason = nft.RejectionReason.String
 	}
 
 	if nft.NftImage != nil {
@@ -211,9 +227,13 @@ func mapNftEntityToModel(nft entity.Nft) model.Nft {
 	}
 
 	nftModel.ID = &nft.ID
-	nftModel.Status = status
+	nftModel.Status = model.NftStatus(nft.Status)
 	nftModel.Categories = categories
 	nftModel.User = user.User{ID: nft.UserId}
+	nftModel.RoyaltyPercent = nft.RoyaltyPercent
+	nftModel.CollectionId = nft.CollectionId
+	nftModel.ContractAddress = nft.ContractAddress
+	nftModel.TokenId = nft.TokenId
 
 	return nftModel
 }
@@ -235,3 +255,112 @@ func createModelNftListFromEntity(nfts []entity.Nft) []model.Nft {
 	}
 	return nftList
 }
+
+func categoryArray(ids []uuid.UUID) pq.StringArray {
+	catIds := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		catIds[i] = id.String()
+	}
+	return catIds
+}
+
+func mapStatusChangeModelToEntity(m model.StatusChange) entity.NftStatusChange {
+	change := entity.NftStatusChange{
+		NftId:      m.NftId,
+		FromStatus: string(m.From),
+		ToStatus:   string(m.To),
+		ActorId:    m.Actor.ID,
+	}
+
+	if len(m.Reason) > 0 {
+		change.Reason = &sql.NullString{String: m.Reason, Valid: true}
+	}
+
+	return change
+}
+
+func mapStatusChangeEntityToModel(e entity.NftStatusChange) model.StatusChange {
+	change := model.StatusChange{
+		ID:        &e.ID,
+		CreatedAt: e.CreatedAt,
+		NftId:     e.NftId,
+		From:      model.NftStatus(e.FromStatus),
+		To:        model.NftStatus(e.ToStatus),
+		Actor:     user.User{ID: e.ActorId},
+	}
+
+	if e.Reason != nil {
+		change.Reason = e.Reason.String
+	}
+
+	return change
+}
+
+func createModelStatusChangeListFromEntity(changes []entity.NftStatusChange) []model.StatusChange {
+	changeList := make([]model.StatusChange, len(changes))
+	for i := range changes {
+		changeList[i] = mapStatusChangeEntityToModel(changes[i])
+	}
+	return changeList
+}
+
+func mapHistoryEventEntityToModel(e entity.NftHistoryEvent) model.HistoryEvent {
+	return model.HistoryEvent{
+		At:             e.At,
+		Kind:           model.HistoryEventKind(e.Kind),
+		ActorId:        e.ActorId,
+		CounterpartyId: e.CounterpartyId,
+		Price:          e.Price,
+		SaleId:         e.SaleId,
+		OfferId:        e.OfferId,
+		TransactionId:  e.TransactionId,
+	}
+}
+
+func createModelHistoryEventListFromEntity(events []entity.NftHistoryEvent) []model.HistoryEvent {
+	eventList := make([]model.HistoryEvent, len(events))
+	for i := range events {
+		eventList[i] = mapHistoryEventEntityToModel(events[i])
+	}
+	return eventList
+}
+
+func createStatusHistoryDtoFromModel(changes []model.StatusChange) dto.StatusHistory {
+	changeList := make([]dto.StatusChange, len(changes))
+	for i, change := range changes {
+		changeList[i] = dto.StatusChange{
+			From:      string(change.From),
+			To:        string(change.To),
+			ActorId:   change.Actor.ID.String(),
+			Reason:    change.Reason,
+			CreatedAt: change.CreatedAt.Unix(),
+		}
+	}
+	return dto.StatusHistory{Changes: changeList}
+}
+
+func mapHistoryPageModelToDto(page model.HistoryPage) dto.HistoryPage {
+	events := make([]dto.HistoryEvent, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = dto.HistoryEvent{
+			Kind:      string(event.Kind),
+			ActorId:   event.ActorId.String(),
+			Price:     event.Price,
+			CreatedAt: event.At.Unix(),
+		}
+		if event.CounterpartyId != nil {
+			events[i].CounterpartyId = event.CounterpartyId.String()
+		}
+		if event.SaleId != nil {
+			events[i].SaleId = event.SaleId.String()
+		}
+		if event.OfferId != nil {
+			events[i].OfferId = event.OfferId.String()
+		}
+		if event.TransactionId != nil {
+			events[i].TransactionId = event.TransactionId.String()
+		}
+	}
+
+	return dto.HistoryPage{Events: events, Total: page.Total, NextOffset: page.NextOffset}
+}
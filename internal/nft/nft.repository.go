@@ -2,9 +2,11 @@ package nft
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"nft/contract"
+	apperrors "nft/error"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	entity "nft/internal/nft/entity"
@@ -37,7 +39,10 @@ func (n NftRepository) Add(c context.Context, m model.Nft) (model.Nft, error) {
 	defer span.Finish()
 
 	nftEntity := mapNftModelToEntity(m)
-	nftEntity.ID = uuid.New()
+	// an edited draft is added again under its own id
+	if nftEntity.ID == uuid.Nil {
+		nftEntity.ID = uuid.New()
+	}
 
 	createdNft, err := n.db.Create(c, &nftEntity)
 	if err != nil {
@@ -99,3 +104,122 @@ func (n NftRepository) GetAll(c context.Context, conditions persist.D) ([]model.
 
 	return createModelNftListFromEntity(*catList.(*[]entity.Nft)), nil
 }
+
+func (n NftRepository) UpdateRoyalty(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[UpdateRoyalty]")
+	defer span.Finish()
+
+	if _, err := n.db.Update(c, &entity.Nft{ID: *m.ID}, persist.D{"royalty_percent": m.RoyaltyPercent}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateCollection moves the nft into its collection, out of any when the
+// model leaves it unset.
+func (n NftRepository) UpdateCollection(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[UpdateCollection]")
+	defer span.Finish()
+
+	if _, err := n.db.Update(c, &entity.Nft{ID: *m.ID}, persist.D{"collection_id": m.CollectionId}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateToken records the contract the nft was minted on and its token id.
+func (n NftRepository) UpdateToken(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[UpdateToken]")
+	defer span.Finish()
+
+	if _, err := n.db.Update(c, &entity.Nft{ID: *m.ID}, persist.D{"contract_address": m.ContractAddress, "token_id": m.TokenId}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (n NftRepository) Lock(c context.Context, conditions persist.D) (model.Nft, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[Lock]")
+	defer span.Finish()
+
+	nft, err := n.db.Lock(c, &entity.Nft{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Nft{}, apperrors.ErrNftNotFound
+		}
+		return model.Nft{}, err
+	}
+
+	return mapNftEntityToModel(*nft.(*entity.Nft)), nil
+}
+
+// UpdateStatus writes the status along with the review columns, which are
+// cleared when the model leaves them unset.
+func (n NftRepository) UpdateStatus(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[UpdateStatus]")
+	defer span.Finish()
+
+	data := persist.D{"status": m.Status, "approved_by": nil, "rejected_by": nil, "rejection_reason": nil}
+	if m.ApprovedBy != nil {
+		data["approved_by"] = m.ApprovedBy.ID
+	}
+	if m.RejectedBy != nil {
+		data["rejected_by"] = m.RejectedBy.ID
+		data["rejection_reason"] = m.RejectionReason
+	}
+
+	if _, err := n.db.Update(c, &entity.Nft{ID: *m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (n NftRepository) AddStatusChange(c context.Context, m model.StatusChange) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[AddStatusChange]")
+	defer span.Finish()
+
+	changeEntity := mapStatusChangeModelToEntity(m)
+	changeEntity.ID = uuid.New()
+
+	if _, err := n.db.Create(c, &changeEntity); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (n NftRepository) GetStatusChanges(c context.Context, nftId uuid.UUID) ([]model.StatusChange, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[GetStatusChanges]")
+	defer span.Finish()
+
+	changes, err := n.db.Find(c, &[]entity.NftStatusChange{}, persist.Query{
+		Conditions: persist.D{"nft_id": nftId},
+		Order:      "created_at asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelStatusChangeListFromEntity(*changes.(*[]entity.NftStatusChange)), nil
+}
+
+// GetHistory returns the events of the nft history view the query matches.
+func (n NftRepository) GetHistory(c context.Context, query persist.Query) ([]model.HistoryEvent, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[GetHistory]")
+	defer span.Finish()
+
+	query.Unscoped = true
+	events, err := n.db.Find(c, &[]entity.NftHistoryEvent{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelHistoryEventListFromEntity(*events.(*[]entity.NftHistoryEvent)), nil
+}
+
+// CountHistory returns the number of events in the history of the nft.
+func (n NftRepository) CountHistory(c context.Context, nftId uuid.UUID) (int, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftRepository[CountHistory]")
+	defer span.Finish()
+
+	return n.db.Count(c, &entity.NftHistoryEvent{}, persist.D{"nft_id": nftId})
+}
@@ -8,30 +8,55 @@ import (
 	"nft/config"
 	"nft/contract"
 	apperrors "nft/error"
+	"nft/infra/eventbus"
+	eventmodel "nft/infra/eventbus/model"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	model "nft/internal/nft/model"
+	notificationmodel "nft/internal/notification/model"
+	salemodel "nft/internal/sale/model"
 	usermodel "nft/internal/user/model"
+	"nft/pkg/royalty"
+	"sort"
+	"time"
 )
 
 type NftService struct {
-	fileService        contract.IFileService
-	nftRepository      contract.INftRepository
-	transactionService contract.ITransactionService
+	db                   contract.IPersist
+	fileService          contract.IFileService
+	nftRepository        contract.INftRepository
+	collectionRepository contract.ICollectionRepository
+	saleRepository       contract.ISaleRepository
+	transactionService   contract.ITransactionService
+	chainService         contract.IChainService
+	notificationService  contract.INotificationService
+	outboxService        contract.IOutboxService
 }
 
 type NftServiceParams struct {
 	fx.In
-	FileService        contract.IFileService
-	NftRepository      contract.INftRepository
-	TransactionService contract.ITransactionService
+	DB                   contract.IPersist
+	FileService          contract.IFileService
+	NftRepository        contract.INftRepository
+	CollectionRepository contract.ICollectionRepository
+	SaleRepository       contract.ISaleRepository
+	TransactionService   contract.ITransactionService
+	ChainService         contract.IChainService
+	NotificationService  contract.INotificationService
+	OutboxService        contract.IOutboxService
 }
 
 func NewNftService(params NftServiceParams) contract.INftService {
 	return NftService{
-		fileService:        params.FileService,
-		nftRepository:      params.NftRepository,
-		transactionService: params.TransactionService,
+		db:                   params.DB,
+		fileService:          params.FileService,
+		nftRepository:        params.NftRepository,
+		collectionRepository: params.CollectionRepository,
+		saleRepository:       params.SaleRepository,
+		transactionService:   params.TransactionService,
+		chainService:         params.ChainService,
+		notificationService:  params.NotificationService,
+		outboxService:        params.OutboxService,
 	}
 }
 
@@ -39,34 +64,78 @@ func (n NftService) Create(c context.Context, m model.Nft) (model.Nft, error) {
 	span, c := jtrace.T().SpanFromContext(c, "NftService[Create]")
 	defer span.Finish()
 
-	if m.Status == model.NftStatusDraft {
+	if !royalty.Valid(m.RoyaltyPercent) {
+		return model.Nft{}, apperrors.ErrInvalidRoyalty
+	}
+
+	if m.NftImage != nil {
+		m.NftImage.Bucket = config.C().Storage.Buckets.NFT
+		nftFileName, err := n.fileService.UploadImage(c, *m.NftImage)
+		if err != nil {
+			return model.Nft{}, err
+		}
+		m.NftImage.FileName = nftFileName
+	}
+
+	var nftModel model.Nft
+	err := n.db.Transaction(c, func(c context.Context) error {
+		// an edited draft starts over from its previous status, a new nft
+		// from none
+		var from model.NftStatus
 		if m.ID != nil {
-			nftModel, err := n.nftRepository.Get(c, persist.D{"id": m.ID.String()})
+			draft, err := n.nftRepository.Lock(c, persist.D{"id": *m.ID, "user_id": m.User.ID})
 			if err != nil {
-				return model.Nft{}, apperrors.ErrNftDraftNotFound
+				return apperrors.ErrNftDraftNotFound
 			}
 
-			if nftModel.Status != model.NftStatusDraft {
-				return model.Nft{}, apperrors.ErrNftIsNotDraft
+			if draft.Status != model.NftStatusDraft {
+				return apperrors.ErrNftIsNotDraft
 			}
+			from = draft.Status
 
-			err = n.nftRepository.HardDelete(c, *m.ID)
-			if err != nil {
-				return model.Nft{}, err
+			if err := n.nftRepository.HardDelete(c, *m.ID); err != nil {
+				return err
 			}
 		}
-	}
 
-	if m.NftImage != nil {
-		m.NftImage.Bucket = config.C().Storage.Buckets.NFT
-		nftFileName, err := n.fileService.UploadImage(c, *m.NftImage)
+		if m.CollectionId != nil {
+			if _, err := n.collectionRepository.Lock(c, persist.D{"id": *m.CollectionId}); err != nil {
+				return err
+			}
+			if err := n.checkCollection(c, *m.CollectionId, m.User.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		nftModel, err = n.nftRepository.Add(c, m)
 		if err != nil {
-			return model.Nft{}, err
+			return err
+		}
+
+		// an edited draft was announced when it was first created
+		if m.ID == nil {
+			if err := eventbus.Enqueue(c, n.outboxService, eventmodel.NftCreated{
+				NftId:      *nftModel.ID,
+				CreatorId:  m.User.ID,
+				Title:      nftModel.Title,
+				OccurredAt: time.Now(),
+			}); err != nil {
+				return err
+			}
 		}
-		m.NftImage.FileName = nftFileName
-	}
 
-	nftModel, err := n.nftRepository.Add(c, m)
+		if from == nftModel.Status {
+			return nil
+		}
+
+		return n.nftRepository.AddStatusChange(c, model.StatusChange{
+			NftId: *nftModel.ID,
+			From:  from,
+			To:    nftModel.Status,
+			Actor: m.User,
+		})
+	})
 	if err != nil {
 		return model.Nft{}, err
 	}
@@ -79,6 +148,9 @@ func (n NftService) GetOwnedNft(c context.Context, m model.Nft) (model.Nft, erro
 
 	nft, err := n.nftRepository.Get(c, persist.D{"id": *m.ID})
 	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Nft{}, apperrors.ErrNftNotFound
+		}
 		return model.Nft{}, err
 	}
 
@@ -86,22 +158,15 @@ func (n NftService) GetOwnedNft(c context.Context, m model.Nft) (model.Nft, erro
 		return model.Nft{}, apperrors.ErrNftNotFound
 	}
 
-	tx, err := n.transactionService.GetLastTransaction(c, *nft.ID)
+	ownerId, err := n.transactionService.GetOwner(c, *nft.ID, nft.User.ID)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrTransactionNotFound) {
-			if m.CurrentOwner.ID != nft.User.ID {
-				return model.Nft{}, apperrors.ErrNftNotFound
-			}
-			nft.CurrentOwner = &usermodel.User{ID: nft.User.ID}
-			return nft, nil
-		}
 		return model.Nft{}, err
 	}
 
-	if nft.CurrentOwner.ID != m.CurrentOwner.ID {
+	if ownerId != m.CurrentOwner.ID {
 		return model.Nft{}, apperrors.ErrNftNotFound
 	}
-	nft.CurrentOwner = &usermodel.User{ID: tx.BuyerId}
+	nft.CurrentOwner = &usermodel.User{ID: ownerId}
 
 	return nft, nil
 }
@@ -110,52 +175,166 @@ func (n NftService) Approve(c context.Context, m model.Nft) error {
 	span, c := jtrace.T().SpanFromContext(c, "NftService[Approve]")
 	defer span.Finish()
 
-	nftModel, err := n.nftRepository.Get(c, persist.D{"id": m.ID})
-	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrNftNotFound
+	return n.db.Transaction(c, func(c context.Context) error {
+		nftModel, err := n.nftRepository.Lock(c, persist.D{"id": *m.ID})
+		if err != nil {
+			return err
 		}
-		return err
-	}
 
-	if nftModel.Status != model.NftStatusPending {
-		return apperrors.ErrNftNotSubmittedForReview
-	}
+		if nftModel.Status != model.NftStatusPending {
+			return apperrors.ErrNftNotSubmittedForReview
+		}
 
-	nftModel.ApprovedBy = m.ApprovedBy
-	nftModel.RejectedBy = nil
-	nftModel.RejectionReason = ""
+		nftModel.ApprovedBy = m.ApprovedBy
+		nftModel.RejectedBy = nil
+		nftModel.RejectionReason = ""
 
-	if _, err := n.nftRepository.Update(c, nftModel); err != nil {
-		return err
-	}
+		if err := n.transition(c, nftModel, model.NftStatusApproved, m.ApprovedBy.ID, ""); err != nil {
+			return err
+		}
 
-	return nil
+		if err := n.chainService.QueueMint(c, nftModel); err != nil {
+			return err
+		}
+
+		if err := n.notificationService.Notify(c, notificationmodel.Notification{
+			UserId: nftModel.User.ID,
+			Kind:   notificationmodel.KindNftApproved,
+			NftId:  nftModel.ID,
+		}); err != nil {
+			return err
+		}
+
+		return eventbus.Enqueue(c, n.outboxService, eventmodel.NftApproved{
+			NftId:      *nftModel.ID,
+			CreatorId:  nftModel.User.ID,
+			ApprovedBy: m.ApprovedBy.ID,
+			OccurredAt: time.Now(),
+		})
+	})
 }
 
 func (n NftService) Reject(c context.Context, m model.Nft) error {
 	span, c := jtrace.T().SpanFromContext(c, "NftService[Reject]")
 	defer span.Finish()
 
-	nftModel, err := n.nftRepository.Get(c, persist.D{"id": m.ID})
-	if err != nil {
+	return n.db.Transaction(c, func(c context.Context) error {
+		nftModel, err := n.nftRepository.Lock(c, persist.D{"id": *m.ID})
+		if err != nil {
+			return err
+		}
+
+		if nftModel.Status != model.NftStatusPending {
+			return apperrors.ErrNftNotSubmittedForReview
+		}
+
+		nftModel.RejectedBy = m.RejectedBy
+		nftModel.RejectionReason = m.RejectionReason
+		nftModel.ApprovedBy = nil
+
+		if err := n.transition(c, nftModel, model.NftStatusRejected, m.RejectedBy.ID, m.RejectionReason); err != nil {
+			return err
+		}
+
+		return n.notificationService.Notify(c, notificationmodel.Notification{
+			UserId: nftModel.User.ID,
+			Kind:   notificationmodel.KindNftRejected,
+			NftId:  nftModel.ID,
+			Reason: m.RejectionReason,
+		})
+	})
+}
+
+// Submit sends a draft, or an nft whose review was rejected, to be reviewed.
+func (n NftService) Submit(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[Submit]")
+	defer span.Finish()
+
+	return n.db.Transaction(c, func(c context.Context) error {
+		nftModel, err := n.nftRepository.Lock(c, persist.D{"id": *m.ID, "user_id": m.User.ID})
+		if err != nil {
+			return err
+		}
+
+		if !complete(nftModel) {
+			return apperrors.ErrNftIncomplete
+		}
+
+		nftModel.ApprovedBy = nil
+		nftModel.RejectedBy = nil
+		nftModel.RejectionReason = ""
+
+		return n.transition(c, nftModel, model.NftStatusPending, m.User.ID, "")
+	})
+}
+
+func (n NftService) GetStatusHistory(c context.Context, nftId uuid.UUID) ([]model.StatusChange, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[GetStatusHistory]")
+	defer span.Finish()
+
+	if _, err := n.nftRepository.Get(c, persist.D{"id": nftId}); err != nil {
 		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrNftNotFound
+			return nil, apperrors.ErrNftNotFound
 		}
-		return err
+		return nil, err
 	}
 
-	if nftModel.Status != model.NftStatusPending {
-		return apperrors.ErrNftNotSubmittedForReview
+	return n.nftRepository.GetStatusChanges(c, nftId)
+}
+
+// GetHistory returns a page of the provenance of the nft: its mint, its
+// listings and those of the collections it changed hands in, the offers made
+// to them and every time it changed hands, oldest first.
+func (n NftService) GetHistory(c context.Context, query model.HistoryQuery) (model.HistoryPage, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[GetHistory]")
+	defer span.Finish()
+
+	if _, err := n.nftRepository.Get(c, persist.D{"id": query.NftId}); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.HistoryPage{}, apperrors.ErrNftNotFound
+		}
+		return model.HistoryPage{}, err
 	}
 
-	nftModel.RejectedBy = m.RejectedBy
-	nftModel.RejectionReason = m.RejectionReason
-	nftModel.ApprovedBy = nil
-	if _, err := n.nftRepository.Update(c, nftModel); err != nil {
+	total, err := n.nftRepository.CountHistory(c, query.NftId)
+	if err != nil {
+		return model.HistoryPage{}, err
+	}
+
+	offset, limit := historyWindow(query.Offset, query.Limit)
+	events, err := n.nftRepository.GetHistory(c, persist.Query{
+		Conditions: persist.D{"nft_id": query.NftId},
+		Order:      "at asc, ord asc, id asc",
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return model.HistoryPage{}, err
+	}
+
+	return historyPage(events, total, offset), nil
+}
+
+// transition moves the nft to the given status and logs the change. The nft
+// keeps the review columns the caller set on it.
+func (n NftService) transition(c context.Context, m model.Nft, to model.NftStatus, actorId uuid.UUID, reason string) error {
+	from := m.Status
+	if !canTransition(from, to) {
+		return apperrors.ErrInvalidNftTransition
+	}
+
+	m.Status = to
+	if err := n.nftRepository.UpdateStatus(c, m); err != nil {
 		return err
 	}
-	return nil
+
+	return n.nftRepository.AddStatusChange(c, model.StatusChange{
+		NftId:  *m.ID,
+		From:   from,
+		To:     to,
+		Actor:  usermodel.User{ID: actorId},
+		Reason: reason,
+	})
 }
 
 func (n NftService) GetNft(c context.Context, m model.Nft) (model.Nft, error) {
@@ -212,6 +391,166 @@ func (n NftService) GetAllNfts(c context.Context, userId uuid.UUID) ([]model.Nft
 	return nfts, nil
 }
 
+func (n NftService) QueryNfts(c context.Context, query model.QueryNft) ([]model.Nft, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[QueryNfts]")
+	defer span.Finish()
+
+	conditions := persist.D{"status <>": model.NftStatusDraft}
+	if query.UserId != nil {
+		conditions["user_id"] = *query.UserId
+	}
+	if len(query.CategoryIds) > 0 {
+		conditions["category_ids &&"] = categoryArray(query.CategoryIds)
+	}
+
+	nfts, err := n.nftRepository.GetAll(c, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, nft := range nfts {
+		if nft.NftImage == nil {
+			continue
+		}
+
+		nft.NftImage.Bucket = config.C().Storage.Buckets.NFT
+		nftUrl, err := n.fileService.GetImageUrl(c, *nft.NftImage)
+		if err != nil {
+			return nil, err
+		}
+
+		nfts[i].NftImage.FileUrl = nftUrl
+	}
+
+	return nfts, nil
+}
+
+// SetCollection moves an nft of the current owner into one of the owner's
+// collections, or out of its collection when none is given. Collections
+// listed for sale can't gain or lose nfts.
+func (n NftService) SetCollection(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[SetCollection]")
+	defer span.Finish()
+
+	return n.db.Transaction(c, func(c context.Context) error {
+		nft, err := n.GetOwnedNft(c, m)
+		if err != nil {
+			return err
+		}
+
+		if sameCollection(nft.CollectionId, m.CollectionId) {
+			return nil
+		}
+
+		// both collections are locked in id order before the nft, the order
+		// listing a collection locks it and its members in, so the move is
+		// serialized with listing either collection and with other moves
+		var collectionIds []uuid.UUID
+		for _, id := range []*uuid.UUID{nft.CollectionId, m.CollectionId} {
+			if id != nil {
+				collectionIds = append(collectionIds, *id)
+			}
+		}
+		sort.Slice(collectionIds, func(i, j int) bool {
+			return collectionIds[i].String() < collectionIds[j].String()
+		})
+		for _, id := range collectionIds {
+			if _, err := n.collectionRepository.Lock(c, persist.D{"id": id}); err != nil {
+				return err
+			}
+		}
+
+		locked, err := n.nftRepository.Lock(c, persist.D{"id": *nft.ID})
+		if err != nil {
+			return err
+		}
+		if !sameCollection(locked.CollectionId, nft.CollectionId) {
+			return apperrors.ErrNftMoved
+		}
+
+		if nft.CollectionId != nil {
+			if err := n.checkNotOnSale(c, *nft.CollectionId); err != nil {
+				return err
+			}
+		}
+
+		if m.CollectionId != nil {
+			if err := n.checkCollection(c, *m.CollectionId, m.CurrentOwner.ID); err != nil {
+				return err
+			}
+		}
+
+		return n.nftRepository.UpdateCollection(c, model.Nft{ID: nft.ID, CollectionId: m.CollectionId})
+	})
+}
+
+// checkCollection makes sure the collection is currently owned by the user
+// and can take new nfts.
+func (n NftService) checkCollection(c context.Context, collectionId uuid.UUID, userId uuid.UUID) error {
+	col, err := n.collectionRepository.Get(c, persist.D{"id": collectionId})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return apperrors.ErrCollectionNotFound
+		}
+		return err
+	}
+
+	ownerId, err := n.transactionService.GetOwner(c, collectionId, col.User.ID)
+	if err != nil {
+		return err
+	}
+	if ownerId != userId {
+		return apperrors.ErrCollectionNotFound
+	}
+
+	return n.checkNotOnSale(c, collectionId)
+}
+
+func (n NftService) checkNotOnSale(c context.Context, collectionId uuid.UUID) error {
+	sales, err := n.saleRepository.GetAll(c, persist.D{"asset_id": collectionId, "status": salemodel.SaleStatusInProgress})
+	if err != nil {
+		return err
+	}
+	if len(sales) > 0 {
+		return apperrors.ErrCollectionOnSale
+	}
+	return nil
+}
+
+func sameCollection(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GetCollectionNfts returns the nfts in the collection.
+func (n NftService) GetCollectionNfts(c context.Context, collectionId uuid.UUID) ([]model.Nft, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[GetCollectionNfts]")
+	defer span.Finish()
+
+	nfts, err := n.nftRepository.GetAll(c, persist.D{"collection_id": collectionId})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, nft := range nfts {
+		if nft.NftImage == nil {
+			continue
+		}
+
+		nft.NftImage.Bucket = config.C().Storage.Buckets.NFT
+		nftUrl, err := n.fileService.GetImageUrl(c, *nft.NftImage)
+		if err != nil {
+			return nil, err
+		}
+
+		nfts[i].NftImage.FileUrl = nftUrl
+	}
+
+	return nfts, nil
+}
+
 func (n NftService) DeleteDraft(c context.Context, m model.Nft) error {
 	span, c := jtrace.T().SpanFromContext(c, "NftService[DeleteDraft]")
 	defer span.Finish()
@@ -226,3 +565,22 @@ func (n NftService) DeleteDraft(c context.Context, m model.Nft) error {
 
 	return n.nftRepository.Delete(c, *m.ID)
 }
+
+// SetRoyalty changes the royalty the creator earns on resales of the nft.
+func (n NftService) SetRoyalty(c context.Context, m model.Nft) error {
+	span, c := jtrace.T().SpanFromContext(c, "NftService[SetRoyalty]")
+	defer span.Finish()
+
+	if !royalty.Valid(m.RoyaltyPercent) {
+		return apperrors.ErrInvalidRoyalty
+	}
+
+	if _, err := n.nftRepository.Get(c, persist.D{"id": *m.ID, "user_id": m.User.ID}); err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return apperrors.ErrNftNotFound
+		}
+		return err
+	}
+
+	return n.nftRepository.UpdateRoyalty(c, m)
+}
@@ -0,0 +1,26 @@
+package nft
+
+import model "nft/internal/nft/model"
+
+// nftTransitions lists the statuses an nft may move to from each status. A
+// rejected nft goes back to review when its owner submits it again.
+var nftTransitions = map[model.NftStatus][]model.NftStatus{
+	model.NftStatusDraft:    {model.NftStatusPending},
+	model.NftStatusPending:  {model.NftStatusApproved, model.NftStatusRejected},
+	model.NftStatusRejected: {model.NftStatusPending},
+	model.NftStatusApproved: {model.NftStatusProcessed},
+}
+
+func canTransition(from, to model.NftStatus) bool {
+	for _, status := range nftTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// complete reports whether the nft has everything a reviewer needs.
+func complete(m model.Nft) bool {
+	return len(m.Title) > 0 && len(m.Description) > 0 && len(m.Categories) > 0 && m.NftImage != nil
+}
@@ -0,0 +1,49 @@
+package nft
+
+import (
+	catmodel "nft/internal/category/model"
+	file "nft/internal/file/model"
+	model "nft/internal/nft/model"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to model.NftStatus
+		want     bool
+	}{
+		{model.NftStatusDraft, model.NftStatusPending, true},
+		{model.NftStatusPending, model.NftStatusApproved, true},
+		{model.NftStatusPending, model.NftStatusRejected, true},
+		{model.NftStatusRejected, model.NftStatusPending, true},
+		{model.NftStatusApproved, model.NftStatusProcessed, true},
+		{model.NftStatusDraft, model.NftStatusApproved, false},
+		{model.NftStatusRejected, model.NftStatusApproved, false},
+		{model.NftStatusApproved, model.NftStatusRejected, false},
+		{model.NftStatusProcessed, model.NftStatusPending, false},
+		{model.NftStatusPending, model.NftStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	nft := model.Nft{
+		Title:       "title",
+		Description: "description",
+		Categories:  []catmodel.Category{{}},
+		NftImage:    &file.Image{},
+	}
+	if !complete(nft) {
+		t.Error("complete() = false for an nft with every field set")
+	}
+
+	nft.NftImage = nil
+	if complete(nft) {
+		t.Error("complete() = true for an nft without an image")
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+type Notification struct {
+	ID        string  `json:"id"`
+	Kind      string  `json:"kind"`
+	SaleId    string  `json:"sale_id,omitempty"`
+	NftId     string  `json:"nft_id,omitempty"`
+	Price     float64 `json:"price,omitempty"`
+	Reason    string  `json:"reason,omitempty"`
+	Read      bool    `json:"read"`
+	CreatedAt int64   `json:"created_at"`
+}
+
+type NotificationList struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+type UnreadCount struct {
+	Count int `json:"count"`
+}
+
+type Preference struct {
+	Kind  string `json:"kind" validate:"required,oneof=offer_received outbid offer_accepted sale_sold sale_expired sale_canceled kyc_approved kyc_rejected nft_approved nft_rejected"`
+	Email bool   `json:"email"`
+}
+
+type Language struct {
+	Language string `json:"language" validate:"required,oneof=en-US fa-IR"`
+}
+
+type PreferenceList struct {
+	Preferences []Preference `json:"preferences" validate:"required,min=1,dive"`
+}
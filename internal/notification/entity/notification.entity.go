@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Notification struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId uuid.UUID `gorm:"type:uuid;index"`
+	Kind   string
+	SaleId *uuid.UUID `gorm:"type:uuid"`
+	NftId  *uuid.UUID `gorm:"type:uuid"`
+	Price  float64
+	Reason *sql.NullString
+	ReadAt *time.Time
+}
+
+type NotificationPreference struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_notification_preference"`
+	Kind   string    `gorm:"uniqueIndex:idx_notification_preference"`
+	Email  bool
+}
+
+type NotificationLanguage struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId   uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	Language string
+}
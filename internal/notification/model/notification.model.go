@@ -0,0 +1,65 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Notification tells a user about something that happened to their sales,
+// offers, nfts or identity verification. Which of sale, nft, price and
+// reason are set depends on its kind.
+type Notification struct {
+	ID        *uuid.UUID
+	CreatedAt time.Time
+	UserId    uuid.UUID
+	Kind      Kind
+	SaleId    *uuid.UUID
+	NftId     *uuid.UUID
+	Price     float64
+	Reason    string
+	ReadAt    *time.Time
+}
+
+type Kind string
+
+const (
+	// KindOfferReceived tells the seller about a new offer or bid.
+	KindOfferReceived Kind = "offer_received"
+	// KindOutbid tells a bidder a higher bid replaced theirs.
+	KindOutbid Kind = "outbid"
+	// KindOfferAccepted tells the buyer their offer settled the sale.
+	KindOfferAccepted Kind = "offer_accepted"
+	// KindSaleSold tells the seller their sale settled.
+	KindSaleSold Kind = "sale_sold"
+	// KindSaleExpired tells the seller, and whoever had an open offer,
+	// that the sale ended unsold.
+	KindSaleExpired Kind = "sale_expired"
+	// KindSaleCanceled tells whoever had an open offer that the seller
+	// canceled the sale.
+	KindSaleCanceled Kind = "sale_canceled"
+	KindKycApproved  Kind = "kyc_approved"
+	KindKycRejected  Kind = "kyc_rejected"
+	KindNftApproved  Kind = "nft_approved"
+	KindNftRejected  Kind = "nft_rejected"
+)
+
+// Kinds lists every kind, in the order preferences are shown.
+var Kinds = []Kind{
+	KindOfferReceived,
+	KindOutbid,
+	KindOfferAccepted,
+	KindSaleSold,
+	KindSaleExpired,
+	KindSaleCanceled,
+	KindKycApproved,
+	KindKycRejected,
+	KindNftApproved,
+	KindNftRejected,
+}
+
+// Preference is whether notifications of the kind are also emailed to the
+// user. A kind the user never set is emailed.
+type Preference struct {
+	Kind  Kind
+	Email bool
+}
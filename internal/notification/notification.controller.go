@@ -0,0 +1,251 @@
+package notification
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/notification/dto"
+	"nft/pkg/filper"
+	"nft/pkg/lang"
+	"nft/pkg/validator"
+)
+
+type NotificationController struct {
+	notificationService contract.INotificationService
+}
+
+type NotificationControllerParams struct {
+	fx.In
+	NotificationService contract.INotificationService
+}
+
+func NewNotificationController(params NotificationControllerParams) contract.INotificationController {
+	return &NotificationController{
+		notificationService: params.NotificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary  get your latest notifications
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Param    unread  query     bool  false  "only the unread ones"
+// @Success  200     {object}  dto.NotificationList
+// @Router   /v1/notification [get]
+func (n NotificationController) GetNotifications(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[GetNotifications]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	notifications, err := n.notificationService.GetNotifications(ctx, userId, c.Query("unread") == "true")
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createNotificationListDtoFromModel(notifications))
+}
+
+// GetUnreadCount godoc
+// @Summary  get how many of your notifications are unread
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  dto.UnreadCount
+// @Router   /v1/notification/unread-count [get]
+func (n NotificationController) GetUnreadCount(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[GetUnreadCount]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	count, err := n.notificationService.GetUnreadCount(ctx, userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.UnreadCount{Count: count})
+}
+
+// MarkRead godoc
+// @Summary  mark a notification as read
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "notification id"
+// @Success  200  {string}  string  "notification read"
+// @Router   /v1/notification/{id}/read [post]
+func (n NotificationController) MarkRead(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[MarkRead]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	notificationId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNotificationId.Error())
+	}
+
+	if err := n.notificationService.MarkRead(ctx, notificationId, userId); err != nil {
+		if errors.Is(err, apperrors.ErrNotificationNotFound) {
+			return filper.GetNotFoundError(c, err.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "notification read")
+}
+
+// MarkAllRead godoc
+// @Summary  mark all your notifications as read
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Success  200  {string}  string  "notifications read"
+// @Router   /v1/notification/read-all [post]
+func (n NotificationController) MarkAllRead(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[MarkAllRead]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	if err := n.notificationService.MarkAllRead(ctx, userId); err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "notifications read")
+}
+
+// GetPreferences godoc
+// @Summary  get which notifications are also emailed to you
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  dto.PreferenceList
+// @Router   /v1/notification/preferences [get]
+func (n NotificationController) GetPreferences(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[GetPreferences]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	preferences, err := n.notificationService.GetPreferences(ctx, userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createPreferenceListDtoFromModel(preferences))
+}
+
+// SetPreferences godoc
+// @Summary  choose which notifications are also emailed to you
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Param    message  body      dto.PreferenceList  true  "kinds to change"
+// @Success  200      {object}  dto.PreferenceList
+// @Router   /v1/notification/preferences [put]
+func (n NotificationController) SetPreferences(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[SetPreferences]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	var request dto.PreferenceList
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	preferences, err := n.notificationService.SetPreferences(ctx, userId, createModelPreferenceListFromDto(request))
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createPreferenceListDtoFromModel(preferences))
+}
+
+// GetLanguage godoc
+// @Summary  get the language your emails are written in
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  dto.Language
+// @Router   /v1/notification/language [get]
+func (n NotificationController) GetLanguage(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[GetLanguage]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	language, err := n.notificationService.GetLanguage(ctx, userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Language{Language: string(language)})
+}
+
+// SetLanguage godoc
+// @Summary  choose the language your emails are written in
+// @Tags     notification
+// @Accept   json
+// @Produce  json
+// @Param    message  body      dto.Language  true  "language"
+// @Success  200      {object}  dto.Language
+// @Router   /v1/notification/language [put]
+func (n NotificationController) SetLanguage(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "NotificationController[SetLanguage]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	var request dto.Language
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	if err := n.notificationService.SetLanguage(ctx, userId, lang.Language(request.Language)); err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"github.com/google/uuid"
+	emailmodel "nft/internal/email/model"
+	"nft/internal/notification/model"
+)
+
+// emailContent returns the email telling the same as the notification.
+func emailContent(n model.Notification) emailmodel.Content {
+	var saleId, nftId uuid.UUID
+	if n.SaleId != nil {
+		saleId = *n.SaleId
+	}
+	if n.NftId != nil {
+		nftId = *n.NftId
+	}
+
+	switch n.Kind {
+	case model.KindOfferReceived:
+		return emailmodel.Content{Kind: emailmodel.KindOfferReceived, Data: emailmodel.OfferReceivedData{SaleId: saleId, Price: n.Price}}
+	case model.KindOutbid:
+		return emailmodel.Content{Kind: emailmodel.KindOutbid, Data: emailmodel.OutbidData{SaleId: saleId, Price: n.Price}}
+	case model.KindOfferAccepted:
+		return emailmodel.Content{Kind: emailmodel.KindOfferAccepted, Data: emailmodel.OfferAcceptedData{SaleId: saleId, Price: n.Price}}
+	case model.KindSaleSold:
+		return emailmodel.Content{Kind: emailmodel.KindSaleSold, Data: emailmodel.SaleSoldData{SaleId: saleId, Price: n.Price}}
+	case model.KindSaleExpired:
+		return emailmodel.Content{Kind: emailmodel.KindSaleExpired, Data: emailmodel.SaleExpiredData{SaleId: saleId}}
+	case model.KindSaleCanceled:
+		return emailmodel.Content{Kind: emailmodel.KindSaleCanceled, Data: emailmodel.SaleCanceledData{SaleId: saleId}}
+	case model.KindKycApproved, model.KindKycRejected:
+		return emailmodel.Content{Kind: emailmodel.KindKycDecision, Data: emailmodel.KycDecisionData{
+			Approved: n.Kind == model.KindKycApproved,
+			Reason:   n.Reason,
+		}}
+	default:
+		return emailmodel.Content{Kind: emailmodel.KindNftDecision, Data: emailmodel.NftDecisionData{
+			NftId:    nftId,
+			Approved: n.Kind == model.KindNftApproved,
+			Reason:   n.Reason,
+		}}
+	}
+}
+
+// withDefaults returns a preference for every kind, taking the ones the
+// user set and emailing the rest.
+func withDefaults(set []model.Preference) []model.Preference {
+	email := make(map[model.Kind]bool, len(set))
+	for _, preference := range set {
+		email[preference.Kind] = preference.Email
+	}
+
+	preferences := make([]model.Preference, len(model.Kinds))
+	for i, kind := range model.Kinds {
+		wanted, ok := email[kind]
+		preferences[i] = model.Preference{Kind: kind, Email: wanted || !ok}
+	}
+	return preferences
+}
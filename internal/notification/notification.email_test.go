@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"github.com/google/uuid"
+	emailmodel "nft/internal/email/model"
+	"nft/internal/notification/model"
+	"testing"
+)
+
+func TestEmailContent(t *testing.T) {
+	saleId := uuid.New()
+
+	content := emailContent(model.Notification{Kind: model.KindOutbid, SaleId: &saleId, Price: 12})
+	if content.Kind != emailmodel.KindOutbid {
+		t.Errorf("emailContent(outbid).Kind = %s, want %s", content.Kind, emailmodel.KindOutbid)
+	}
+	if data, ok := content.Data.(emailmodel.OutbidData); !ok || data.SaleId != saleId || data.Price != 12 {
+		t.Errorf("emailContent(outbid).Data = %+v, want the sale and price", content.Data)
+	}
+
+	content = emailContent(model.Notification{Kind: model.KindKycRejected, Reason: "blurry photo"})
+	if data, ok := content.Data.(emailmodel.KycDecisionData); !ok || data.Approved || data.Reason != "blurry photo" {
+		t.Errorf("emailContent(kyc_rejected).Data = %+v, want a rejection with its reason", content.Data)
+	}
+
+	content = emailContent(model.Notification{Kind: model.KindNftApproved})
+	if data, ok := content.Data.(emailmodel.NftDecisionData); !ok || !data.Approved {
+		t.Errorf("emailContent(nft_approved).Data = %+v, want an approval", content.Data)
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	preferences := withDefaults([]model.Preference{{Kind: model.KindOutbid, Email: false}})
+
+	if len(preferences) != len(model.Kinds) {
+		t.Fatalf("withDefaults() returned %d preferences, want one per kind", len(preferences))
+	}
+	for _, preference := range preferences {
+		want := preference.Kind != model.KindOutbid
+		if preference.Email != want {
+			t.Errorf("withDefaults() email of %s = %v, want %v", preference.Kind, preference.Email, want)
+		}
+	}
+}
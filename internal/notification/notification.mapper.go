@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"database/sql"
+	"nft/internal/notification/dto"
+	"nft/internal/notification/entity"
+	"nft/internal/notification/model"
+)
+
+func nullString(s string) *sql.NullString {
+	if len(s) == 0 {
+		return nil
+	}
+	return &sql.NullString{String: s, Valid: true}
+}
+
+func mapNotificationModelToEntity(m model.Notification) entity.Notification {
+	return entity.Notification{
+		UserId: m.UserId,
+		Kind:   string(m.Kind),
+		SaleId: m.SaleId,
+		NftId:  m.NftId,
+		Price:  m.Price,
+		Reason: nullString(m.Reason),
+		ReadAt: m.ReadAt,
+	}
+}
+
+func mapNotificationEntityToModel(e entity.Notification) model.Notification {
+	notification := model.Notification{
+		ID:        &e.ID,
+		CreatedAt: e.CreatedAt,
+		UserId:    e.UserId,
+		Kind:      model.Kind(e.Kind),
+		SaleId:    e.SaleId,
+		NftId:     e.NftId,
+		Price:     e.Price,
+		ReadAt:    e.ReadAt,
+	}
+
+	if e.Reason != nil {
+		notification.Reason = e.Reason.String
+	}
+
+	return notification
+}
+
+func createModelNotificationListFromEntity(notifications []entity.Notification) []model.Notification {
+	notificationList := make([]model.Notification, len(notifications))
+	for i := range notifications {
+		notificationList[i] = mapNotificationEntityToModel(notifications[i])
+	}
+	return notificationList
+}
+
+func mapNotificationModelToDto(m model.Notification) dto.Notification {
+	notification := dto.Notification{
+		ID:        m.ID.String(),
+		Kind:      string(m.Kind),
+		Price:     m.Price,
+		Reason:    m.Reason,
+		Read:      m.ReadAt != nil,
+		CreatedAt: m.CreatedAt.Unix(),
+	}
+
+	if m.SaleId != nil {
+		notification.SaleId = m.SaleId.String()
+	}
+	if m.NftId != nil {
+		notification.NftId = m.NftId.String()
+	}
+
+	return notification
+}
+
+func createNotificationListDtoFromModel(notifications []model.Notification) dto.NotificationList {
+	notificationList := make([]dto.Notification, len(notifications))
+	for i := range notifications {
+		notificationList[i] = mapNotificationModelToDto(notifications[i])
+	}
+	return dto.NotificationList{Notifications: notificationList}
+}
+
+func createPreferenceListDtoFromModel(preferences []model.Preference) dto.PreferenceList {
+	preferenceList := make([]dto.Preference, len(preferences))
+	for i := range preferences {
+		preferenceList[i] = dto.Preference{Kind: string(preferences[i].Kind), Email: preferences[i].Email}
+	}
+	return dto.PreferenceList{Preferences: preferenceList}
+}
+
+func createModelPreferenceListFromDto(request dto.PreferenceList) []model.Preference {
+	preferenceList := make([]model.Preference, len(request.Preferences))
+	for i := range request.Preferences {
+		preferenceList[i] = model.Preference{Kind: model.Kind(request.Preferences[i].Kind), Email: request.Preferences[i].Email}
+	}
+	return preferenceList
+}
@@ -0,0 +1,9 @@
+package notification
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewNotificationController),
+	fx.Provide(NewNotificationService),
+	fx.Provide(NewNotificationRepository),
+)
@@ -0,0 +1,184 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/notification/entity"
+	"nft/internal/notification/model"
+	"nft/pkg/lang"
+	"time"
+)
+
+type NotificationRepository struct {
+	db contract.IPersist
+}
+
+type NotificationRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewNotificationRepository(params NotificationRepositoryParams) contract.INotificationRepository {
+	return &NotificationRepository{
+		db: params.DB,
+	}
+}
+
+func (n NotificationRepository) Add(c context.Context, m model.Notification) (model.Notification, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[Add]")
+	defer span.Finish()
+
+	notificationEntity := mapNotificationModelToEntity(m)
+	notificationEntity.ID = uuid.New()
+
+	created, err := n.db.Create(c, &notificationEntity)
+	if err != nil {
+		return model.Notification{}, err
+	}
+
+	return mapNotificationEntityToModel(*created.(*entity.Notification)), nil
+}
+
+func (n NotificationRepository) Get(c context.Context, conditions persist.D) (model.Notification, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[Get]")
+	defer span.Finish()
+
+	notification, err := n.db.Get(c, &entity.Notification{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Notification{}, apperrors.ErrNotificationNotFound
+		}
+		return model.Notification{}, err
+	}
+
+	return mapNotificationEntityToModel(*notification.(*entity.Notification)), nil
+}
+
+func (n NotificationRepository) Find(c context.Context, query persist.Query) ([]model.Notification, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[Find]")
+	defer span.Finish()
+
+	notifications, err := n.db.Find(c, &[]entity.Notification{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelNotificationListFromEntity(*notifications.(*[]entity.Notification)), nil
+}
+
+func (n NotificationRepository) Count(c context.Context, conditions persist.D) (int, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[Count]")
+	defer span.Finish()
+	return n.db.Count(c, &entity.Notification{}, conditions)
+}
+
+func (n NotificationRepository) MarkRead(c context.Context, id uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[MarkRead]")
+	defer span.Finish()
+
+	if _, err := n.db.Update(c, &entity.Notification{ID: id}, persist.D{"read_at": time.Now()}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of the user as read in a
+// single statement.
+func (n NotificationRepository) MarkAllRead(c context.Context, userId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[MarkAllRead]")
+	defer span.Finish()
+
+	if _, err := n.db.UpdateWhere(c, &entity.Notification{}, persist.D{"user_id": userId, "read_at": nil}, persist.D{"read_at": time.Now()}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (n NotificationRepository) GetPreferences(c context.Context, userId uuid.UUID) ([]model.Preference, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[GetPreferences]")
+	defer span.Finish()
+
+	preferences, err := n.db.GetAll(c, &[]entity.NotificationPreference{}, persist.D{"user_id": userId})
+	if err != nil {
+		return nil, err
+	}
+
+	preferenceEntities := *preferences.(*[]entity.NotificationPreference)
+	preferenceList := make([]model.Preference, len(preferenceEntities))
+	for i, preference := range preferenceEntities {
+		preferenceList[i] = model.Preference{Kind: model.Kind(preference.Kind), Email: preference.Email}
+	}
+	return preferenceList, nil
+}
+
+// SetPreference stores the preference of the user for its kind, replacing
+// the one set before.
+func (n NotificationRepository) SetPreference(c context.Context, userId uuid.UUID, preference model.Preference) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[SetPreference]")
+	defer span.Finish()
+
+	existing, err := n.db.Get(c, &entity.NotificationPreference{}, persist.D{"user_id": userId, "kind": preference.Kind})
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrRecordNotFound) {
+			return err
+		}
+
+		_, err := n.db.Create(c, &entity.NotificationPreference{
+			ID:     uuid.New(),
+			UserId: userId,
+			Kind:   string(preference.Kind),
+			Email:  preference.Email,
+		})
+		return err
+	}
+
+	_, err = n.db.Update(c, &entity.NotificationPreference{ID: existing.(*entity.NotificationPreference).ID}, persist.D{"email": preference.Email})
+	return err
+}
+
+// GetLanguage returns the language emails to the user are written in, FA
+// unless the user chose another.
+func (n NotificationRepository) GetLanguage(c context.Context, userId uuid.UUID) (lang.Language, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[GetLanguage]")
+	defer span.Finish()
+
+	language, err := n.db.Get(c, &entity.NotificationLanguage{}, persist.D{"user_id": userId})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return lang.FA, nil
+		}
+		return "", err
+	}
+
+	return lang.Language(language.(*entity.NotificationLanguage).Language), nil
+}
+
+// SetLanguage stores the language emails to the user are written in,
+// replacing the one set before.
+func (n NotificationRepository) SetLanguage(c context.Context, userId uuid.UUID, language lang.Language) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationRepository[SetLanguage]")
+	defer span.Finish()
+
+	existing, err := n.db.Get(c, &entity.NotificationLanguage{}, persist.D{"user_id": userId})
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrRecordNotFound) {
+			return err
+		}
+
+		_, err := n.db.Create(c, &entity.NotificationLanguage{
+			ID:       uuid.New(),
+			UserId:   userId,
+			Language: string(language),
+		})
+		return err
+	}
+
+	_, err = n.db.Update(c, &entity.NotificationLanguage{ID: existing.(*entity.NotificationLanguage).ID}, persist.D{"language": string(language)})
+	return err
+}
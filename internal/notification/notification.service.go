@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/notification/model"
+	"nft/pkg/lang"
+)
+
+// pageSize bounds how many notifications are listed at once, newest first.
+const pageSize = 100
+
+type NotificationService struct {
+	db                     contract.IPersist
+	notificationRepository contract.INotificationRepository
+	emailService           contract.IEmailService
+}
+
+type NotificationServiceParams struct {
+	fx.In
+	DB                     contract.IPersist
+	NotificationRepository contract.INotificationRepository
+	EmailService           contract.IEmailService
+}
+
+func NewNotificationService(params NotificationServiceParams) contract.INotificationService {
+	return &NotificationService{
+		db:                     params.DB,
+		notificationRepository: params.NotificationRepository,
+		emailService:           params.EmailService,
+	}
+}
+
+// Notify stores the notification and, unless the user turned emails of its
+// kind off, queues the email telling the same. Called in the transaction of
+// the change it tells about, both are only kept if the change is.
+func (n NotificationService) Notify(c context.Context, m model.Notification) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[Notify]")
+	defer span.Finish()
+
+	if _, err := n.notificationRepository.Add(c, m); err != nil {
+		return err
+	}
+
+	preferences, err := n.GetPreferences(c, m.UserId)
+	if err != nil {
+		return err
+	}
+
+	for _, preference := range preferences {
+		if preference.Kind == m.Kind && !preference.Email {
+			return nil
+		}
+	}
+
+	return n.emailService.SendToUser(c, m.UserId, emailContent(m))
+}
+
+func (n NotificationService) GetNotifications(c context.Context, userId uuid.UUID, unreadOnly bool) ([]model.Notification, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[GetNotifications]")
+	defer span.Finish()
+
+	conditions := persist.D{"user_id": userId}
+	if unreadOnly {
+		conditions["read_at"] = nil
+	}
+
+	return n.notificationRepository.Find(c, persist.Query{Conditions: conditions, Order: "created_at desc", Limit: pageSize})
+}
+
+func (n NotificationService) GetUnreadCount(c context.Context, userId uuid.UUID) (int, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[GetUnreadCount]")
+	defer span.Finish()
+	return n.notificationRepository.Count(c, persist.D{"user_id": userId, "read_at": nil})
+}
+
+func (n NotificationService) MarkRead(c context.Context, id uuid.UUID, userId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[MarkRead]")
+	defer span.Finish()
+
+	notification, err := n.notificationRepository.Get(c, persist.D{"id": id, "user_id": userId})
+	if err != nil {
+		return err
+	}
+
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return n.notificationRepository.MarkRead(c, id)
+}
+
+func (n NotificationService) MarkAllRead(c context.Context, userId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[MarkAllRead]")
+	defer span.Finish()
+
+	return n.notificationRepository.MarkAllRead(c, userId)
+}
+
+// GetPreferences returns whether each kind is emailed to the user.
+func (n NotificationService) GetPreferences(c context.Context, userId uuid.UUID) ([]model.Preference, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[GetPreferences]")
+	defer span.Finish()
+
+	preferences, err := n.notificationRepository.GetPreferences(c, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return withDefaults(preferences), nil
+}
+
+// SetPreferences changes the given kinds and leaves the others as they
+// were.
+func (n NotificationService) SetPreferences(c context.Context, userId uuid.UUID, preferences []model.Preference) ([]model.Preference, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[SetPreferences]")
+	defer span.Finish()
+
+	err := n.db.Transaction(c, func(c context.Context) error {
+		for _, preference := range preferences {
+			if err := n.notificationRepository.SetPreference(c, userId, preference); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return n.GetPreferences(c, userId)
+}
+
+// GetLanguage returns the language emails to the user are written in.
+func (n NotificationService) GetLanguage(c context.Context, userId uuid.UUID) (lang.Language, error) {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[GetLanguage]")
+	defer span.Finish()
+	return n.notificationRepository.GetLanguage(c, userId)
+}
+
+// SetLanguage changes the language emails to the user are written in.
+func (n NotificationService) SetLanguage(c context.Context, userId uuid.UUID, language lang.Language) error {
+	span, c := jtrace.T().SpanFromContext(c, "NotificationService[SetLanguage]")
+	defer span.Finish()
+	return n.notificationRepository.SetLanguage(c, userId, language)
+}
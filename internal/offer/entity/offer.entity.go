@@ -10,8 +10,9 @@ type Offer struct {
 	CreatedAt time.Time
 	DeletedAt *time.Time
 
-	UserId   uuid.UUID `gorm:"type:uuid;"`
-	SaleId   uuid.UUID `gorm:"type:uuid;"`
-	Price    float64
-	Accepted bool
+	UserId     uuid.UUID `gorm:"type:uuid;"`
+	SaleId     uuid.UUID `gorm:"type:uuid;"`
+	Price      float64
+	Accepted   bool
+	RejectedAt *time.Time
 }
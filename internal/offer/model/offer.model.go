@@ -3,12 +3,15 @@ package model
 import (
 	"github.com/google/uuid"
 	user "nft/internal/user/model"
+	"time"
 )
 
 type Offer struct {
-	ID       *uuid.UUID
-	User     user.User
-	SaleId   uuid.UUID
-	Price    float64
-	Accepted bool
+	ID         *uuid.UUID
+	CreatedAt  time.Time
+	User       user.User
+	SaleId     uuid.UUID
+	Price      float64
+	Accepted   bool
+	RejectedAt *time.Time
 }
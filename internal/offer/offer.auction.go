@@ -0,0 +1,45 @@
+package offer
+
+import (
+	"nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	"time"
+)
+
+// leadingBid returns the highest active bid of an auction, or nil when
+// nobody has bid yet.
+func leadingBid(bids []model.Offer) *model.Offer {
+	var leading *model.Offer
+	for i := range bids {
+		if bids[i].RejectedAt != nil {
+			continue
+		}
+		if leading == nil || bids[i].Price > leading.Price {
+			leading = &bids[i]
+		}
+	}
+	return leading
+}
+
+// minimumBid is the lowest price a new bid has to reach. The opening bid
+// only needs the sale min price, later ones must beat the leading bid by
+// the configured increment.
+func minimumBid(sale salemodel.Sale, leading *model.Offer, increment float64) float64 {
+	if leading == nil {
+		return sale.MinPrice
+	}
+	return leading.Price + increment
+}
+
+// extendedExpiration pushes the end of an auction back when a bid lands in
+// its closing window, so a last-second bid can still be answered.
+func extendedExpiration(expiration time.Time, now time.Time, window time.Duration, extension time.Duration) time.Time {
+	if window <= 0 || expiration.Sub(now) > window {
+		return expiration
+	}
+
+	if extended := now.Add(extension); extended.After(expiration) {
+		return extended
+	}
+	return expiration
+}
@@ -0,0 +1,62 @@
+package offer
+
+import (
+	"nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	"testing"
+	"time"
+)
+
+func TestLeadingBid(t *testing.T) {
+	now := time.Now()
+	bids := []model.Offer{
+		{Price: 10},
+		{Price: 30, RejectedAt: &now},
+		{Price: 20},
+	}
+
+	if got := leadingBid(bids); got == nil || got.Price != 20 {
+		t.Errorf("leadingBid() = %v, want price 20", got)
+	}
+
+	if got := leadingBid(nil); got != nil {
+		t.Errorf("leadingBid() = %v, want nil", got)
+	}
+}
+
+func TestMinimumBid(t *testing.T) {
+	sale := salemodel.Sale{MinPrice: 100}
+
+	if got := minimumBid(sale, nil, 5); got != 100 {
+		t.Errorf("minimumBid() = %v, want 100", got)
+	}
+
+	if got := minimumBid(sale, &model.Offer{Price: 120}, 5); got != 125 {
+		t.Errorf("minimumBid() = %v, want 125", got)
+	}
+}
+
+func TestExtendedExpiration(t *testing.T) {
+	now := time.Now()
+	window, extension := 5*time.Minute, 10*time.Minute
+
+	{
+		expiration := now.Add(time.Hour)
+		if got := extendedExpiration(expiration, now, window, extension); !got.Equal(expiration) {
+			t.Errorf("extendedExpiration() = %v, want %v", got, expiration)
+		}
+	}
+	{
+		expiration := now.Add(2 * time.Minute)
+		want := now.Add(extension)
+		if got := extendedExpiration(expiration, now, window, extension); !got.Equal(want) {
+			t.Errorf("extendedExpiration() = %v, want %v", got, want)
+		}
+	}
+	{
+		expiration := now.Add(2 * time.Minute)
+		if got := extendedExpiration(expiration, now, 0, extension); !got.Equal(expiration) {
+			t.Errorf("extendedExpiration() = %v, want %v", got, expiration)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package offer
+
+import (
+	"nft/config"
+	"nft/contract"
+	"nft/pkg/schedule"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// StartAuctionCloser periodically settles auctions that reached their end
+// time for the lifetime of the application.
+func StartAuctionCloser(lc fx.Lifecycle, offerService contract.IOfferService) {
+	schedule.Every(lc, "auction closer", func() time.Duration {
+		return time.Duration(config.C().Auction.CloserIntervalInSec) * time.Second
+	}, offerService.CloseEndedAuctions)
+}
@@ -38,7 +38,7 @@ func NewOfferController(params OfferControllerParams) contract.IOfferController
 // @Accept   json
 // @Produce  json
 // @Router   /v1/offer/ [post]
-// @Param    message  body      dto.MakeOfferRequest  true  "price should be higher than sale min price"
+// @Param    message  body      dto.MakeOfferRequest  true  "price should be higher than sale min price, auction bids should also beat the highest bid by the minimum increment"
 // @Success  200      {string}  string                "offer made successfully"
 func (o OfferController) MakeOffer(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "OfferController[MakeOffer]")
@@ -76,6 +76,22 @@ func (o OfferController) MakeOffer(c *fiber.Ctx) error {
 			return filper.GetNotFoundError(c, "you can't make offer on your own sale")
 		} else if errors.Is(err, apperrors.ErrOfferLowerMinPrice) {
 			return filper.GetNotFoundError(c, "your offer should be higher than sale min price")
+		} else if errors.Is(err, apperrors.ErrSaleClosed) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleClosed.Error())
+		} else if errors.Is(err, apperrors.ErrSaleExpired) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleExpired.Error())
+		} else if errors.Is(err, apperrors.ErrAuctionEnded) {
+			return filper.GetBadRequestError(c, apperrors.ErrAuctionEnded.Error())
+		} else if errors.Is(err, apperrors.ErrBidBelowIncrement) {
+			return filper.GetBadRequestError(c, apperrors.ErrBidBelowIncrement.Error())
+		} else if errors.Is(err, apperrors.ErrInsufficientFunds) {
+			return filper.GetBadRequestError(c, apperrors.ErrInsufficientFunds.Error())
+		} else if errors.Is(err, apperrors.ErrFixedPriceOffer) {
+			return filper.GetBadRequestError(c, apperrors.ErrFixedPriceOffer.Error())
+		} else if errors.Is(err, apperrors.ErrKycRequired) {
+			return filper.GetForbiddenError(c, apperrors.ErrKycRequired.Error())
+		} else if errors.Is(err, apperrors.ErrTradingLimitExceeded) {
+			return filper.GetBadRequestError(c, apperrors.ErrTradingLimitExceeded.Error())
 		}
 		return filper.GetInternalError(c, "")
 	}
@@ -106,7 +122,14 @@ func (o OfferController) CancelOffer(c *fiber.Ctx) error {
 	}
 
 	if err := o.offerService.CancelOffer(ctx, model.Offer{ID: &offerId, User: usermodel.User{ID: userId}}); err != nil {
-		return err
+		if errors.Is(err, apperrors.ErrOfferNotFound) {
+			return filper.GetNotFoundError(c, "offer not found")
+		} else if errors.Is(err, apperrors.ErrBidWithdrawal) {
+			return filper.GetBadRequestError(c, apperrors.ErrBidWithdrawal.Error())
+		} else if errors.Is(err, apperrors.ErrOfferNotActive) {
+			return filper.GetBadRequestError(c, apperrors.ErrOfferNotActive.Error())
+		}
+		return filper.GetInternalError(c, "")
 	}
 
 	return filper.GetSuccessResponse(c, "offer canceled successfully")
@@ -139,6 +162,16 @@ func (o OfferController) AcceptOffer(c *fiber.Ctx) error {
 			return filper.GetNotFoundError(c, "offer not found")
 		} else if errors.Is(err, apperrors.ErrSaleNotFound) {
 			return filper.GetNotFoundError(c, "sale not found")
+		} else if errors.Is(err, apperrors.ErrSaleClosed) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleClosed.Error())
+		} else if errors.Is(err, apperrors.ErrSaleExpired) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleExpired.Error())
+		} else if errors.Is(err, apperrors.ErrOfferNotActive) {
+			return filper.GetBadRequestError(c, apperrors.ErrOfferNotActive.Error())
+		} else if errors.Is(err, apperrors.ErrAuctionManualAccept) {
+			return filper.GetBadRequestError(c, apperrors.ErrAuctionManualAccept.Error())
+		} else if errors.Is(err, apperrors.ErrHoldNotFound) {
+			return filper.GetBadRequestError(c, apperrors.ErrHoldNotFound.Error())
 		}
 		return filper.GetInternalError(c, "")
 	}
@@ -19,11 +19,13 @@ func mapOfferModelToEntity(m model.Offer) entity.Offer {
 
 func mapOfferEntityToModel(offer entity.Offer) model.Offer {
 	return model.Offer{
-		ID:       &offer.ID,
-		User:     usermodel.User{ID: offer.UserId},
-		SaleId:   offer.SaleId,
-		Price:    offer.Price,
-		Accepted: offer.Accepted,
+		ID:         &offer.ID,
+		CreatedAt:  offer.CreatedAt,
+		User:       usermodel.User{ID: offer.UserId},
+		SaleId:     offer.SaleId,
+		Price:      offer.Price,
+		Accepted:   offer.Accepted,
+		RejectedAt: offer.RejectedAt,
 	}
 }
 
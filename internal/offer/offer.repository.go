@@ -104,3 +104,14 @@ func (o OfferRepository) Update(c context.Context, m model.Offer) (model.Offer,
 
 	return mapOfferEntityToModel(*offer.(*entity.Offer)), nil
 }
+
+func (o OfferRepository) Reject(c context.Context, m model.Offer) error {
+	span, c := jtrace.T().SpanFromContext(c, "OfferRepository[Reject]")
+	defer span.Finish()
+
+	if _, err := o.db.Update(c, &entity.Offer{ID: *m.ID}, persist.D{"rejected_at": time.Now()}); err != nil {
+		return err
+	}
+
+	return nil
+}
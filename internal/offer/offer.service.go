@@ -3,29 +3,72 @@ package offer
 import (
 	"context"
 	"errors"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
+	"log"
+	"nft/config"
 	"nft/contract"
 	apperrors "nft/error"
+	"nft/infra/eventbus"
+	eventmodel "nft/infra/eventbus/model"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
+	feemodel "nft/internal/fee/model"
+	ledgermodel "nft/internal/ledger/model"
+	nftmodel "nft/internal/nft/model"
+	notificationmodel "nft/internal/notification/model"
 	"nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	txmodel "nft/internal/transaction/model"
+	"nft/pkg/money"
+	"time"
 )
 
 type OfferService struct {
-	offerRepository contract.IOfferRepository
-	saleRepository  contract.ISaleRepository
+	db                    contract.IPersist
+	offerRepository       contract.IOfferRepository
+	saleRepository        contract.ISaleRepository
+	transactionRepository contract.ITransactionRepository
+	ledgerService         contract.ILedgerService
+	nftRepository         contract.INftRepository
+	collectionRepository  contract.ICollectionRepository
+	feeService            contract.IFeeService
+	limitService          contract.ILimitService
+	chainService          contract.IChainService
+	notificationService   contract.INotificationService
+	outboxService         contract.IOutboxService
 }
 
 type OfferServiceParams struct {
 	fx.In
-	OfferRepository contract.IOfferRepository
-	SaleRepository  contract.ISaleRepository
+	DB                    contract.IPersist
+	OfferRepository       contract.IOfferRepository
+	SaleRepository        contract.ISaleRepository
+	TransactionRepository contract.ITransactionRepository
+	LedgerService         contract.ILedgerService
+	NftRepository         contract.INftRepository
+	CollectionRepository  contract.ICollectionRepository
+	FeeService            contract.IFeeService
+	LimitService          contract.ILimitService
+	ChainService          contract.IChainService
+	NotificationService   contract.INotificationService
+	OutboxService         contract.IOutboxService
 }
 
 func NewOfferService(params OfferServiceParams) contract.IOfferService {
 	return &OfferService{
-		offerRepository: params.OfferRepository,
-		saleRepository:  params.SaleRepository,
+		db:                    params.DB,
+		offerRepository:       params.OfferRepository,
+		saleRepository:        params.SaleRepository,
+		transactionRepository: params.TransactionRepository,
+		ledgerService:         params.LedgerService,
+		nftRepository:         params.NftRepository,
+		collectionRepository:  params.CollectionRepository,
+		feeService:            params.FeeService,
+		limitService:          params.LimitService,
+		chainService:          params.ChainService,
+		notificationService:   params.NotificationService,
+		outboxService:         params.OutboxService,
 	}
 }
 
@@ -33,25 +76,156 @@ func (o OfferService) MakeOfferToSale(c context.Context, m model.Offer) error {
 	span, c := jtrace.T().SpanFromContext(c, "OfferService[MakeOfferToSale]")
 	defer span.Finish()
 
-	sale, err := o.saleRepository.Get(c, persist.D{"id": m.SaleId})
+	return o.db.Transaction(c, func(c context.Context) error {
+		sale, err := o.saleRepository.Lock(c, persist.D{"id": m.SaleId})
+		if err != nil {
+			return err
+		}
+
+		if sale.Status != salemodel.SaleStatusInProgress || sale.CanceledAt != nil {
+			return apperrors.ErrSaleClosed
+		}
+
+		if sale.User.ID == m.User.ID {
+			return apperrors.ErrOfferYourSale
+		}
+
+		if sale.SaleType == salemodel.SaleTypeAuction {
+			bid, err := o.placeBid(c, sale, m)
+			if err != nil {
+				return err
+			}
+			return o.announceOffer(c, sale, bid)
+		}
+
+		if sale.SaleType == salemodel.SaleTypeFixedPrice {
+			return apperrors.ErrFixedPriceOffer
+		}
+
+		// the expirer may not have swept the sale yet
+		if !time.Now().Before(sale.Expiration) {
+			return apperrors.ErrSaleExpired
+		}
+
+		if sale.MinPrice > m.Price {
+			return apperrors.ErrOfferLowerMinPrice
+		}
+
+		offer, err := o.addOffer(c, m)
+		if err != nil {
+			return err
+		}
+		return o.announceOffer(c, sale, offer)
+	})
+}
+
+// addOffer stores the offer and escrows its price from the buyer, so an
+// offer only exists while the buyer can pay for it and its kyc tier allows it.
+func (o OfferService) addOffer(c context.Context, m model.Offer) (model.Offer, error) {
+	if err := o.limitService.Check(c, m.User.ID, m.Price); err != nil {
+		return model.Offer{}, err
+	}
+
+	offer, err := o.offerRepository.Add(c, m)
 	if err != nil {
+		return model.Offer{}, err
+	}
+
+	if err := o.ledgerService.PlaceHold(c, ledgermodel.Hold{UserId: m.User.ID, OfferId: *offer.ID, Amount: money.FromFloat(m.Price)}); err != nil {
+		return model.Offer{}, err
+	}
+
+	return offer, nil
+}
+
+// reject takes the offer out of the competition, releases its hold and
+// publishes why.
+func (o OfferService) reject(c context.Context, sale salemodel.Sale, offer model.Offer, reason eventmodel.RejectReason) error {
+	if err := o.offerRepository.Reject(c, offer); err != nil {
 		return err
 	}
 
-	if sale.MinPrice > m.Price {
-		return apperrors.ErrOfferLowerMinPrice
+	if err := o.ledgerService.ReleaseHold(c, *offer.ID); err != nil {
+		return err
 	}
 
-	if sale.User.ID == m.User.ID {
-		return apperrors.ErrOfferYourSale
+	return eventbus.Enqueue(c, o.outboxService, eventmodel.OfferRejected{
+		OfferId:    *offer.ID,
+		SaleId:     *sale.ID,
+		SellerId:   sale.User.ID,
+		BuyerId:    offer.User.ID,
+		Price:      offer.Price,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+}
+
+// placeBid applies the english auction rules to a new bid. It must run
+// while the sale row is locked so bids on the same auction are serialized.
+func (o OfferService) placeBid(c context.Context, sale salemodel.Sale, m model.Offer) (model.Offer, error) {
+	now := time.Now()
+	if !now.Before(sale.Expiration) {
+		return model.Offer{}, apperrors.ErrAuctionEnded
 	}
 
-	_, err = o.offerRepository.Add(c, m)
+	bids, err := o.offerRepository.GetAll(c, persist.D{"sale_id": *sale.ID, "rejected_at": nil})
 	if err != nil {
-		return err
+		return model.Offer{}, err
 	}
 
-	return nil
+	leading := leadingBid(bids)
+	if m.Price < minimumBid(sale, leading, config.C().Auction.MinIncrement) {
+		if leading == nil {
+			return model.Offer{}, apperrors.ErrOfferLowerMinPrice
+		}
+		return model.Offer{}, apperrors.ErrBidBelowIncrement
+	}
+
+	placed, err := o.addOffer(c, m)
+	if err != nil {
+		return model.Offer{}, err
+	}
+
+	// every earlier bid is outbid now
+	for _, bid := range bids {
+		if err := o.reject(c, sale, bid, eventmodel.RejectReasonOutbid); err != nil {
+			return model.Offer{}, err
+		}
+		if bid.User.ID == m.User.ID {
+			continue
+		}
+		if err := o.notificationService.Notify(c, notificationmodel.Notification{
+			UserId: bid.User.ID,
+			Kind:   notificationmodel.KindOutbid,
+			SaleId: sale.ID,
+			Price:  m.Price,
+		}); err != nil {
+			return model.Offer{}, err
+		}
+	}
+
+	expiration := extendedExpiration(
+		sale.Expiration,
+		now,
+		time.Duration(config.C().Auction.ExtensionWindowInMin)*time.Minute,
+		time.Duration(config.C().Auction.ExtensionInMin)*time.Minute,
+	)
+	if expiration.After(sale.Expiration) {
+		if err := o.saleRepository.Extend(c, salemodel.Sale{ID: sale.ID, Expiration: expiration}); err != nil {
+			return model.Offer{}, err
+		}
+
+		if err := eventbus.Enqueue(c, o.outboxService, eventmodel.SaleExtended{
+			SaleId:     *sale.ID,
+			SellerId:   sale.User.ID,
+			Expiration: expiration,
+			OccurredAt: now,
+		}); err != nil {
+			return model.Offer{}, err
+		}
+	}
+
+	return placed, nil
 }
 
 func (o OfferService) CancelOffer(c context.Context, m model.Offer) error {
@@ -60,9 +234,6 @@ func (o OfferService) CancelOffer(c context.Context, m model.Offer) error {
 
 	offerModel, err := o.offerRepository.Get(c, persist.D{"id": *m.ID})
 	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrOfferNotFound
-		}
 		return err
 	}
 
@@ -70,39 +241,336 @@ func (o OfferService) CancelOffer(c context.Context, m model.Offer) error {
 		return apperrors.ErrOfferNotFound
 	}
 
-	return o.offerRepository.Delete(c, model.Offer{ID: m.ID})
+	// the sale is locked like on accept, so a cancel and an accept of the
+	// same offer are serialized
+	return o.db.Transaction(c, func(c context.Context) error {
+		sale, err := o.saleRepository.Lock(c, persist.D{"id": offerModel.SaleId})
+		if err != nil {
+			return err
+		}
+
+		// read the offer again under the sale lock to observe an accept or
+		// cancel that committed while we were waiting for it
+		offerModel, err := o.offerRepository.Get(c, persist.D{"id": *m.ID})
+		if err != nil {
+			return err
+		}
+
+		if offerModel.Accepted {
+			return apperrors.ErrOfferNotActive
+		}
+
+		if sale.SaleType == salemodel.SaleTypeAuction && offerModel.RejectedAt == nil {
+			return apperrors.ErrBidWithdrawal
+		}
+
+		if err := o.offerRepository.Delete(c, model.Offer{ID: m.ID}); err != nil {
+			return err
+		}
+
+		if err := o.ledgerService.ReleaseHold(c, *m.ID); err != nil {
+			return err
+		}
+
+		return eventbus.Enqueue(c, o.outboxService, eventmodel.OfferCanceled{
+			OfferId:    *offerModel.ID,
+			SaleId:     *sale.ID,
+			SellerId:   sale.User.ID,
+			BuyerId:    offerModel.User.ID,
+			Price:      offerModel.Price,
+			OccurredAt: time.Now(),
+		})
+	})
 }
 
+// AcceptOffer settles a sale in a single database transaction. The sale row
+// is locked first so concurrent accepts on the same sale are serialized and
+// only the first one finds it still in progress.
 func (o OfferService) AcceptOffer(c context.Context, m model.Offer) error {
 	span, c := jtrace.T().SpanFromContext(c, "OfferService[AcceptOffer]")
 	defer span.Finish()
 
-	offerModel, err := o.offerRepository.Get(c, persist.D{"id": *m.ID})
-	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
+	return o.db.Transaction(c, func(c context.Context) error {
+		offerModel, err := o.offerRepository.Get(c, persist.D{"id": *m.ID})
+		if err != nil {
+			return err
+		}
+
+		sale, err := o.saleRepository.Lock(c, persist.D{"id": offerModel.SaleId})
+		if err != nil {
+			return err
+		}
+
+		if m.User.ID != sale.User.ID {
 			return apperrors.ErrOfferNotFound
 		}
+
+		if sale.SaleType == salemodel.SaleTypeAuction {
+			return apperrors.ErrAuctionManualAccept
+		}
+
+		if sale.Status != salemodel.SaleStatusInProgress || sale.CanceledAt != nil {
+			return apperrors.ErrSaleClosed
+		}
+
+		if !time.Now().Before(sale.Expiration) {
+			return apperrors.ErrSaleExpired
+		}
+
+		// read the offer again under the sale lock to observe a cancel or
+		// rejection that committed while we were waiting for it
+		offerModel, err = o.offerRepository.Get(c, persist.D{"id": *m.ID, "rejected_at": nil})
+		if err != nil {
+			if errors.Is(err, apperrors.ErrOfferNotFound) {
+				return apperrors.ErrOfferNotActive
+			}
+			return err
+		}
+
+		return o.settle(c, sale, offerModel)
+	})
+}
+
+// Buy purchases a fixed price sale at its list price in one step. The buyer
+// places an offer for the list price that is accepted right away, so the
+// purchase goes through the same escrow and settlement as any other offer.
+// The sale row is locked first, so of several concurrent buyers only the
+// first finds the sale still in progress and the others get ErrSaleClosed.
+func (o OfferService) Buy(c context.Context, m model.Offer) error {
+	span, c := jtrace.T().SpanFromContext(c, "OfferService[Buy]")
+	defer span.Finish()
+
+	return o.db.Transaction(c, func(c context.Context) error {
+		sale, err := o.saleRepository.Lock(c, persist.D{"id": m.SaleId})
+		if err != nil {
+			return err
+		}
+
+		if sale.SaleType != salemodel.SaleTypeFixedPrice {
+			return apperrors.ErrSaleNotFixedPrice
+		}
+
+		if sale.Status != salemodel.SaleStatusInProgress || sale.CanceledAt != nil {
+			return apperrors.ErrSaleClosed
+		}
+
+		if !time.Now().Before(sale.Expiration) {
+			return apperrors.ErrSaleExpired
+		}
+
+		if sale.User.ID == m.User.ID {
+			return apperrors.ErrBuyYourSale
+		}
+
+		m.Price = sale.MinPrice
+		offer, err := o.addOffer(c, m)
+		if err != nil {
+			return err
+		}
+
+		return o.settle(c, sale, offer)
+	})
+}
+
+// CloseEndedAuctions settles every auction whose end time has passed with
+// its highest bid. Auctions that ended without any bid are marked expired.
+func (o OfferService) CloseEndedAuctions(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "OfferService[CloseEndedAuctions]")
+	defer span.Finish()
+
+	sales, err := o.saleRepository.GetAll(c, persist.D{
+		"sale_type":    salemodel.SaleTypeAuction,
+		"status":       salemodel.SaleStatusInProgress,
+		"canceled_at":  nil,
+		"expiration <": time.Now(),
+	})
+	if err != nil {
 		return err
 	}
 
-	sale, err := o.saleRepository.Get(c, persist.D{"id": offerModel.SaleId})
+	for _, sale := range sales {
+		if err := o.closeAuction(c, *sale.ID); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+func (o OfferService) closeAuction(c context.Context, saleId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "OfferService[closeAuction]")
+	defer span.Finish()
+
+	return o.db.Transaction(c, func(c context.Context) error {
+		sale, err := o.saleRepository.Lock(c, persist.D{"id": saleId})
+		if err != nil {
+			return err
+		}
+
+		// a bid may have extended the auction since it was listed
+		if sale.Status != salemodel.SaleStatusInProgress || sale.CanceledAt != nil || time.Now().Before(sale.Expiration) {
+			return nil
+		}
+
+		bids, err := o.offerRepository.GetAll(c, persist.D{"sale_id": saleId, "rejected_at": nil})
+		if err != nil {
+			return err
+		}
+
+		leading := leadingBid(bids)
+		if leading == nil {
+			if err := o.saleRepository.UpdateStatus(c, salemodel.Sale{ID: sale.ID, Status: salemodel.SaleStatusExpired}); err != nil {
+				return err
+			}
+			if err := o.notificationService.Notify(c, notificationmodel.Notification{
+				UserId: sale.User.ID,
+				Kind:   notificationmodel.KindSaleExpired,
+				SaleId: sale.ID,
+			}); err != nil {
+				return err
+			}
+			return eventbus.Enqueue(c, o.outboxService, eventmodel.SaleExpired{
+				SaleId:     *sale.ID,
+				SellerId:   sale.User.ID,
+				OccurredAt: time.Now(),
+			})
+		}
+
+		return o.settle(c, sale, *leading)
+	})
+}
+
+// settle marks the offer accepted, rejects the competing ones, records the
+// ownership transfer, pays the escrowed price out to the seller, the creator
+// and the platform, closes the sale, notifies the seller and the buyer and
+// publishes the acceptance. The caller must hold the sale lock inside a
+// database transaction.
+func (o OfferService) settle(c context.Context, sale salemodel.Sale, offer model.Offer) error {
+	if _, err := o.offerRepository.Update(c, model.Offer{ID: offer.ID, Accepted: true}); err != nil {
+		return err
+	}
+
+	competingOffers, err := o.offerRepository.GetAll(c, persist.D{"sale_id": *sale.ID, "rejected_at": nil})
 	if err != nil {
-		if errors.Is(err, apperrors.ErrRecordNotFound) {
-			return apperrors.ErrSaleNotFound
+		return err
+	}
+
+	for _, competingOffer := range competingOffers {
+		if *competingOffer.ID == *offer.ID {
+			continue
+		}
+		if err := o.reject(c, sale, competingOffer, eventmodel.RejectReasonSold); err != nil {
+			return err
 		}
+	}
+
+	settled := txmodel.Transaction{
+		AssetId:      saleAssetId(sale),
+		SaleId:       *sale.ID,
+		BuyerId:      offer.User.ID,
+		SellerId:     sale.User.ID,
+		OfferId:      *offer.ID,
+		SettlementId: uuid.New(),
+		Kind:         txmodel.KindSale,
+		Price:        offer.Price,
+	}
+
+	tx, err := o.transactionRepository.Add(c, settled)
+	if err != nil {
 		return err
 	}
 
-	if m.User.ID != sale.User.ID {
-		return apperrors.ErrOfferNotFound
+	// the royalty is looked up before the nft leaves its collection
+	creatorId, royaltyPercent, err := o.royalty(c, sale)
+	if err != nil {
+		return err
 	}
 
-	_, err = o.offerRepository.Update(c, model.Offer{ID: m.ID, Accepted: true})
+	if err := o.transferAsset(c, sale, tx); err != nil {
+		return err
+	}
+
+	fee, err := o.feeService.Quote(c, feemodel.Quote{
+		SaleType:  string(sale.SaleType),
+		AssetType: string(sale.AssetType),
+		Price:     offer.Price,
+		SellerId:  sale.User.ID,
+	})
 	if err != nil {
 		return err
 	}
 
-	return nil
+	split := splitProceeds(
+		money.FromFloat(offer.Price),
+		royaltyPercent,
+		money.FromFloat(fee),
+		royaltyDue(creatorId, sale.User.ID, offer.User.ID),
+	)
+
+	if err := o.ledgerService.CaptureHold(c, ledgermodel.Capture{
+		OfferId:       *offer.ID,
+		TransactionId: *tx.ID,
+		Payouts: []ledgermodel.Payout{
+			{UserId: sale.User.ID, Kind: ledgermodel.EntryKindPayout, Amount: split.payout},
+			{UserId: creatorId, Kind: ledgermodel.EntryKindRoyalty, Amount: split.royalty},
+			{UserId: uuid.Nil, Kind: ledgermodel.EntryKindFee, Amount: split.fee},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if err := o.saleRepository.UpdateStatus(c, salemodel.Sale{ID: sale.ID, Status: salemodel.SaleStatusSold}); err != nil {
+		return err
+	}
+
+	if err := o.notificationService.Notify(c, notificationmodel.Notification{
+		UserId: sale.User.ID,
+		Kind:   notificationmodel.KindSaleSold,
+		SaleId: sale.ID,
+		Price:  offer.Price,
+	}); err != nil {
+		return err
+	}
+
+	if err := o.notificationService.Notify(c, notificationmodel.Notification{
+		UserId: offer.User.ID,
+		Kind:   notificationmodel.KindOfferAccepted,
+		SaleId: sale.ID,
+		Price:  offer.Price,
+	}); err != nil {
+		return err
+	}
+
+	return eventbus.Enqueue(c, o.outboxService, eventmodel.OfferAccepted{
+		OfferId:    *offer.ID,
+		SaleId:     *sale.ID,
+		SellerId:   sale.User.ID,
+		BuyerId:    offer.User.ID,
+		Price:      offer.Price,
+		OccurredAt: time.Now(),
+	})
+}
+
+// announceOffer tells the seller about the new offer or bid and publishes
+// it once it is committed.
+func (o OfferService) announceOffer(c context.Context, sale salemodel.Sale, offer model.Offer) error {
+	if err := o.notificationService.Notify(c, notificationmodel.Notification{
+		UserId: sale.User.ID,
+		Kind:   notificationmodel.KindOfferReceived,
+		SaleId: sale.ID,
+		Price:  offer.Price,
+	}); err != nil {
+		return err
+	}
+
+	return eventbus.Enqueue(c, o.outboxService, eventmodel.OfferMade{
+		OfferId:    *offer.ID,
+		SaleId:     *sale.ID,
+		SellerId:   sale.User.ID,
+		BuyerId:    offer.User.ID,
+		Price:      offer.Price,
+		OccurredAt: time.Now(),
+	})
 }
 
 func (o OfferService) GetAllOffers(c context.Context, m model.Offer) ([]model.Offer, error) {
@@ -110,3 +578,68 @@ func (o OfferService) GetAllOffers(c context.Context, m model.Offer) ([]model.Of
 	defer span.Finish()
 	return o.offerRepository.GetAll(c, persist.D{"sale_id": m.SaleId})
 }
+
+// transferAsset moves what the sale held along with the asset. The member
+// nfts of a collection go to the buyer, each with a transaction of the same
+// settlement. A single nft leaves the seller's collection. Every nft that
+// changed hands has the move of its token queued for the chain.
+func (o OfferService) transferAsset(c context.Context, sale salemodel.Sale, settled txmodel.Transaction) error {
+	if sale.AssetType != salemodel.AssetTypeCollection {
+		if err := o.nftRepository.UpdateCollection(c, nftmodel.Nft{ID: sale.Nft.ID}); err != nil {
+			return err
+		}
+		return o.chainService.QueueTransfer(c, settled)
+	}
+
+	members, err := o.nftRepository.GetAll(c, persist.D{"collection_id": *sale.Collection.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		settled.AssetId = *member.ID
+		memberTx, err := o.transactionRepository.Add(c, settled)
+		if err != nil {
+			return err
+		}
+		if err := o.chainService.QueueTransfer(c, memberTx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func saleAssetId(sale salemodel.Sale) uuid.UUID {
+	if sale.AssetType == salemodel.AssetTypeCollection {
+		return *sale.Collection.ID
+	}
+	return *sale.Nft.ID
+}
+
+// royalty returns the creator of the asset on sale and the royalty percent
+// it set on it. An nft without a royalty of its own earns the royalty of the
+// collection it belongs to, which goes to the creator of the collection.
+func (o OfferService) royalty(c context.Context, sale salemodel.Sale) (uuid.UUID, float64, error) {
+	if sale.AssetType == salemodel.AssetTypeCollection {
+		return o.collectionRoyalty(c, *sale.Collection.ID)
+	}
+
+	nft, err := o.nftRepository.Get(c, persist.D{"id": *sale.Nft.ID})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+
+	if nft.RoyaltyPercent == 0 && nft.CollectionId != nil {
+		return o.collectionRoyalty(c, *nft.CollectionId)
+	}
+	return nft.User.ID, nft.RoyaltyPercent, nil
+}
+
+func (o OfferService) collectionRoyalty(c context.Context, collectionId uuid.UUID) (uuid.UUID, float64, error) {
+	collection, err := o.collectionRepository.Get(c, persist.D{"id": collectionId})
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	return collection.User.ID, collection.RoyaltyPercent, nil
+}
@@ -0,0 +1,38 @@
+package offer
+
+import (
+	"github.com/google/uuid"
+	"nft/pkg/money"
+)
+
+// proceeds is how the price of a settled sale is split between the seller,
+// the creator of the asset and the platform.
+type proceeds struct {
+	payout  money.Amount
+	royalty money.Amount
+	fee     money.Amount
+}
+
+// splitProceeds takes the platform fee and, when it is due, the creator
+// royalty out of the price. A minimum fee can leave less than the royalty,
+// so the royalty is capped at what the fee leaves. The seller gets the rest,
+// so the three parts always add up to the price.
+func splitProceeds(price money.Amount, royaltyPercent float64, fee money.Amount, royaltyDue bool) proceeds {
+	var p proceeds
+	p.fee = fee
+	if royaltyDue {
+		p.royalty = price.Percent(royaltyPercent)
+		if p.royalty > price-fee {
+			p.royalty = price - fee
+		}
+	}
+	p.payout = price - p.fee - p.royalty
+	return p
+}
+
+// royaltyDue reports whether a sale is a resale between non-creators. The
+// creator earns nothing on a sale it is part of, be it the first one or one
+// where it buys its work back.
+func royaltyDue(creatorId, sellerId, buyerId uuid.UUID) bool {
+	return creatorId != sellerId && creatorId != buyerId
+}
@@ -0,0 +1,53 @@
+package offer
+
+import (
+	"github.com/google/uuid"
+	"nft/pkg/money"
+	"testing"
+)
+
+func TestSplitProceeds(t *testing.T) {
+	tests := []struct {
+		name       string
+		royaltyDue bool
+		want       proceeds
+	}{
+		{"resale", true, proceeds{payout: 875, royalty: 100, fee: 25}},
+		{"primary sale", false, proceeds{payout: 975, fee: 25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitProceeds(1000, 10, 25, tt.royaltyDue)
+			if got != tt.want {
+				t.Errorf("splitProceeds() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	price := money.FromFloat(0.3)
+	got := splitProceeds(price, 7, money.FromFloat(0.0075), true)
+	if sum := got.payout + got.royalty + got.fee; sum != price {
+		t.Errorf("splitProceeds() parts add up to %d, want %d", sum, price)
+	}
+
+	// a minimum fee above the price less the royalty leaves the seller nothing
+	got = splitProceeds(10, 50, 8, true)
+	if want := (proceeds{payout: 0, royalty: 2, fee: 8}); got != want {
+		t.Errorf("splitProceeds() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRoyaltyDue(t *testing.T) {
+	creator, seller, buyer := uuid.New(), uuid.New(), uuid.New()
+
+	if !royaltyDue(creator, seller, buyer) {
+		t.Error("royaltyDue() = false for a resale between non-creators")
+	}
+	if royaltyDue(creator, creator, buyer) {
+		t.Error("royaltyDue() = true for a sale by the creator")
+	}
+	if royaltyDue(creator, seller, creator) {
+		t.Error("royaltyDue() = true for a sale to the creator")
+	}
+}
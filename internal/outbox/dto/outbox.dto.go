@@ -0,0 +1,18 @@
+package dto
+
+// Message leaves the payload out, as it may carry codes meant only for the
+// recipient.
+type Message struct {
+	ID            string `json:"id"`
+	Topic         string `json:"topic"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	LastError     string `json:"last_error,omitempty"`
+	SentAt        int64  `json:"sent_at,omitempty"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+type MessageList struct {
+	Messages []Message `json:"messages"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type OutboxMessage struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	Topic         string
+	Payload       string
+	Status        string `gorm:"index"`
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"index"`
+	LastError     *sql.NullString
+	SentAt        *time.Time
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Message is something to deliver once the change that queued it is
+// committed, such as an email. It is stored in the same transaction as the
+// change and handed to the handler of its topic by the outbox worker.
+type Message struct {
+	ID            *uuid.UUID
+	CreatedAt     time.Time
+	Topic         string
+	Payload       []byte
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	SentAt        *time.Time
+}
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	// StatusDead is for messages that failed every attempt. They stay until
+	// an admin requeues them.
+	StatusDead Status = "dead"
+)
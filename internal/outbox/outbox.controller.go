@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/outbox/model"
+	"nft/pkg/filper"
+)
+
+type OutboxController struct {
+	outboxService contract.IOutboxService
+}
+
+type OutboxControllerParams struct {
+	fx.In
+	OutboxService contract.IOutboxService
+}
+
+func NewOutboxController(params OutboxControllerParams) contract.IOutboxController {
+	return &OutboxController{
+		outboxService: params.OutboxService,
+	}
+}
+
+// GetMessages godoc
+// @Summary  get the messages of the outbox, to inspect the ones that failed
+// @Tags     outbox
+// @Accept   json
+// @Produce  json
+// @Param    status  query     string  false  "pending, sent or dead"
+// @Success  200     {object}  dto.MessageList
+// @Router   /v1/admin/outbox [get]
+func (o OutboxController) GetMessages(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "OutboxController[GetMessages]")
+	defer span.Finish()
+
+	messages, err := o.outboxService.GetMessages(ctx, model.Status(c.Query("status")))
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createMessageListDtoFromModel(messages))
+}
+
+// Requeue godoc
+// @Summary  requeue a dead message for delivery
+// @Tags     outbox
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "message id"
+// @Success  200  {object}  dto.Message
+// @Router   /v1/admin/outbox/{id}/requeue [post]
+func (o OutboxController) Requeue(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "OutboxController[Requeue]")
+	defer span.Finish()
+
+	messageId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidOutboxMessageId.Error())
+	}
+
+	message, err := o.outboxService.Requeue(ctx, messageId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOutboxMessageNotFound) {
+			return filper.GetNotFoundError(c, err.Error())
+		} else if errors.Is(err, apperrors.ErrOutboxMessageNotDead) {
+			return filper.GetBadRequestError(c, err.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapMessageModelToDto(message))
+}
@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"database/sql"
+	"nft/internal/outbox/dto"
+	"nft/internal/outbox/entity"
+	"nft/internal/outbox/model"
+)
+
+func nullString(s string) *sql.NullString {
+	if len(s) == 0 {
+		return nil
+	}
+	return &sql.NullString{String: s, Valid: true}
+}
+
+func mapMessageModelToEntity(m model.Message) entity.OutboxMessage {
+	return entity.OutboxMessage{
+		Topic:         m.Topic,
+		Payload:       string(m.Payload),
+		Status:        string(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     nullString(m.LastError),
+		SentAt:        m.SentAt,
+	}
+}
+
+func mapMessageEntityToModel(e entity.OutboxMessage) model.Message {
+	message := model.Message{
+		ID:            &e.ID,
+		CreatedAt:     e.CreatedAt,
+		Topic:         e.Topic,
+		Payload:       []byte(e.Payload),
+		Status:        model.Status(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		SentAt:        e.SentAt,
+	}
+
+	if e.LastError != nil {
+		message.LastError = e.LastError.String
+	}
+
+	return message
+}
+
+func createModelMessageListFromEntity(messages []entity.OutboxMessage) []model.Message {
+	messageList := make([]model.Message, len(messages))
+	for i := range messages {
+		messageList[i] = mapMessageEntityToModel(messages[i])
+	}
+	return messageList
+}
+
+func mapMessageModelToDto(m model.Message) dto.Message {
+	message := dto.Message{
+		ID:            m.ID.String(),
+		Topic:         m.Topic,
+		Status:        string(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt.Unix(),
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt.Unix(),
+	}
+
+	if m.SentAt != nil {
+		message.SentAt = m.SentAt.Unix()
+	}
+
+	return message
+}
+
+func createMessageListDtoFromModel(messages []model.Message) dto.MessageList {
+	messageList := make([]dto.Message, len(messages))
+	for i := range messages {
+		messageList[i] = mapMessageModelToDto(messages[i])
+	}
+	return dto.MessageList{Messages: messageList}
+}
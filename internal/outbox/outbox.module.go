@@ -0,0 +1,9 @@
+package outbox
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewOutboxController),
+	fx.Provide(NewOutboxService),
+	fx.Provide(NewOutboxRepository),
+)
@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/outbox/entity"
+	"nft/internal/outbox/model"
+	"time"
+)
+
+type OutboxRepository struct {
+	db contract.IPersist
+}
+
+type OutboxRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewOutboxRepository(params OutboxRepositoryParams) contract.IOutboxRepository {
+	return &OutboxRepository{
+		db: params.DB,
+	}
+}
+
+func (o OutboxRepository) Add(c context.Context, m model.Message) (model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxRepository[Add]")
+	defer span.Finish()
+
+	messageEntity := mapMessageModelToEntity(m)
+	messageEntity.ID = uuid.New()
+
+	created, err := o.db.Create(c, &messageEntity)
+	if err != nil {
+		return model.Message{}, err
+	}
+
+	return mapMessageEntityToModel(*created.(*entity.OutboxMessage)), nil
+}
+
+func (o OutboxRepository) Lock(c context.Context, conditions persist.D) (model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxRepository[Lock]")
+	defer span.Finish()
+
+	message, err := o.db.Lock(c, &entity.OutboxMessage{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Message{}, apperrors.ErrOutboxMessageNotFound
+		}
+		return model.Message{}, err
+	}
+
+	return mapMessageEntityToModel(*message.(*entity.OutboxMessage)), nil
+}
+
+func (o OutboxRepository) Find(c context.Context, query persist.Query) ([]model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxRepository[Find]")
+	defer span.Finish()
+
+	messages, err := o.db.Find(c, &[]entity.OutboxMessage{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelMessageListFromEntity(*messages.(*[]entity.OutboxMessage)), nil
+}
+
+func (o OutboxRepository) Update(c context.Context, m model.Message) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxRepository[Update]")
+	defer span.Finish()
+
+	data := persist.D{
+		"status":          m.Status,
+		"attempts":        m.Attempts,
+		"next_attempt_at": m.NextAttemptAt,
+		"last_error":      nullString(m.LastError),
+		"sent_at":         m.SentAt,
+	}
+	if _, err := o.db.Update(c, &entity.OutboxMessage{ID: *m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateLeased updates the message like Update, but only while it still
+// holds the lease it was claimed with. Once the lease ran out the message
+// may have been claimed again, and the new claim owns it.
+func (o OutboxRepository) UpdateLeased(c context.Context, m model.Message, leasedUntil time.Time) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxRepository[UpdateLeased]")
+	defer span.Finish()
+
+	data := persist.D{
+		"status":          m.Status,
+		"attempts":        m.Attempts,
+		"next_attempt_at": m.NextAttemptAt,
+		"last_error":      nullString(m.LastError),
+		"sent_at":         m.SentAt,
+	}
+	updated, err := o.db.UpdateWhere(c, &entity.OutboxMessage{}, persist.D{"id": *m.ID, "next_attempt_at": leasedUntil}, data)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return apperrors.ErrOutboxLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/outbox/model"
+	"nft/pkg/schedule"
+	"time"
+)
+
+type OutboxService struct {
+	db               contract.IPersist
+	outboxRepository contract.IOutboxRepository
+	handlers         map[string]contract.IOutboxHandler
+}
+
+type OutboxServiceParams struct {
+	fx.In
+	DB               contract.IPersist
+	OutboxRepository contract.IOutboxRepository
+	Handlers         []contract.IOutboxHandler `group:"outbox_handlers"`
+}
+
+func NewOutboxService(params OutboxServiceParams) contract.IOutboxService {
+	handlers := make(map[string]contract.IOutboxHandler, len(params.Handlers))
+	for _, handler := range params.Handlers {
+		handlers[handler.Topic()] = handler
+	}
+
+	return &OutboxService{
+		db:               params.DB,
+		outboxRepository: params.OutboxRepository,
+		handlers:         handlers,
+	}
+}
+
+// Enqueue stores the payload for the handler of the topic. Called inside a
+// transaction, the message is only delivered if the transaction commits.
+// It is tried right after the commit rather than on the next run of the
+// worker, which still retries it if that fails.
+func (o OutboxService) Enqueue(c context.Context, topic string, payload any) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[Enqueue]")
+	defer span.Finish()
+
+	if _, ok := o.handlers[topic]; !ok {
+		return fmt.Errorf("%w: %s", apperrors.ErrUnknownOutboxTopic, topic)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	message, err := o.outboxRepository.Add(c, model.Message{
+		Topic:         topic,
+		Payload:       data,
+		Status:        model.StatusPending,
+		NextAttemptAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the caller's context ends with its request, the delivery may not
+	o.db.AfterCommit(c, func(context.Context) {
+		go o.deliverNow(*message.ID)
+	})
+	return nil
+}
+
+// Deliver hands the pending messages that are due to their handlers, oldest
+// first. A message that fails is retried later, and is dead once it runs out
+// of attempts.
+func (o OutboxService) Deliver(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[Deliver]")
+	defer span.Finish()
+
+	messages, err := o.claim(c, persist.D{})
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := o.deliver(c, message); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// deliverNow delivers the message unless the worker already claimed it.
+func (o OutboxService) deliverNow(id uuid.UUID) {
+	c := context.Background()
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[deliverNow]")
+	defer span.Finish()
+
+	messages, err := o.claim(c, persist.D{"id": id})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, message := range messages {
+		if err := o.deliver(c, message); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// claim takes a batch of the due messages that match the conditions for
+// the lease, skipping those another worker is claiming, so no message is
+// handed to two handlers at once. A message whose worker dies is delivered
+// again once its lease runs out.
+func (o OutboxService) claim(c context.Context, conditions persist.D) ([]model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[claim]")
+	defer span.Finish()
+
+	var claimed []model.Message
+	err := o.db.Transaction(c, func(c context.Context) error {
+		now := time.Now()
+		conditions["status"] = model.StatusPending
+		conditions["next_attempt_at <="] = now
+		messages, err := o.outboxRepository.Find(c, persist.Query{
+			Conditions: conditions,
+			Order:      "next_attempt_at asc",
+			Limit:      config.C().Outbox.BatchSize,
+			SkipLocked: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		// postgres keeps microseconds, and deliver matches the lease exactly
+		leasedUntil := now.Add(time.Duration(config.C().Outbox.LeaseInSec) * time.Second).Truncate(time.Microsecond)
+		for i := range messages {
+			messages[i].NextAttemptAt = leasedUntil
+			if err := o.outboxRepository.Update(c, messages[i]); err != nil {
+				return err
+			}
+		}
+
+		claimed = messages
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// deliver runs the handler outside of any transaction and records how it
+// went afterwards, so a slow handler holds no lock. The outcome is dropped
+// if the lease ran out meanwhile and the message was claimed again.
+func (o OutboxService) deliver(c context.Context, m model.Message) error {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[deliver]")
+	defer span.Finish()
+
+	leasedUntil := m.NextAttemptAt
+
+	var err error
+	if handler, ok := o.handlers[m.Topic]; ok {
+		err = handler.Handle(c, m.Payload)
+	} else {
+		err = fmt.Errorf("%w: %s", apperrors.ErrUnknownOutboxTopic, m.Topic)
+	}
+
+	m.Attempts++
+	if err == nil {
+		now := time.Now()
+		m.Status = model.StatusSent
+		m.SentAt = &now
+		return o.outboxRepository.UpdateLeased(c, m, leasedUntil)
+	}
+
+	m.LastError = err.Error()
+	if m.Attempts >= config.C().Outbox.MaxAttempts {
+		m.Status = model.StatusDead
+	} else {
+		m.NextAttemptAt = time.Now().Add(schedule.Backoff(m.Attempts,
+			time.Duration(config.C().Outbox.BackoffInSec)*time.Second,
+			time.Duration(config.C().Outbox.MaxBackoffInSec)*time.Second))
+	}
+	if err := o.outboxRepository.UpdateLeased(c, m, leasedUntil); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("outbox message %s failed attempt %d: %w", m.ID, m.Attempts, err)
+}
+
+func (o OutboxService) GetMessages(c context.Context, status model.Status) ([]model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[GetMessages]")
+	defer span.Finish()
+
+	conditions := persist.D{}
+	if len(status) > 0 {
+		conditions["status"] = status
+	}
+
+	return o.outboxRepository.Find(c, persist.Query{Conditions: conditions, Order: "created_at desc"})
+}
+
+// Requeue gives a dead message a fresh set of attempts, starting right away.
+func (o OutboxService) Requeue(c context.Context, id uuid.UUID) (model.Message, error) {
+	span, c := jtrace.T().SpanFromContext(c, "OutboxService[Requeue]")
+	defer span.Finish()
+
+	var message model.Message
+	err := o.db.Transaction(c, func(c context.Context) error {
+		var err error
+		message, err = o.outboxRepository.Lock(c, persist.D{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if message.Status != model.StatusDead {
+			return apperrors.ErrOutboxMessageNotDead
+		}
+
+		message.Status = model.StatusPending
+		message.Attempts = 0
+		message.NextAttemptAt = time.Now()
+
+		return o.outboxRepository.Update(c, message)
+	})
+	if err != nil {
+		return model.Message{}, err
+	}
+
+	return message, nil
+}
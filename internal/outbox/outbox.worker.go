@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"nft/config"
+	"nft/contract"
+	"nft/pkg/schedule"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// StartOutboxWorker periodically delivers the pending messages of the
+// outbox for the lifetime of the application.
+func StartOutboxWorker(lc fx.Lifecycle, outboxService contract.IOutboxService) {
+	schedule.Every(lc, "outbox worker", func() time.Duration {
+		return time.Duration(config.C().Outbox.WorkerIntervalInSec) * time.Second
+	}, outboxService.Deliver)
+}
@@ -0,0 +1,17 @@
+package sale
+
+type MarketRequest struct {
+	CategoryId string   `query:"category_id" validate:"omitempty,uuid"`
+	AssetType  string   `query:"asset_type" validate:"omitempty,oneof=nft collection"`
+	SaleType   string   `query:"sale_type" validate:"omitempty,oneof=p2p auction fixed_price"`
+	MinPrice   *float64 `query:"min_price" validate:"omitempty,min=0"`
+	MaxPrice   *float64 `query:"max_price" validate:"omitempty,min=0"`
+	Sort       string   `query:"sort" validate:"omitempty,oneof=newest price_asc price_desc ending_soon"`
+	Cursor     string   `query:"cursor"`
+	Limit      int      `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type MarketPage struct {
+	Sales      []Sale `json:"sales"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
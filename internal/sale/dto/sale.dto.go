@@ -15,6 +15,7 @@ type Sale struct {
 	Collection *collection.Collection `json:"collection,omitempty"`
 	Nft        *nft.Nft               `json:"nft,omitempty"`
 	MinPrice   float64                `json:"min_price"`
+	Fee        float64                `json:"fee"`
 	SaleType   SaleType               `json:"sale_type"`
 	AssetType  AssetType              `json:"asset_type"`
 	Status     Status                 `json:"status"`
@@ -23,8 +24,9 @@ type Sale struct {
 type SaleType string
 
 const (
-	SaleTypeP2P     SaleType = "p2p"
-	SaleTypeAuction SaleType = "auction"
+	SaleTypeP2P        SaleType = "p2p"
+	SaleTypeAuction    SaleType = "auction"
+	SaleTypeFixedPrice SaleType = "fixed_price"
 )
 
 type AssetType string
@@ -5,7 +5,7 @@ import "github.com/google/uuid"
 type SaleRequest struct {
 	AssetId  uuid.UUID `json:"asset_id" validate:"required"`
 	MinPrice float64   `json:"min_price" validate:"required"`
-	SaleType SaleType  `json:"sale_type" validate:"required"`
+	SaleType SaleType  `json:"sale_type" validate:"required,oneof=p2p auction fixed_price"`
 }
 
 type SaleResponse struct {
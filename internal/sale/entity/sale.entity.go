@@ -19,13 +19,15 @@ type Sale struct {
 	AssetType  AssetType
 	AssetId    uuid.UUID `gorm:"type:uuid;"`
 	MinPrice   float64
+	Status     SaleStatus `gorm:"default:in_progress"`
 }
 
 type SaleType string
 
 const (
-	SaleTypeP2P     SaleType = "p2p"
-	SaleTypeAuction SaleType = "auction"
+	SaleTypeP2P        SaleType = "p2p"
+	SaleTypeAuction    SaleType = "auction"
+	SaleTypeFixedPrice SaleType = "fixed_price"
 )
 
 type AssetType string
@@ -34,3 +36,12 @@ const (
 	AssetTypeNft        AssetType = "nft"
 	AssetTypeCollection AssetType = "collection"
 )
+
+type SaleStatus string
+
+const (
+	SaleStatusSold       SaleStatus = "sold"
+	SaleStatusInProgress SaleStatus = "in_progress"
+	SaleStatusCanceled   SaleStatus = "canceled"
+	SaleStatusExpired    SaleStatus = "expired"
+)
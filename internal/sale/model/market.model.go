@@ -0,0 +1,28 @@
+package model
+
+import "github.com/google/uuid"
+
+type MarketQuery struct {
+	CategoryId *uuid.UUID
+	AssetType  AssetType
+	SaleType   Type
+	MinPrice   *float64
+	MaxPrice   *float64
+	Sort       MarketSort
+	Cursor     string
+	Limit      int
+}
+
+type MarketPage struct {
+	Sales      []Sale
+	NextCursor string
+}
+
+type MarketSort string
+
+const (
+	MarketSortNewest     MarketSort = "newest"
+	MarketSortPriceAsc   MarketSort = "price_asc"
+	MarketSortPriceDesc  MarketSort = "price_desc"
+	MarketSortEndingSoon MarketSort = "ending_soon"
+)
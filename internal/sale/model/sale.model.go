@@ -11,6 +11,7 @@ import (
 
 type Sale struct {
 	ID            *uuid.UUID
+	CreatedAt     time.Time
 	User          usermodel.User
 	Expiration    time.Time
 	CanceledBy    *usermodel.User
@@ -19,6 +20,7 @@ type Sale struct {
 	Nft           *nft.Nft
 	AcceptedOffer *offer.Offer
 	MinPrice      float64
+	Fee           float64
 	SaleType      Type
 	AssetType     AssetType
 	Status        Status
@@ -27,8 +29,9 @@ type Sale struct {
 type Type string
 
 const (
-	SaleTypeP2P     Type = "p2p"
-	SaleTypeAuction Type = "auction"
+	SaleTypeP2P        Type = "p2p"
+	SaleTypeAuction    Type = "auction"
+	SaleTypeFixedPrice Type = "fixed_price"
 )
 
 type AssetType string
@@ -65,6 +65,15 @@ func (s SaleController) SellNft(c *fiber.Ctx) error {
 	sale, err := s.saleService.CreateNftSale(ctx, mapCreateSaleDtoToModel(request, userId))
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, apperrors.ErrKycRequired) {
+			return filper.GetForbiddenError(c, apperrors.ErrKycRequired.Error())
+		} else if errors.Is(err, apperrors.ErrTradingLimitExceeded) {
+			return filper.GetBadRequestError(c, apperrors.ErrTradingLimitExceeded.Error())
+		} else if errors.Is(err, apperrors.ErrNftNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrNftNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrNftListedInCollection) || errors.Is(err, apperrors.ErrAssetListed) {
+			return filper.GetBadRequestError(c, err.Error())
+		}
 		return filper.GetInternalError(c, "")
 	}
 
@@ -102,8 +111,18 @@ func (s SaleController) SellCollection(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errRes)
 	}
 
-	sale, err := s.saleService.CreateNftSale(ctx, mapCreateSaleDtoToModel(request, userId))
+	sale, err := s.saleService.CreateCollectionSale(ctx, mapCreateCollectionSaleDtoToModel(request, userId))
 	if err != nil {
+		if errors.Is(err, apperrors.ErrKycRequired) {
+			return filper.GetForbiddenError(c, apperrors.ErrKycRequired.Error())
+		} else if errors.Is(err, apperrors.ErrTradingLimitExceeded) {
+			return filper.GetBadRequestError(c, apperrors.ErrTradingLimitExceeded.Error())
+		} else if errors.Is(err, apperrors.ErrCollectionNotFound) {
+			return filper.GetNotFoundError(c, apperrors.ErrCollectionNotFound.Error())
+		} else if errors.Is(err, apperrors.ErrCollectionEmpty) || errors.Is(err, apperrors.ErrCollectionNftNotOwned) ||
+			errors.Is(err, apperrors.ErrNftListedSeparately) || errors.Is(err, apperrors.ErrAssetListed) {
+			return filper.GetBadRequestError(c, err.Error())
+		}
 		return filper.GetInternalError(c, "")
 	}
 
@@ -133,12 +152,64 @@ func (s SaleController) CancelSale(c *fiber.Ctx) error {
 	}
 
 	if err := s.saleService.CancelSale(ctx, model.Sale{ID: &saleId, User: usermodel.User{ID: userId}}); err != nil {
+		if errors.Is(err, apperrors.ErrSaleNotFound) {
+			return filper.GetNotFoundError(c, "sale not found")
+		} else if errors.Is(err, apperrors.ErrSaleClosed) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleClosed.Error())
+		}
 		return filper.GetInternalError(c, "")
 	}
 
 	return filper.GetSuccessResponse(c, "sale canceled")
 }
 
+// BuySale godoc
+// @Summary  buy a fixed price sale at its list price
+// @Tags     sale
+// @Accept   json
+// @Produce  json
+// @Param    id  path  string  true  "sale id that you want to buy"
+// @Router   /v1/sale/{id}/buy [post]
+// @Success  200  {string}  string  "sale bought successfully"
+func (s SaleController) BuySale(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "SaleController[BuySale]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	saleId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, "invalid sale id")
+	}
+
+	if err := s.saleService.BuySale(ctx, model.Sale{ID: &saleId, User: usermodel.User{ID: userId}}); err != nil {
+		if errors.Is(err, apperrors.ErrSaleNotFound) {
+			return filper.GetNotFoundError(c, "sale not found")
+		} else if errors.Is(err, apperrors.ErrSaleNotFixedPrice) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleNotFixedPrice.Error())
+		} else if errors.Is(err, apperrors.ErrSaleClosed) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleClosed.Error())
+		} else if errors.Is(err, apperrors.ErrSaleExpired) {
+			return filper.GetBadRequestError(c, apperrors.ErrSaleExpired.Error())
+		} else if errors.Is(err, apperrors.ErrBuyYourSale) {
+			return filper.GetBadRequestError(c, apperrors.ErrBuyYourSale.Error())
+		} else if errors.Is(err, apperrors.ErrInsufficientFunds) {
+			return filper.GetBadRequestError(c, apperrors.ErrInsufficientFunds.Error())
+		} else if errors.Is(err, apperrors.ErrKycRequired) {
+			return filper.GetForbiddenError(c, apperrors.ErrKycRequired.Error())
+		} else if errors.Is(err, apperrors.ErrTradingLimitExceeded) {
+			return filper.GetBadRequestError(c, apperrors.ErrTradingLimitExceeded.Error())
+		}
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "sale bought successfully")
+}
+
 // GetAllSales godoc
 // @Summary  get user sale list
 // @Tags     sale
@@ -199,3 +270,46 @@ func (s SaleController) GetSale(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(mapSaleModelToDto(sale))
 }
+
+// GetMarket godoc
+// @Summary  browse active sales on the marketplace
+// @Tags     sale
+// @Accept   json
+// @Produce  json
+// @Param    category_id  query  string  false  "category id, subcategories are included"
+// @Param    asset_type   query  string  false  "nft or collection"
+// @Param    sale_type    query  string  false  "p2p, auction or fixed_price"
+// @Param    min_price    query  number  false  "minimum price"
+// @Param    max_price    query  number  false  "maximum price"
+// @Param    sort         query  string  false  "newest, price_asc, price_desc or ending_soon"
+// @Param    cursor       query  string  false  "next_cursor of the previous page"
+// @Param    limit        query  int     false  "page size, at most 100"
+// @Router   /v1/sale/market [get]
+// @Success  200  {object}  dto.MarketPage
+func (s SaleController) GetMarket(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "SaleController[GetMarket]")
+	defer span.Finish()
+
+	var request dto.MarketRequest
+	if err := c.QueryParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid query params")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	page, err := s.saleService.GetMarket(ctx, mapMarketRequestDtoToModel(request))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidMarketCursor) {
+			return filper.GetBadRequestError(c, apperrors.ErrInvalidMarketCursor.Error())
+		} else if errors.Is(err, apperrors.ErrInvalidMarketSort) {
+			return filper.GetBadRequestError(c, apperrors.ErrInvalidMarketSort.Error())
+		}
+		log.Println(err)
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapMarketPageModelToDto(page))
+}
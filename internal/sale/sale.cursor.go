@@ -0,0 +1,90 @@
+package sale
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	apperrors "nft/error"
+	"nft/internal/sale/model"
+	"strconv"
+	"time"
+)
+
+// marketOrder is the keyset used to page through the market for one sort.
+// The sale id breaks ties so every row has a distinct position.
+type marketOrder struct {
+	column string
+	desc   bool
+}
+
+var marketOrders = map[model.MarketSort]marketOrder{
+	model.MarketSortNewest:     {column: "created_at", desc: true},
+	model.MarketSortPriceAsc:   {column: "min_price"},
+	model.MarketSortPriceDesc:  {column: "min_price", desc: true},
+	model.MarketSortEndingSoon: {column: "expiration"},
+}
+
+func (o marketOrder) clause() string {
+	if o.desc {
+		return fmt.Sprintf("%s desc, id desc", o.column)
+	}
+	return fmt.Sprintf("%s asc, id asc", o.column)
+}
+
+// after is the condition key selecting the rows that follow a cursor.
+func (o marketOrder) after() string {
+	if o.desc {
+		return fmt.Sprintf("(%s, id) <", o.column)
+	}
+	return fmt.Sprintf("(%s, id) >", o.column)
+}
+
+type marketCursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func encodeMarketCursor(sort model.MarketSort, sale model.Sale) string {
+	var value string
+	switch marketOrders[sort].column {
+	case "created_at":
+		value = sale.CreatedAt.Format(time.RFC3339Nano)
+	case "expiration":
+		value = sale.Expiration.Format(time.RFC3339Nano)
+	case "min_price":
+		value = strconv.FormatFloat(sale.MinPrice, 'g', -1, 64)
+	}
+
+	raw, _ := json.Marshal(marketCursor{Value: value, ID: *sale.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeMarketCursor returns the keyset values of the row a cursor points
+// to, in the order expected by marketOrder.after.
+func decodeMarketCursor(sort model.MarketSort, cursor string) ([]any, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, apperrors.ErrInvalidMarketCursor
+	}
+
+	var mc marketCursor
+	if err := json.Unmarshal(raw, &mc); err != nil {
+		return nil, apperrors.ErrInvalidMarketCursor
+	}
+
+	var value any
+	switch marketOrders[sort].column {
+	case "created_at", "expiration":
+		value, err = time.Parse(time.RFC3339Nano, mc.Value)
+	case "min_price":
+		value, err = strconv.ParseFloat(mc.Value, 64)
+	default:
+		err = apperrors.ErrInvalidMarketCursor
+	}
+	if err != nil {
+		return nil, apperrors.ErrInvalidMarketCursor
+	}
+
+	return []any{value, mc.ID}, nil
+}
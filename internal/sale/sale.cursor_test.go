@@ -0,0 +1,67 @@
+package sale
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	apperrors "nft/error"
+	"nft/internal/sale/model"
+	"testing"
+	"time"
+)
+
+func TestMarketCursor(t *testing.T) {
+	id := uuid.New()
+	createdAt := time.Date(2022, 8, 20, 10, 30, 0, 123456000, time.UTC)
+	sale := model.Sale{ID: &id, CreatedAt: createdAt, MinPrice: 12.75}
+
+	{
+		values, err := decodeMarketCursor(model.MarketSortNewest, encodeMarketCursor(model.MarketSortNewest, sale))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := values[0].(time.Time); !got.Equal(createdAt) {
+			t.Errorf("decodeMarketCursor() value = %v, want %v", got, createdAt)
+		}
+		if got := values[1].(uuid.UUID); got != id {
+			t.Errorf("decodeMarketCursor() id = %v, want %v", got, id)
+		}
+	}
+	{
+		values, err := decodeMarketCursor(model.MarketSortPriceDesc, encodeMarketCursor(model.MarketSortPriceDesc, sale))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := values[0].(float64); got != 12.75 {
+			t.Errorf("decodeMarketCursor() value = %v, want 12.75", got)
+		}
+	}
+	{
+		if _, err := decodeMarketCursor(model.MarketSortNewest, "not-a-cursor"); !errors.Is(err, apperrors.ErrInvalidMarketCursor) {
+			t.Errorf("decodeMarketCursor() error = %v, want %v", err, apperrors.ErrInvalidMarketCursor)
+		}
+	}
+	{
+		cursor := encodeMarketCursor(model.MarketSortPriceAsc, sale)
+		if _, err := decodeMarketCursor(model.MarketSortNewest, cursor); !errors.Is(err, apperrors.ErrInvalidMarketCursor) {
+			t.Errorf("decodeMarketCursor() error = %v, want %v", err, apperrors.ErrInvalidMarketCursor)
+		}
+	}
+}
+
+func TestMarketOrder(t *testing.T) {
+	order := marketOrders[model.MarketSortNewest]
+	if got := order.clause(); got != "created_at desc, id desc" {
+		t.Errorf("clause() = %v", got)
+	}
+	if got := order.after(); got != "(created_at, id) <" {
+		t.Errorf("after() = %v", got)
+	}
+
+	order = marketOrders[model.MarketSortEndingSoon]
+	if got := order.clause(); got != "expiration asc, id asc" {
+		t.Errorf("clause() = %v", got)
+	}
+	if got := order.after(); got != "(expiration, id) >" {
+		t.Errorf("after() = %v", got)
+	}
+}
@@ -0,0 +1,18 @@
+package sale
+
+import (
+	"nft/config"
+	"nft/contract"
+	"nft/pkg/schedule"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// StartSaleExpirer periodically expires sales that passed their expiration
+// for the lifetime of the application.
+func StartSaleExpirer(lc fx.Lifecycle, saleService contract.ISaleService) {
+	schedule.Every(lc, "sale expirer", func() time.Duration {
+		return time.Duration(config.C().Sale.ExpirerIntervalInSec) * time.Second
+	}, saleService.ExpireSales)
+}
@@ -31,6 +31,7 @@ func mapSaleModelToEntity(m model.Sale) entity.Sale {
 		AssetType:  entity.AssetType(m.AssetType),
 		AssetId:    assetId,
 		MinPrice:   m.MinPrice,
+		Status:     entity.SaleStatus(m.Status),
 	}
 }
 
@@ -40,27 +41,35 @@ func mapSaleEntityToModel(e entity.Sale) model.Sale {
 		canceledBy.ID = *e.CanceledBy
 	}
 
-	var col collection.Collection
-	var nftModel nft.Nft
+	// rows canceled before the status column existed only carry canceled_at
+	status := model.Status(e.Status)
+	if e.CanceledAt != nil {
+		status = model.SaleStatusCanceled
+	}
+
+	var col *collection.Collection
+	var nftModel *nft.Nft
 
 	switch e.AssetType {
 	case entity.AssetTypeNft:
-		nftModel.ID = &e.AssetId
+		nftModel = &nft.Nft{ID: &e.AssetId}
 	case entity.AssetTypeCollection:
-		col.ID = &e.AssetId
+		col = &collection.Collection{ID: &e.AssetId}
 	}
 
 	return model.Sale{
 		ID:         &e.ID,
+		CreatedAt:  e.CreatedAt,
 		User:       usermodel.User{ID: e.UserId},
 		Expiration: e.Expiration,
 		CanceledBy: &canceledBy,
 		CanceledAt: e.CanceledAt,
-		Collection: &col,
-		Nft:        &nftModel,
+		Collection: col,
+		Nft:        nftModel,
 		MinPrice:   e.MinPrice,
 		SaleType:   model.Type(e.SaleType),
 		AssetType:  model.AssetType(e.AssetType),
+		Status:     status,
 	}
 }
 
@@ -73,6 +82,15 @@ func mapCreateSaleDtoToModel(request dto.SaleRequest, userId uuid.UUID) model.Sa
 	}
 }
 
+func mapCreateCollectionSaleDtoToModel(request dto.SaleRequest, userId uuid.UUID) model.Sale {
+	return model.Sale{
+		User:       usermodel.User{ID: userId},
+		Collection: &collection.Collection{ID: &request.AssetId},
+		MinPrice:   request.MinPrice,
+		SaleType:   model.Type(request.SaleType),
+	}
+}
+
 func mapCreateSaleModelToDto(sale model.Sale) dto.SaleResponse {
 	return dto.SaleResponse{
 		SaleId:     *sale.ID,
@@ -90,6 +108,31 @@ func createSalesListDtoFromModel(list []model.Sale) dto.SaleList {
 	return dto.SaleList{Sales: saleList}
 }
 
+func mapMarketRequestDtoToModel(request dto.MarketRequest) model.MarketQuery {
+	query := model.MarketQuery{
+		AssetType: model.AssetType(request.AssetType),
+		SaleType:  model.Type(request.SaleType),
+		MinPrice:  request.MinPrice,
+		MaxPrice:  request.MaxPrice,
+		Sort:      model.MarketSort(request.Sort),
+		Cursor:    request.Cursor,
+		Limit:     request.Limit,
+	}
+
+	if categoryId, err := uuid.Parse(request.CategoryId); err == nil {
+		query.CategoryId = &categoryId
+	}
+
+	return query
+}
+
+func mapMarketPageModelToDto(page model.MarketPage) dto.MarketPage {
+	return dto.MarketPage{
+		Sales:      createSalesListDtoFromModel(page.Sales).Sales,
+		NextCursor: page.NextCursor,
+	}
+}
+
 func mapSaleModelToDto(sale model.Sale) dto.Sale {
 	var col *collectiondto.Collection
 	var nftDto *nftdto.Nft
@@ -108,6 +151,7 @@ func mapSaleModelToDto(sale model.Sale) dto.Sale {
 		Collection: col,
 		Nft:        nftDto,
 		MinPrice:   sale.MinPrice,
+		Fee:        sale.Fee,
 		SaleType:   dto.SaleType(sale.SaleType),
 		AssetType:  dto.AssetType(sale.AssetType),
 		Status:     dto.Status(sale.Status),
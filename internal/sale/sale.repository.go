@@ -36,6 +36,7 @@ func (s SaleRepository) Create(c context.Context, m model.Sale) (model.Sale, err
 	saleEntity := mapSaleModelToEntity(m)
 	saleEntity.ID = uuid.New()
 	saleEntity.Expiration = time.Now().Add(time.Hour * 168)
+	saleEntity.Status = entity.SaleStatusInProgress
 
 	createdSale, err := s.db.Create(c, &saleEntity)
 	if err != nil {
@@ -64,7 +65,7 @@ func (s SaleRepository) Cancel(c context.Context, m model.Sale) error {
 	span, c := jtrace.T().SpanFromContext(c, "SaleRepository[Cancel]")
 	defer span.Finish()
 
-	data := persist.D{"canceled_at": time.Now(), "canceled_by": m.User.ID}
+	data := persist.D{"canceled_at": time.Now(), "canceled_by": m.User.ID, "status": entity.SaleStatusCanceled}
 	if _, err := s.db.Update(c, &entity.Sale{ID: *m.ID}, data); err != nil {
 		return err
 	}
@@ -82,3 +83,50 @@ func (s SaleRepository) GetAll(c context.Context, conditions persist.D) ([]model
 
 	return createModelSaleListFromEntity(*saleList.(*[]entity.Sale)), nil
 }
+
+func (s SaleRepository) Lock(c context.Context, conditions persist.D) (model.Sale, error) {
+	span, c := jtrace.T().SpanFromContext(c, "SaleRepository[Lock]")
+	defer span.Finish()
+
+	sale, err := s.db.Lock(c, &entity.Sale{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Sale{}, apperrors.ErrSaleNotFound
+		}
+		return model.Sale{}, err
+	}
+
+	return mapSaleEntityToModel(*sale.(*entity.Sale)), nil
+}
+
+func (s SaleRepository) UpdateStatus(c context.Context, m model.Sale) error {
+	span, c := jtrace.T().SpanFromContext(c, "SaleRepository[UpdateStatus]")
+	defer span.Finish()
+
+	if _, err := s.db.Update(c, &entity.Sale{ID: *m.ID}, persist.D{"status": m.Status}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s SaleRepository) Extend(c context.Context, m model.Sale) error {
+	span, c := jtrace.T().SpanFromContext(c, "SaleRepository[Extend]")
+	defer span.Finish()
+
+	if _, err := s.db.Update(c, &entity.Sale{ID: *m.ID}, persist.D{"expiration": m.Expiration}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s SaleRepository) Find(c context.Context, query persist.Query) ([]model.Sale, error) {
+	span, c := jtrace.T().SpanFromContext(c, "SaleRepository[Find]")
+	defer span.Finish()
+
+	saleList, err := s.db.Find(c, &[]entity.Sale{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelSaleListFromEntity(*saleList.(*[]entity.Sale)), nil
+}
@@ -2,41 +2,81 @@ package sale
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"log"
 	"nft/contract"
 	apperrors "nft/error"
+	"nft/infra/eventbus"
+	eventmodel "nft/infra/eventbus/model"
 	"nft/infra/jtrace"
 	persist "nft/infra/persist/type"
+	catmodel "nft/internal/category/model"
 	collection "nft/internal/collection/model"
+	feemodel "nft/internal/fee/model"
 	nft "nft/internal/nft/model"
+	notificationmodel "nft/internal/notification/model"
+	offermodel "nft/internal/offer/model"
 	"nft/internal/sale/model"
 	usermodel "nft/internal/user/model"
+	"sort"
+	"time"
 )
 
 type SaleService struct {
-	saleRepository    contract.ISaleRepository
-	nftService        contract.INftService
-	collectionService contract.ICollectionService
-	offerRepository   contract.IOfferRepository
+	db                    contract.IPersist
+	saleRepository        contract.ISaleRepository
+	nftService            contract.INftService
+	nftRepository         contract.INftRepository
+	collectionService     contract.ICollectionService
+	collectionRepository  contract.ICollectionRepository
+	offerRepository       contract.IOfferRepository
+	transactionRepository contract.ITransactionRepository
+	categoryService       contract.ICategoryService
+	ledgerService         contract.ILedgerService
+	offerService          contract.IOfferService
+	feeService            contract.IFeeService
+	limitService          contract.ILimitService
+	notificationService   contract.INotificationService
+	outboxService         contract.IOutboxService
 }
 
 type SaleServiceParams struct {
 	fx.In
-	SaleRepository    contract.ISaleRepository
-	NftService        contract.INftService
-	CollectionService contract.ICollectionService
-	OfferRepository   contract.IOfferRepository
+	DB                    contract.IPersist
+	SaleRepository        contract.ISaleRepository
+	NftService            contract.INftService
+	NftRepository         contract.INftRepository
+	CollectionService     contract.ICollectionService
+	CollectionRepository  contract.ICollectionRepository
+	OfferRepository       contract.IOfferRepository
+	TransactionRepository contract.ITransactionRepository
+	CategoryService       contract.ICategoryService
+	LedgerService         contract.ILedgerService
+	OfferService          contract.IOfferService
+	FeeService            contract.IFeeService
+	LimitService          contract.ILimitService
+	NotificationService   contract.INotificationService
+	OutboxService         contract.IOutboxService
 }
 
 func NewSaleService(params SaleServiceParams) contract.ISaleService {
 	return &SaleService{
-		saleRepository:    params.SaleRepository,
-		nftService:        params.NftService,
-		collectionService: params.CollectionService,
-		offerRepository:   params.OfferRepository,
+		db:                    params.DB,
+		saleRepository:        params.SaleRepository,
+		nftService:            params.NftService,
+		nftRepository:         params.NftRepository,
+		collectionService:     params.CollectionService,
+		collectionRepository:  params.CollectionRepository,
+		offerRepository:       params.OfferRepository,
+		transactionRepository: params.TransactionRepository,
+		categoryService:       params.CategoryService,
+		ledgerService:         params.LedgerService,
+		offerService:          params.OfferService,
+		feeService:            params.FeeService,
+		limitService:          params.LimitService,
+		notificationService:   params.NotificationService,
+		outboxService:         params.OutboxService,
 	}
 }
 
@@ -44,42 +84,290 @@ func (s SaleService) CreateNftSale(c context.Context, m model.Sale) (model.Sale,
 	span, c := jtrace.T().SpanFromContext(c, "SaleService[CreateNftSale]")
 	defer span.Finish()
 
-	ownedNft, err := s.nftService.GetOwnedNft(c, nft.Nft{ID: m.Nft.ID, CurrentOwner: &m.User})
-	if err != nil {
-		return model.Sale{}, err
-	}
+	var sale model.Sale
+	err := s.db.Transaction(c, func(c context.Context) error {
+		// a sale settles at its min price at least
+		if err := s.limitService.Check(c, m.User.ID, m.MinPrice); err != nil {
+			return err
+		}
+
+		// the nft is locked like on transfer, so listing and moving it, or
+		// listing it twice, are serialized
+		if _, err := s.nftRepository.Lock(c, persist.D{"id": *m.Nft.ID}); err != nil {
+			return err
+		}
 
-	m.User = usermodel.User{ID: ownedNft.CurrentOwner.ID}
-	m.AssetType = model.AssetTypeNft
+		ownedNft, err := s.nftService.GetOwnedNft(c, nft.Nft{ID: m.Nft.ID, CurrentOwner: &m.User})
+		if err != nil {
+			return err
+		}
+
+		listed, err := s.listed(c, persist.D{"asset_id": *ownedNft.ID})
+		if err != nil {
+			return err
+		}
+		if listed {
+			return apperrors.ErrAssetListed
+		}
 
-	return s.saleRepository.Create(c, m)
+		if ownedNft.CollectionId != nil {
+			listed, err := s.listed(c, persist.D{"asset_id": *ownedNft.CollectionId})
+			if err != nil {
+				return err
+			}
+			if listed {
+				return apperrors.ErrNftListedInCollection
+			}
+		}
+
+		m.User = usermodel.User{ID: ownedNft.CurrentOwner.ID}
+		m.AssetType = model.AssetTypeNft
+
+		sale, err = s.create(c, m, *ownedNft.ID)
+		return err
+	})
+	return sale, err
 }
 
 func (s SaleService) CreateCollectionSale(c context.Context, m model.Sale) (model.Sale, error) {
 	span, c := jtrace.T().SpanFromContext(c, "SaleService[CreateCollectionSale]")
 	defer span.Finish()
 
-	nftModel, err := s.nftService.GetOwnedNft(c, nft.Nft{ID: m.Nft.ID, CurrentOwner: &m.User})
+	var sale model.Sale
+	err := s.db.Transaction(c, func(c context.Context) error {
+		if err := s.limitService.Check(c, m.User.ID, m.MinPrice); err != nil {
+			return err
+		}
+
+		col, err := s.collectionService.GetOwnedCollection(c, collection.Collection{ID: m.Collection.ID, User: m.User})
+		if err != nil {
+			return err
+		}
+
+		// the collection is locked before its members, the order moving an
+		// nft locks them in, so no nft can join or leave it meanwhile
+		if _, err := s.collectionRepository.Lock(c, persist.D{"id": *col.ID}); err != nil {
+			return err
+		}
+
+		members, err := s.nftService.GetCollectionNfts(c, *col.ID)
+		if err != nil {
+			return err
+		}
+
+		memberIds := make([]uuid.UUID, len(members))
+		for i, member := range members {
+			memberIds[i] = *member.ID
+		}
+
+		// the members are locked in id order, so listing the collection is
+		// serialized with listing any of them separately
+		sort.Slice(memberIds, func(i, j int) bool {
+			return memberIds[i].String() < memberIds[j].String()
+		})
+		for _, id := range memberIds {
+			if _, err := s.nftRepository.Lock(c, persist.D{"id": id}); err != nil {
+				return err
+			}
+		}
+
+		listed, err := s.listed(c, persist.D{"asset_id": *col.ID})
+		if err != nil {
+			return err
+		}
+		if listed {
+			return apperrors.ErrAssetListed
+		}
+
+		listed, err = s.listed(c, persist.D{"asset_id in": memberIds})
+		if err != nil {
+			return err
+		}
+		if listed {
+			return apperrors.ErrNftListedSeparately
+		}
+
+		m.AssetType = model.AssetTypeCollection
+
+		sale, err = s.create(c, m, *col.ID)
+		return err
+	})
+	return sale, err
+}
+
+// create lists the sale of the asset and publishes it.
+func (s SaleService) create(c context.Context, m model.Sale, assetId uuid.UUID) (model.Sale, error) {
+	sale, err := s.saleRepository.Create(c, m)
 	if err != nil {
 		return model.Sale{}, err
 	}
 
-	m.User = usermodel.User{ID: nftModel.CurrentOwner.ID}
-	m.AssetType = model.AssetTypeCollection
+	if err := eventbus.Enqueue(c, s.outboxService, eventmodel.SaleCreated{
+		SaleId:     *sale.ID,
+		SellerId:   m.User.ID,
+		SaleType:   string(m.SaleType),
+		AssetType:  string(m.AssetType),
+		AssetId:    assetId,
+		MinPrice:   m.MinPrice,
+		Expiration: sale.Expiration,
+		OccurredAt: time.Now(),
+	}); err != nil {
+		return model.Sale{}, err
+	}
+	return sale, nil
+}
+
+// listed reports whether any sale matching the conditions is in progress.
+func (s SaleService) listed(c context.Context, conditions persist.D) (bool, error) {
+	conditions["status"] = model.SaleStatusInProgress
+
+	sales, err := s.saleRepository.GetAll(c, conditions)
+	if err != nil {
+		return false, err
+	}
 
-	return s.saleRepository.Create(c, m)
+	return len(sales) > 0, nil
 }
 
 func (s SaleService) CancelSale(c context.Context, m model.Sale) error {
 	span, c := jtrace.T().SpanFromContext(c, "SaleService[CancelSale]")
 	defer span.Finish()
 
-	_, err := s.saleRepository.Get(c, persist.D{"id": *m.ID, "user_id": m.User.ID})
+	return s.db.Transaction(c, func(c context.Context) error {
+		sale, err := s.saleRepository.Lock(c, persist.D{"id": *m.ID, "user_id": m.User.ID})
+		if err != nil {
+			return err
+		}
+
+		if sale.Status != model.SaleStatusInProgress {
+			return apperrors.ErrSaleClosed
+		}
+
+		if err := s.saleRepository.Cancel(c, m); err != nil {
+			return err
+		}
+
+		if err := s.voidOpenOffers(c, sale, notificationmodel.KindSaleCanceled, eventmodel.RejectReasonSaleCanceled); err != nil {
+			return err
+		}
+
+		return eventbus.Enqueue(c, s.outboxService, eventmodel.SaleCanceled{
+			SaleId:     *sale.ID,
+			SellerId:   sale.User.ID,
+			OccurredAt: time.Now(),
+		})
+	})
+}
+
+// BuySale buys a fixed price sale for the user at its list price.
+func (s SaleService) BuySale(c context.Context, m model.Sale) error {
+	span, c := jtrace.T().SpanFromContext(c, "SaleService[BuySale]")
+	defer span.Finish()
+
+	return s.offerService.Buy(c, offermodel.Offer{SaleId: *m.ID, User: m.User})
+}
+
+// ExpireSales marks sales that passed their expiration as expired and voids
+// their open offers. Auctions are left to the auction closer since an ended
+// auction with bids has to be settled rather than expired.
+func (s SaleService) ExpireSales(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "SaleService[ExpireSales]")
+	defer span.Finish()
+
+	sales, err := s.saleRepository.GetAll(c, persist.D{
+		"status":       model.SaleStatusInProgress,
+		"canceled_at":  nil,
+		"sale_type <>": model.SaleTypeAuction,
+		"expiration <": time.Now(),
+	})
 	if err != nil {
 		return err
 	}
 
-	return s.saleRepository.Cancel(c, m)
+	for _, sale := range sales {
+		if err := s.expireSale(c, *sale.ID); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+func (s SaleService) expireSale(c context.Context, saleId uuid.UUID) error {
+	span, c := jtrace.T().SpanFromContext(c, "SaleService[expireSale]")
+	defer span.Finish()
+
+	return s.db.Transaction(c, func(c context.Context) error {
+		sale, err := s.saleRepository.Lock(c, persist.D{"id": saleId})
+		if err != nil {
+			return err
+		}
+
+		// the sale may have been settled or canceled since it was listed
+		if sale.Status != model.SaleStatusInProgress || time.Now().Before(sale.Expiration) {
+			return nil
+		}
+
+		if err := s.saleRepository.UpdateStatus(c, model.Sale{ID: sale.ID, Status: model.SaleStatusExpired}); err != nil {
+			return err
+		}
+
+		if err := s.notificationService.Notify(c, notificationmodel.Notification{
+			UserId: sale.User.ID,
+			Kind:   notificationmodel.KindSaleExpired,
+			SaleId: sale.ID,
+		}); err != nil {
+			return err
+		}
+
+		if err := eventbus.Enqueue(c, s.outboxService, eventmodel.SaleExpired{
+			SaleId:     *sale.ID,
+			SellerId:   sale.User.ID,
+			OccurredAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		return s.voidOpenOffers(c, sale, notificationmodel.KindSaleExpired, eventmodel.RejectReasonSaleExpired)
+	})
+}
+
+// voidOpenOffers rejects the open offers on the sale, releases their holds,
+// notifies their buyers of why with the kind and publishes the reason.
+func (s SaleService) voidOpenOffers(c context.Context, sale model.Sale, kind notificationmodel.Kind, reason eventmodel.RejectReason) error {
+	offers, err := s.offerRepository.GetAll(c, persist.D{"sale_id": *sale.ID, "accepted": false, "rejected_at": nil})
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if err := s.offerRepository.Reject(c, offer); err != nil {
+			return err
+		}
+		if err := s.ledgerService.ReleaseHold(c, *offer.ID); err != nil {
+			return err
+		}
+		if err := s.notificationService.Notify(c, notificationmodel.Notification{
+			UserId: offer.User.ID,
+			Kind:   kind,
+			SaleId: sale.ID,
+		}); err != nil {
+			return err
+		}
+		if err := eventbus.Enqueue(c, s.outboxService, eventmodel.OfferRejected{
+			OfferId:    *offer.ID,
+			SaleId:     *sale.ID,
+			SellerId:   sale.User.ID,
+			BuyerId:    offer.User.ID,
+			Price:      offer.Price,
+			Reason:     reason,
+			OccurredAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s SaleService) GetSalesList(c context.Context, userId uuid.UUID) ([]model.Sale, error) {
@@ -107,19 +395,15 @@ func (s SaleService) GetSalesList(c context.Context, userId uuid.UUID) ([]model.
 			sales[i].Collection = &collectionModel
 		}
 
-		offer, err := s.offerRepository.Get(c, persist.D{"sale_id": *sales[i].ID, "accepted": true})
-		if err != nil {
-			if errors.Is(err, apperrors.ErrOfferNotFound) {
-				sales[i].Status = model.SaleStatusInProgress
-			} else {
-				return nil, err
-			}
+		if sales[i].Status != model.SaleStatusSold {
+			continue
 		}
 
-		if offer.Accepted {
-			sales[i].Status = model.SaleStatusSold
-			sales[i].AcceptedOffer = &offer
+		offer, err := s.offerRepository.Get(c, persist.D{"sale_id": *sales[i].ID, "accepted": true})
+		if err != nil {
+			return nil, err
 		}
+		sales[i].AcceptedOffer = &offer
 	}
 
 	return sales, nil
@@ -149,20 +433,180 @@ func (s SaleService) GetSale(c context.Context, m model.Sale) (model.Sale, error
 		sale.Collection = &collectionModel
 	}
 
-	offer, err := s.offerRepository.Get(c, persist.D{"sale_id": *sale.ID, "deleted_at": nil, "accepted": true})
-	if err != nil {
-		log.Println(err)
-		if errors.Is(err, apperrors.ErrOfferNotFound) {
-			sale.Status = model.SaleStatusInProgress
-		} else {
+	// a sold sale shows the fee the ledger charged at settlement, which
+	// later changes of the policy or of the seller's tier don't touch
+	if sale.Status == model.SaleStatusSold {
+		offer, err := s.offerRepository.Get(c, persist.D{"sale_id": *sale.ID, "accepted": true})
+		if err != nil {
+			return model.Sale{}, err
+		}
+		sale.AcceptedOffer = &offer
+
+		settled, err := s.transactionRepository.Get(c, persist.D{"sale_id": *sale.ID, "offer_id": *offer.ID})
+		if err != nil {
 			return model.Sale{}, err
 		}
+
+		fee, err := s.ledgerService.GetSettledFee(c, *settled.ID)
+		if err != nil {
+			return model.Sale{}, err
+		}
+		sale.Fee = fee.Float()
+		return sale, nil
 	}
 
-	if offer.Accepted {
-		sale.Status = model.SaleStatusSold
-		sale.AcceptedOffer = &offer
+	// until then it is quoted on the min price
+	sale.Fee, err = s.feeService.Quote(c, feemodel.Quote{
+		SaleType:  string(sale.SaleType),
+		AssetType: string(sale.AssetType),
+		Price:     sale.MinPrice,
+		SellerId:  sale.User.ID,
+	})
+	if err != nil {
+		return model.Sale{}, err
 	}
 
 	return sale, nil
 }
+
+const (
+	defaultMarketLimit = 20
+	maxMarketLimit     = 100
+)
+
+// GetMarket lists the sales that are open for offers, one page at a time.
+// Pages are keyset paginated on the sort column and the sale id, so sales
+// listed while a client is paging do not shift the pages it has not read.
+func (s SaleService) GetMarket(c context.Context, query model.MarketQuery) (model.MarketPage, error) {
+	span, c := jtrace.T().SpanFromContext(c, "SaleService[GetMarket]")
+	defer span.Finish()
+
+	if query.Sort == "" {
+		query.Sort = model.MarketSortNewest
+	}
+	order, ok := marketOrders[query.Sort]
+	if !ok {
+		return model.MarketPage{}, apperrors.ErrInvalidMarketSort
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultMarketLimit
+	}
+	if query.Limit > maxMarketLimit {
+		query.Limit = maxMarketLimit
+	}
+
+	conditions := persist.D{
+		"status":       model.SaleStatusInProgress,
+		"canceled_at":  nil,
+		"expiration >": time.Now(),
+	}
+	if query.AssetType != "" {
+		conditions["asset_type"] = query.AssetType
+	}
+	if query.SaleType != "" {
+		conditions["sale_type"] = query.SaleType
+	}
+	if query.MinPrice != nil {
+		conditions["min_price >="] = *query.MinPrice
+	}
+	if query.MaxPrice != nil {
+		conditions["min_price <="] = *query.MaxPrice
+	}
+
+	if query.Cursor != "" {
+		after, err := decodeMarketCursor(query.Sort, query.Cursor)
+		if err != nil {
+			return model.MarketPage{}, err
+		}
+		conditions[order.after()] = after
+	}
+
+	if query.CategoryId != nil {
+		assetIds, err := s.marketAssetIds(c, *query.CategoryId, query.AssetType)
+		if err != nil {
+			return model.MarketPage{}, err
+		}
+		if len(assetIds) == 0 {
+			return model.MarketPage{Sales: []model.Sale{}}, nil
+		}
+		conditions["asset_id in"] = assetIds
+	}
+
+	// one extra row tells whether there is a next page
+	sales, err := s.saleRepository.Find(c, persist.Query{
+		Conditions: conditions,
+		Order:      order.clause(),
+		Limit:      query.Limit + 1,
+	})
+	if err != nil {
+		return model.MarketPage{}, err
+	}
+
+	page := model.MarketPage{Sales: sales}
+	if len(sales) > query.Limit {
+		page.Sales = sales[:query.Limit]
+		page.NextCursor = encodeMarketCursor(query.Sort, page.Sales[query.Limit-1])
+	}
+
+	for i := range page.Sales {
+		switch page.Sales[i].AssetType {
+		case model.AssetTypeNft:
+			nftModel, err := s.nftService.GetNft(c, nft.Nft{ID: page.Sales[i].Nft.ID})
+			if err != nil {
+				return model.MarketPage{}, err
+			}
+			page.Sales[i].Nft = &nftModel
+		case model.AssetTypeCollection:
+			collectionModel, err := s.collectionService.GetCollection(c, collection.Collection{ID: page.Sales[i].Collection.ID})
+			if err != nil {
+				return model.MarketPage{}, err
+			}
+			page.Sales[i].Collection = &collectionModel
+		}
+	}
+
+	return page, nil
+}
+
+// marketAssetIds returns the ids of the nfts and collections that belong to
+// the category or to any of its subcategories.
+func (s SaleService) marketAssetIds(c context.Context, categoryId uuid.UUID, assetType model.AssetType) ([]uuid.UUID, error) {
+	subCategories, err := s.categoryService.GetSubCategories(c, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	categoryIds := append([]uuid.UUID{categoryId}, flattenCategoryIds(subCategories)...)
+
+	var assetIds []uuid.UUID
+	if assetType != model.AssetTypeCollection {
+		nfts, err := s.nftService.QueryNfts(c, nft.QueryNft{CategoryIds: categoryIds})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range nfts {
+			assetIds = append(assetIds, *n.ID)
+		}
+	}
+
+	if assetType != model.AssetTypeNft {
+		collections, err := s.collectionService.GetAllCollections(c, collection.QueryCollection{CategoryIds: categoryIds})
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range collections {
+			assetIds = append(assetIds, *col.ID)
+		}
+	}
+
+	return assetIds, nil
+}
+
+func flattenCategoryIds(categories []catmodel.Category) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+		ids = append(ids, flattenCategoryIds(category.SubCategories)...)
+	}
+	return ids
+}
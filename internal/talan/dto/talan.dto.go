@@ -20,3 +20,13 @@ type TransactionDto struct {
 	Confirmations int64   `json:"confirmations"`
 	Type          string  `json:"type"`
 }
+
+type SendRequestDto struct {
+	To         string  `json:"to"`
+	Amount     float64 `json:"amount"`
+	PrivateKey string  `json:"privateKey"`
+}
+
+type SentDto struct {
+	TxId string `json:"txId"`
+}
@@ -27,3 +27,11 @@ type Address struct {
 	PublicAddress string
 	PrivateKey    string
 }
+
+// Payment sends an amount from a wallet the platform holds the key of to
+// any Talan address.
+type Payment struct {
+	From   Address
+	To     string
+	Amount float64
+}
@@ -18,8 +18,7 @@ func mapTransactionDtoToModel(transactions []dto.TransactionDto) []model.Transac
 
 	for _, tx := range transactions {
 
-		var txType model.TransactionType
-
+		txType := model.TransactionTypeReceive
 		if tx.Type == "send" {
 			txType = model.TransactionTypeSend
 		}
@@ -36,3 +35,11 @@ func mapTransactionDtoToModel(transactions []dto.TransactionDto) []model.Transac
 
 	return txs
 }
+
+func mapPaymentModelToDto(m model.Payment) dto.SendRequestDto {
+	return dto.SendRequestDto{
+		To:         m.To,
+		Amount:     m.Amount,
+		PrivateKey: m.From.PrivateKey,
+	}
+}
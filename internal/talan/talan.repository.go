@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/fx"
 	"net/url"
@@ -20,7 +21,7 @@ var (
 )
 
 type TalanRepository struct {
-	restyClient *resty.Request
+	restyClient *resty.Client
 }
 
 type TalanRepositoryParams struct {
@@ -34,7 +35,13 @@ func NewTalanRepository(params TalanRepositoryParams) contract.ITalanRepository
 		config.C().Talan.Address); err != nil {
 		return nil
 	}
-	return &TalanRepository{restyClient: resty.New().R().EnableTrace().SetResult(dto.Response{})}
+	return &TalanRepository{restyClient: resty.New()}
+}
+
+// request starts a request of its own for each call, as the deposit watcher
+// calls Talan alongside the API handlers.
+func (t TalanRepository) request() *resty.Request {
+	return t.restyClient.R().EnableTrace().SetResult(dto.Response{})
 }
 
 func (t TalanRepository) GenerateAddress(c context.Context) (*model.Address, error) {
@@ -46,7 +53,7 @@ func (t TalanRepository) GenerateAddress(c context.Context) (*model.Address, err
 		return nil, err
 	}
 
-	response, err := t.restyClient.Get(path)
+	response, err := t.request().Get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +84,7 @@ func (t TalanRepository) GetBalance(c context.Context, address string) (float64,
 		return 0, err
 	}
 
-	response, err := t.restyClient.Get(path)
+	response, err := t.request().Get(path)
 	if err != nil {
 		return 0, err
 	}
@@ -108,7 +115,7 @@ func (t TalanRepository) GetTransactions(c context.Context, address string) ([]m
 		return nil, err
 	}
 
-	response, err := t.restyClient.Get(path)
+	response, err := t.request().Get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -132,3 +139,44 @@ func (t TalanRepository) GetTransactions(c context.Context, address string) ([]m
 
 	return mapTransactionDtoToModel(transactions), nil
 }
+
+// Send broadcasts a payment and returns the id of its transaction. Errors
+// Talan answers with wrap ErrTalanRejected, as the payment was surely not
+// sent; on any other error it may have been.
+func (t TalanRepository) Send(c context.Context, m model.Payment) (string, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TalanRepository[Send]")
+	defer span.Finish()
+
+	path, err := url.JoinPath(addressUrl, m.From.PublicAddress, config.C().Talan.Send)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := t.request().SetBody(mapPaymentModelToDto(m)).Post(path)
+	if err != nil {
+		return "", err
+	}
+
+	talanResponse := response.Result().(*dto.Response)
+	if talanResponse.Error != "" {
+		return "", fmt.Errorf("%w: %s", apperrors.ErrTalanRejected, talanResponse.Error)
+	}
+
+	var sent dto.SentDto
+	if talanResponse.Data != nil {
+		data, err := json.Marshal(talanResponse.Data)
+		if err != nil {
+			return "", apperrors.ErrUnableToParseResult
+		}
+
+		if err = json.Unmarshal(data, &sent); err != nil {
+			return "", apperrors.ErrUnableToParseResult
+		}
+	}
+
+	if sent.TxId == "" {
+		return "", apperrors.ErrUnableToParseResult
+	}
+
+	return sent.TxId, nil
+}
@@ -16,6 +16,9 @@ type Transaction struct {
 	BuyerId         uuid.UUID `gorm:"type:uuid;"`
 	SellerId        uuid.UUID `gorm:"type:uuid;"`
 	OfferId         uuid.UUID `gorm:"type:uuid;"`
+	SettlementId    uuid.UUID `gorm:"type:uuid;index"`
+	Kind            string    `gorm:"default:sale"`
+	Price           float64
 	ContractAddress string
 	TransactionId   string
 }
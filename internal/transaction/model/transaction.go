@@ -1,13 +1,30 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type Transaction struct {
+	ID              *uuid.UUID
+	CreatedAt       time.Time
 	AssetId         uuid.UUID `gorm:"type:uuid;"`
 	SaleId          uuid.UUID `gorm:"type:uuid;"`
 	BuyerId         uuid.UUID `gorm:"type:uuid;"`
 	SellerId        uuid.UUID `gorm:"type:uuid;"`
 	OfferId         uuid.UUID `gorm:"type:uuid;"`
+	SettlementId    uuid.UUID `gorm:"type:uuid;"`
+	Kind            Kind
+	Price           float64
 	ContractAddress string
 	TransactionId   string
 }
+
+// Kind tells how the asset changed hands. A transfer is a gift from the
+// seller to the buyer and is recorded at a price of zero.
+type Kind string
+
+const (
+	KindSale     Kind = "sale"
+	KindTransfer Kind = "transfer"
+)
@@ -7,12 +7,32 @@ import (
 
 func mapTransactionEntityToModel(e entity.Transaction) model.Transaction {
 	return model.Transaction{
+		ID:              &e.ID,
+		CreatedAt:       e.CreatedAt,
 		AssetId:         e.AssetId,
 		SaleId:          e.SaleId,
 		BuyerId:         e.BuyerId,
 		SellerId:        e.SellerId,
 		OfferId:         e.OfferId,
+		SettlementId:    e.SettlementId,
+		Kind:            model.Kind(e.Kind),
+		Price:           e.Price,
 		ContractAddress: e.ContractAddress,
 		TransactionId:   e.TransactionId,
 	}
 }
+
+func mapTransactionModelToEntity(m model.Transaction) entity.Transaction {
+	return entity.Transaction{
+		AssetId:         m.AssetId,
+		SaleId:          m.SaleId,
+		BuyerId:         m.BuyerId,
+		SellerId:        m.SellerId,
+		OfferId:         m.OfferId,
+		SettlementId:    m.SettlementId,
+		Kind:            string(m.Kind),
+		Price:           m.Price,
+		ContractAddress: m.ContractAddress,
+		TransactionId:   m.TransactionId,
+	}
+}
@@ -2,8 +2,11 @@ package transaction
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"nft/contract"
+	apperrors "nft/error"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	entity "nft/internal/transaction/entity"
@@ -31,8 +34,72 @@ func (t TransactionRepository) Get(c context.Context, conditions persist.D) (mod
 
 	tx, err := t.db.Get(c, &entity.Transaction{}, conditions)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Transaction{}, apperrors.ErrTransactionNotFound
+		}
 		return model.Transaction{}, err
 	}
 
 	return mapTransactionEntityToModel(*tx.(*entity.Transaction)), nil
 }
+
+func (t TransactionRepository) Last(c context.Context, conditions persist.D) (model.Transaction, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransactionRepository[Last]")
+	defer span.Finish()
+
+	tx, err := t.db.Last(c, &entity.Transaction{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Transaction{}, apperrors.ErrTransactionNotFound
+		}
+		return model.Transaction{}, err
+	}
+
+	return mapTransactionEntityToModel(*tx.(*entity.Transaction)), nil
+}
+
+func (t TransactionRepository) Add(c context.Context, m model.Transaction) (model.Transaction, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransactionRepository[Add]")
+	defer span.Finish()
+
+	txEntity := mapTransactionModelToEntity(m)
+	txEntity.ID = uuid.New()
+
+	createdTx, err := t.db.Create(c, &txEntity)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+
+	return mapTransactionEntityToModel(*createdTx.(*entity.Transaction)), nil
+}
+
+func (t TransactionRepository) GetAll(c context.Context, conditions persist.D) ([]model.Transaction, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransactionRepository[GetAll]")
+	defer span.Finish()
+
+	txList, err := t.db.GetAll(c, &[]entity.Transaction{}, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]model.Transaction, len(*txList.(*[]entity.Transaction)))
+	for i, tx := range *txList.(*[]entity.Transaction) {
+		transactions[i] = mapTransactionEntityToModel(tx)
+	}
+
+	return transactions, nil
+}
+
+// UpdateChain records the on-chain transfer that settled the transaction.
+func (t TransactionRepository) UpdateChain(c context.Context, m model.Transaction) error {
+	span, c := jtrace.T().SpanFromContext(c, "TransactionRepository[UpdateChain]")
+	defer span.Finish()
+
+	if _, err := t.db.Update(c, &entity.Transaction{ID: *m.ID}, persist.D{
+		"contract_address": m.ContractAddress,
+		"transaction_id":   m.TransactionId,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
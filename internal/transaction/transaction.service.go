@@ -2,9 +2,11 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"nft/contract"
+	apperrors "nft/error"
 	"nft/infra/jtrace"
 	"nft/infra/persist/type"
 	model "nft/internal/transaction/model"
@@ -28,5 +30,22 @@ func NewTransactionService(params TransactionServiceParams) contract.ITransactio
 func (t TransactionService) GetLastTransaction(c context.Context, AssetId uuid.UUID) (model.Transaction, error) {
 	span, c := jtrace.T().SpanFromContext(c, "TransactionService[GetLastTransaction]")
 	defer span.Finish()
-	return t.saleRepository.Get(c, persist.D{"asset_id": AssetId})
+	return t.saleRepository.Last(c, persist.D{"asset_id": AssetId})
+}
+
+// GetOwner returns the buyer of the last transaction of the asset, or its
+// creator when it never changed hands.
+func (t TransactionService) GetOwner(c context.Context, assetId uuid.UUID, creatorId uuid.UUID) (uuid.UUID, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransactionService[GetOwner]")
+	defer span.Finish()
+
+	tx, err := t.GetLastTransaction(c, assetId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTransactionNotFound) {
+			return creatorId, nil
+		}
+		return uuid.Nil, err
+	}
+
+	return tx.BuyerId, nil
 }
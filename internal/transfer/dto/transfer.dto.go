@@ -0,0 +1,22 @@
+package dto
+
+type TransferRequest struct {
+	RecipientId       string `json:"recipient_id" validate:"required_without=Email,omitempty,uuid"`
+	Email             string `json:"email" validate:"required_without=RecipientId,omitempty,email"`
+	RequireAcceptance bool   `json:"require_acceptance"`
+	Note              string `json:"note"`
+}
+
+type Transfer struct {
+	ID          string `json:"id"`
+	NftId       string `json:"nft_id"`
+	SenderId    string `json:"sender_id"`
+	RecipientId string `json:"recipient_id"`
+	Status      string `json:"status"`
+	Note        string `json:"note,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+type TransferList struct {
+	Transfers []Transfer `json:"transfers"`
+}
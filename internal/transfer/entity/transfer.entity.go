@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Transfer struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	NftId         uuid.UUID `gorm:"type:uuid;index"`
+	SenderId      uuid.UUID `gorm:"type:uuid;index"`
+	RecipientId   uuid.UUID `gorm:"type:uuid;index"`
+	Status        string    `gorm:"index"`
+	Note          *sql.NullString
+	TransactionId *uuid.UUID `gorm:"type:uuid"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	usermodel "nft/internal/user/model"
+	"time"
+)
+
+// Transfer hands an nft to another user without a sale. A transfer that
+// waits for the recipient to accept stays pending until then.
+type Transfer struct {
+	ID            *uuid.UUID
+	CreatedAt     time.Time
+	NftId         uuid.UUID
+	Sender        usermodel.User
+	Recipient     usermodel.User
+	Status        Status
+	Note          string
+	TransactionId *uuid.UUID
+}
+
+// Recipient names who an nft is transferred to, by user id or by one of
+// their verified emails.
+type Recipient struct {
+	UserId uuid.UUID
+	Email  string
+}
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusDeclined Status = "declined"
+	StatusCanceled Status = "canceled"
+)
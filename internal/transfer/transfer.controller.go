@@ -0,0 +1,201 @@
+package transfer
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/transfer/dto"
+	"nft/internal/transfer/model"
+	usermodel "nft/internal/user/model"
+	"nft/pkg/filper"
+	"nft/pkg/validator"
+)
+
+type TransferController struct {
+	transferService contract.ITransferService
+}
+
+type TransferControllerParams struct {
+	fx.In
+	TransferService contract.ITransferService
+}
+
+func NewTransferController(params TransferControllerParams) contract.ITransferController {
+	return &TransferController{
+		transferService: params.TransferService,
+	}
+}
+
+// Transfer godoc
+// @Summary  transfer or gift nft to another user without a sale
+// @Tags     transfer
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string               true  "nft id"
+// @Param    message  body      dto.TransferRequest  true  "recipient by user id or verified email"
+// @Success  201      {object}  dto.Transfer
+// @Router   /v1/nft/{id}/transfer [post]
+func (t TransferController) Transfer(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "TransferController[Transfer]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	nftId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidNftId.Error())
+	}
+
+	var request dto.TransferRequest
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	recipient, note := mapTransferRequestToModel(request)
+	transfer, err := t.transferService.Transfer(
+		ctx,
+		model.Transfer{NftId: nftId, Sender: usermodel.User{ID: userId}, Note: note},
+		recipient,
+		request.RequireAcceptance,
+	)
+	if err != nil {
+		return transferError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(mapTransferModelToDto(transfer))
+}
+
+// GetIncoming godoc
+// @Summary  get transfers waiting for you to accept them
+// @Tags     transfer
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  dto.TransferList
+// @Router   /v1/transfer/incoming [get]
+func (t TransferController) GetIncoming(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "TransferController[GetIncoming]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	transfers, err := t.transferService.GetIncoming(ctx, userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createTransferListDtoFromModel(transfers))
+}
+
+// Accept godoc
+// @Summary  accept a transfer sent to you
+// @Tags     transfer
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "transfer id"
+// @Success  200  {string}  string  "transfer accepted successfully"
+// @Router   /v1/transfer/{id}/accept [post]
+func (t TransferController) Accept(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "TransferController[Accept]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	transferId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidTransferId.Error())
+	}
+
+	if err := t.transferService.Accept(ctx, model.Transfer{ID: &transferId, Recipient: usermodel.User{ID: userId}}); err != nil {
+		return transferError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "transfer accepted successfully")
+}
+
+// Decline godoc
+// @Summary  decline a transfer sent to you
+// @Tags     transfer
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "transfer id"
+// @Success  200  {string}  string  "transfer declined successfully"
+// @Router   /v1/transfer/{id}/decline [post]
+func (t TransferController) Decline(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "TransferController[Decline]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	transferId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidTransferId.Error())
+	}
+
+	if err := t.transferService.Decline(ctx, model.Transfer{ID: &transferId, Recipient: usermodel.User{ID: userId}}); err != nil {
+		return transferError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "transfer declined successfully")
+}
+
+// Cancel godoc
+// @Summary  cancel a pending transfer you sent
+// @Tags     transfer
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "transfer id"
+// @Success  200  {string}  string  "transfer canceled successfully"
+// @Router   /v1/transfer/{id}/cancel [post]
+func (t TransferController) Cancel(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "TransferController[Cancel]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	transferId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidTransferId.Error())
+	}
+
+	if err := t.transferService.Cancel(ctx, model.Transfer{ID: &transferId, Sender: usermodel.User{ID: userId}}); err != nil {
+		return transferError(c, err)
+	}
+
+	return filper.GetSuccessResponse(c, "transfer canceled successfully")
+}
+
+// transferError maps the errors of a transfer to a response.
+func transferError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, apperrors.ErrNftNotFound) || errors.Is(err, apperrors.ErrTransferNotFound) ||
+		errors.Is(err, apperrors.ErrRecipientNotFound) {
+		return filper.GetNotFoundError(c, err.Error())
+	} else if errors.Is(err, apperrors.ErrTransferToSelf) || errors.Is(err, apperrors.ErrTransferPending) ||
+		errors.Is(err, apperrors.ErrTransferClosed) || errors.Is(err, apperrors.ErrNftOnSale) {
+		return filper.GetBadRequestError(c, err.Error())
+	}
+	return filper.GetInternalError(c, "")
+}
@@ -0,0 +1,82 @@
+package transfer
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"nft/internal/transfer/dto"
+	"nft/internal/transfer/entity"
+	"nft/internal/transfer/model"
+	usermodel "nft/internal/user/model"
+)
+
+func mapTransferModelToEntity(m model.Transfer) entity.Transfer {
+	transfer := entity.Transfer{
+		NftId:         m.NftId,
+		SenderId:      m.Sender.ID,
+		RecipientId:   m.Recipient.ID,
+		Status:        string(m.Status),
+		TransactionId: m.TransactionId,
+	}
+
+	if len(m.Note) > 0 {
+		transfer.Note = &sql.NullString{String: m.Note, Valid: true}
+	}
+
+	return transfer
+}
+
+func mapTransferEntityToModel(e entity.Transfer) model.Transfer {
+	transfer := model.Transfer{
+		ID:            &e.ID,
+		CreatedAt:     e.CreatedAt,
+		NftId:         e.NftId,
+		Sender:        usermodel.User{ID: e.SenderId},
+		Recipient:     usermodel.User{ID: e.RecipientId},
+		Status:        model.Status(e.Status),
+		TransactionId: e.TransactionId,
+	}
+
+	if e.Note != nil {
+		transfer.Note = e.Note.String
+	}
+
+	return transfer
+}
+
+func createModelTransferListFromEntity(transfers []entity.Transfer) []model.Transfer {
+	transferList := make([]model.Transfer, len(transfers))
+	for i := range transfers {
+		transferList[i] = mapTransferEntityToModel(transfers[i])
+	}
+	return transferList
+}
+
+func mapTransferRequestToModel(request dto.TransferRequest) (model.Recipient, string) {
+	var recipient model.Recipient
+	if len(request.RecipientId) > 0 {
+		recipient.UserId = uuid.MustParse(request.RecipientId)
+	} else {
+		recipient.Email = request.Email
+	}
+	return recipient, request.Note
+}
+
+func mapTransferModelToDto(m model.Transfer) dto.Transfer {
+	return dto.Transfer{
+		ID:          m.ID.String(),
+		NftId:       m.NftId.String(),
+		SenderId:    m.Sender.ID.String(),
+		RecipientId: m.Recipient.ID.String(),
+		Status:      string(m.Status),
+		Note:        m.Note,
+		CreatedAt:   m.CreatedAt.Unix(),
+	}
+}
+
+func createTransferListDtoFromModel(transfers []model.Transfer) dto.TransferList {
+	transferList := make([]dto.Transfer, len(transfers))
+	for i := range transfers {
+		transferList[i] = mapTransferModelToDto(transfers[i])
+	}
+	return dto.TransferList{Transfers: transferList}
+}
@@ -0,0 +1,9 @@
+package transfer
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewTransferController),
+	fx.Provide(NewTransferService),
+	fx.Provide(NewTransferRepository),
+)
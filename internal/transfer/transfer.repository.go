@@ -0,0 +1,82 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/transfer/entity"
+	"nft/internal/transfer/model"
+)
+
+type TransferRepository struct {
+	db contract.IPersist
+}
+
+type TransferRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewTransferRepository(params TransferRepositoryParams) contract.ITransferRepository {
+	return &TransferRepository{
+		db: params.DB,
+	}
+}
+
+func (t TransferRepository) Add(c context.Context, m model.Transfer) (model.Transfer, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransferRepository[Add]")
+	defer span.Finish()
+
+	transferEntity := mapTransferModelToEntity(m)
+	transferEntity.ID = uuid.New()
+
+	created, err := t.db.Create(c, &transferEntity)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+
+	return mapTransferEntityToModel(*created.(*entity.Transfer)), nil
+}
+
+func (t TransferRepository) Lock(c context.Context, conditions persist.D) (model.Transfer, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransferRepository[Lock]")
+	defer span.Finish()
+
+	transfer, err := t.db.Lock(c, &entity.Transfer{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Transfer{}, apperrors.ErrTransferNotFound
+		}
+		return model.Transfer{}, err
+	}
+
+	return mapTransferEntityToModel(*transfer.(*entity.Transfer)), nil
+}
+
+func (t TransferRepository) Find(c context.Context, query persist.Query) ([]model.Transfer, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransferRepository[Find]")
+	defer span.Finish()
+
+	transfers, err := t.db.Find(c, &[]entity.Transfer{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelTransferListFromEntity(*transfers.(*[]entity.Transfer)), nil
+}
+
+func (t TransferRepository) UpdateStatus(c context.Context, m model.Transfer) error {
+	span, c := jtrace.T().SpanFromContext(c, "TransferRepository[UpdateStatus]")
+	defer span.Finish()
+
+	data := persist.D{"status": m.Status, "transaction_id": m.TransactionId}
+	if _, err := t.db.Update(c, &entity.Transfer{ID: *m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
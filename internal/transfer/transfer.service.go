@@ -0,0 +1,265 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	nftmodel "nft/internal/nft/model"
+	salemodel "nft/internal/sale/model"
+	txmodel "nft/internal/transaction/model"
+	"nft/internal/transfer/model"
+	usermodel "nft/internal/user/model"
+)
+
+type TransferService struct {
+	db                    contract.IPersist
+	transferRepository    contract.ITransferRepository
+	nftService            contract.INftService
+	nftRepository         contract.INftRepository
+	saleRepository        contract.ISaleRepository
+	transactionRepository contract.ITransactionRepository
+	userRepository        contract.IUserRepository
+	emailService          contract.IEmailService
+	chainService          contract.IChainService
+}
+
+type TransferServiceParams struct {
+	fx.In
+	DB                    contract.IPersist
+	TransferRepository    contract.ITransferRepository
+	NftService            contract.INftService
+	NftRepository         contract.INftRepository
+	SaleRepository        contract.ISaleRepository
+	TransactionRepository contract.ITransactionRepository
+	UserRepository        contract.IUserRepository
+	EmailService          contract.IEmailService
+	ChainService          contract.IChainService
+}
+
+func NewTransferService(params TransferServiceParams) contract.ITransferService {
+	return &TransferService{
+		db:                    params.DB,
+		transferRepository:    params.TransferRepository,
+		nftService:            params.NftService,
+		nftRepository:         params.NftRepository,
+		saleRepository:        params.SaleRepository,
+		transactionRepository: params.TransactionRepository,
+		userRepository:        params.UserRepository,
+		emailService:          params.EmailService,
+		chainService:          params.ChainService,
+	}
+}
+
+// Transfer hands the nft of the sender to the recipient. Unless the
+// recipient has to accept it first, the nft changes hands right away.
+func (t TransferService) Transfer(c context.Context, m model.Transfer, recipient model.Recipient, requireAcceptance bool) (model.Transfer, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransferService[Transfer]")
+	defer span.Finish()
+
+	recipientId, err := t.resolveRecipient(c, recipient)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+	if recipientId == m.Sender.ID {
+		return model.Transfer{}, apperrors.ErrTransferToSelf
+	}
+	m.Recipient = usermodel.User{ID: recipientId}
+
+	var transfer model.Transfer
+	err = t.db.Transaction(c, func(c context.Context) error {
+		nft, err := t.lockTransferable(c, m.NftId, m.Sender.ID)
+		if err != nil {
+			return err
+		}
+
+		pending, err := t.transferRepository.Find(c, persist.Query{
+			Conditions: persist.D{"nft_id": m.NftId, "status": model.StatusPending},
+			Limit:      1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return apperrors.ErrTransferPending
+		}
+
+		m.Status = model.StatusPending
+		transfer, err = t.transferRepository.Add(c, m)
+		if err != nil {
+			return err
+		}
+
+		if requireAcceptance {
+			return nil
+		}
+		return t.complete(c, &transfer, nft)
+	})
+	if err != nil {
+		return model.Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+// Accept completes a pending transfer for its recipient. The sender must
+// still own the nft and it must not have been listed since.
+func (t TransferService) Accept(c context.Context, m model.Transfer) error {
+	span, c := jtrace.T().SpanFromContext(c, "TransferService[Accept]")
+	defer span.Finish()
+
+	return t.db.Transaction(c, func(c context.Context) error {
+		transfer, err := t.lockPending(c, persist.D{"id": *m.ID, "recipient_id": m.Recipient.ID})
+		if err != nil {
+			return err
+		}
+
+		nft, err := t.lockTransferable(c, transfer.NftId, transfer.Sender.ID)
+		if err != nil {
+			return err
+		}
+
+		return t.complete(c, &transfer, nft)
+	})
+}
+
+// Decline lets the recipient refuse a pending transfer.
+func (t TransferService) Decline(c context.Context, m model.Transfer) error {
+	span, c := jtrace.T().SpanFromContext(c, "TransferService[Decline]")
+	defer span.Finish()
+
+	return t.close(c, persist.D{"id": *m.ID, "recipient_id": m.Recipient.ID}, model.StatusDeclined)
+}
+
+// Cancel lets the sender withdraw a pending transfer.
+func (t TransferService) Cancel(c context.Context, m model.Transfer) error {
+	span, c := jtrace.T().SpanFromContext(c, "TransferService[Cancel]")
+	defer span.Finish()
+
+	return t.close(c, persist.D{"id": *m.ID, "sender_id": m.Sender.ID}, model.StatusCanceled)
+}
+
+// GetIncoming returns the transfers waiting for the user to accept them.
+func (t TransferService) GetIncoming(c context.Context, userId uuid.UUID) ([]model.Transfer, error) {
+	span, c := jtrace.T().SpanFromContext(c, "TransferService[GetIncoming]")
+	defer span.Finish()
+
+	return t.transferRepository.Find(c, persist.Query{
+		Conditions: persist.D{"recipient_id": userId, "status": model.StatusPending},
+		Order:      "created_at desc",
+	})
+}
+
+func (t TransferService) close(c context.Context, conditions persist.D, status model.Status) error {
+	return t.db.Transaction(c, func(c context.Context) error {
+		transfer, err := t.lockPending(c, conditions)
+		if err != nil {
+			return err
+		}
+
+		transfer.Status = status
+		return t.transferRepository.UpdateStatus(c, transfer)
+	})
+}
+
+func (t TransferService) lockPending(c context.Context, conditions persist.D) (model.Transfer, error) {
+	transfer, err := t.transferRepository.Lock(c, conditions)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+
+	if transfer.Status != model.StatusPending {
+		return model.Transfer{}, apperrors.ErrTransferClosed
+	}
+
+	return transfer, nil
+}
+
+// lockTransferable locks the nft of the owner and makes sure it isn't listed
+// for sale, on its own or with its collection.
+func (t TransferService) lockTransferable(c context.Context, nftId uuid.UUID, ownerId uuid.UUID) (nftmodel.Nft, error) {
+	if _, err := t.nftRepository.Lock(c, persist.D{"id": nftId}); err != nil {
+		return nftmodel.Nft{}, err
+	}
+
+	nft, err := t.nftService.GetOwnedNft(c, nftmodel.Nft{ID: &nftId, CurrentOwner: &usermodel.User{ID: ownerId}})
+	if err != nil {
+		return nftmodel.Nft{}, err
+	}
+
+	assetIds := []uuid.UUID{nftId}
+	if nft.CollectionId != nil {
+		assetIds = append(assetIds, *nft.CollectionId)
+	}
+
+	sales, err := t.saleRepository.GetAll(c, persist.D{"asset_id in": assetIds, "status": salemodel.SaleStatusInProgress})
+	if err != nil {
+		return nftmodel.Nft{}, err
+	}
+	if len(sales) > 0 {
+		return nftmodel.Nft{}, apperrors.ErrNftOnSale
+	}
+
+	return nft, nil
+}
+
+// complete moves the nft to the recipient with a zero price transaction. The
+// nft leaves the sender's collection on the way.
+func (t TransferService) complete(c context.Context, transfer *model.Transfer, nft nftmodel.Nft) error {
+	tx, err := t.transactionRepository.Add(c, txmodel.Transaction{
+		AssetId:      transfer.NftId,
+		BuyerId:      transfer.Recipient.ID,
+		SellerId:     transfer.Sender.ID,
+		SettlementId: uuid.New(),
+		Kind:         txmodel.KindTransfer,
+	})
+	if err != nil {
+		return err
+	}
+
+	if nft.CollectionId != nil {
+		if err := t.nftRepository.UpdateCollection(c, nftmodel.Nft{ID: nft.ID}); err != nil {
+			return err
+		}
+	}
+
+	if err := t.chainService.QueueTransfer(c, tx); err != nil {
+		return err
+	}
+
+	transfer.Status = model.StatusAccepted
+	transfer.TransactionId = tx.ID
+	return t.transferRepository.UpdateStatus(c, *transfer)
+}
+
+// resolveRecipient finds the user a transfer goes to. An email has to be
+// verified to identify its user.
+func (t TransferService) resolveRecipient(c context.Context, recipient model.Recipient) (uuid.UUID, error) {
+	if recipient.UserId != uuid.Nil {
+		exists, err := t.userRepository.Exists(c, persist.D{"id": recipient.UserId})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if !exists {
+			return uuid.Nil, apperrors.ErrRecipientNotFound
+		}
+		return recipient.UserId, nil
+	}
+
+	email, err := t.emailService.GetEmail(c, recipient.Email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrEmailNotFound) {
+			return uuid.Nil, apperrors.ErrRecipientNotFound
+		}
+		return uuid.Nil, err
+	}
+	if !email.Verified {
+		return uuid.Nil, apperrors.ErrRecipientNotFound
+	}
+
+	return email.UserId, nil
+}
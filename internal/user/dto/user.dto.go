@@ -16,4 +16,9 @@ type User struct {
 	City           string `json:"city,omitempty"`
 	Address        string `json:"address,omitempty"`
 	PublicKey      string `json:"public_key,omitempty"`
+	Role           string `json:"role,omitempty"`
+}
+
+type SetRole struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 )
 
+// User keeps the wallet secrets in PrivateKey and Mnemonic sealed by the
+// vault, never in plaintext.
 type User struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
 	CreatedAt time.Time
@@ -24,4 +26,5 @@ type User struct {
 	PublicKey      string
 	PrivateKey     string
 	Mnemonic       string
+	Role           string `gorm:"default:user"`
 }
@@ -0,0 +1,22 @@
+package user
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type Permission string
+
+const (
+	PermissionReviewNft         Permission = "nft:review"
+	PermissionReviewKyc         Permission = "kyc:review"
+	PermissionReviewCard        Permission = "card:review"
+	PermissionManageCategories  Permission = "category:manage"
+	PermissionManageUsers       Permission = "user:manage"
+	PermissionManageFees        Permission = "fee:manage"
+	PermissionManageWithdrawals Permission = "withdrawal:manage"
+	PermissionManageOutbox      Permission = "outbox:manage"
+)
@@ -23,6 +23,25 @@ type User struct {
 	City           string
 	Address        string
 	PublicKey      string
-	PrivateKey     string
-	Mnemonic       string
+	Role           Role
+
+	// Wallet is only set to hand the keys of a new wallet to
+	// IUserRepository.Add; users read back never carry it.
+	Wallet WalletSecrets
+}
+
+// WalletSecrets are the keys of the custodial wallet of a user. They are
+// sealed at rest, read through IUserRepository.GetWalletSecrets alone, and
+// never printed or serialized.
+type WalletSecrets struct {
+	PrivateKey string
+	Mnemonic   string
+}
+
+func (WalletSecrets) String() string {
+	return "[redacted]"
+}
+
+func (WalletSecrets) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
 }
@@ -1,10 +1,13 @@
 package user
 
 import (
+	"errors"
 	"nft/contract"
+	merror "nft/error"
 	"nft/infra/jtrace"
 	authdto "nft/internal/auth/dto"
 	user "nft/internal/user/dto"
+	model "nft/internal/user/model"
 	"nft/pkg/filper"
 	"nft/pkg/validator"
 
@@ -40,7 +43,7 @@ func NewUserController(params UserControllerParams) contract.IUserController {
 // @Accept   json
 // @Produce  json
 // @Success  200      {object}  user.UserList
-// @Router   /v1/user [get]
+// @Router   /v1/admin/user [get]
 func (u UserController) GetAllUsers(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[GetAllUsers]")
 	defer span.Finish()
@@ -61,7 +64,7 @@ func (u UserController) GetAllUsers(c *fiber.Ctx) error {
 // @Produce  json
 // @Param    id   path      int  true  "user id"
 // @Success  200  {object}  user.User
-// @Router   /v1/user/{id} [get]
+// @Router   /v1/admin/user/{id} [get]
 func (u UserController) GetUser(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[GetUser]")
 	defer span.Finish()
@@ -86,7 +89,7 @@ func (u UserController) GetUser(c *fiber.Ctx) error {
 // @Produce  json
 // @Param    message  body      authdto.SignUpRequest  true  "add user request body"
 // @Success  200      {object}  user.User
-// @Router   /v1/user [post]
+// @Router   /v1/admin/user [post]
 func (u UserController) AddUser(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[AddUser]")
 	defer span.Finish()
@@ -122,7 +125,7 @@ func (u UserController) AddUser(c *fiber.Ctx) error {
 // @Param    id       path      int                    true  "user id that will be updated"
 // @Param    message  body      authdto.SignUpRequest  true  "update user request body"
 // @Success  200  {object}  user.User
-// @Router   /v1/user/{id} [patch]
+// @Router   /v1/admin/user/{id} [patch]
 func (u UserController) UpdateUser(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[UpdateUser]")
 	defer span.Finish()
@@ -156,7 +159,7 @@ func (u UserController) UpdateUser(c *fiber.Ctx) error {
 // @Produce  json
 // @Param    id   path      int     true  "user id that will be deleted"
 // @Success  200  {string}  string  "user deleted successfully"
-// @Router   /v1/user/{id} [delete]
+// @Router   /v1/admin/user/{id} [delete]
 func (u UserController) DeleteUser(c *fiber.Ctx) error {
 	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[DeleteUser]")
 	defer span.Finish()
@@ -173,3 +176,43 @@ func (u UserController) DeleteUser(c *fiber.Ctx) error {
 
 	return filper.GetSuccessResponse(c, "user deleted successfully")
 }
+
+// SetRole godoc
+// @Summary  set role of user
+// @Tags     user
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string        true  "user id"
+// @Param    message  body      user.SetRole  true  "role request body"
+// @Success  200      {string}  string        "role updated successfully"
+// @Router   /v1/admin/user/{id}/role [put]
+func (u UserController) SetRole(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "UserController[SetRole]")
+	defer span.Finish()
+
+	userId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, "invalid user id")
+	}
+
+	var request user.SetRole
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	if err := u.userService.SetRole(ctx, userId, model.Role(request.Role)); err != nil {
+		if errors.Is(err, merror.ErrRecordNotFound) {
+			return filper.GetNotFoundError(c, "user not found")
+		} else if errors.Is(err, merror.ErrInvalidRole) {
+			return filper.GetBadRequestError(c, merror.ErrInvalidRole.Error())
+		}
+		return filper.GetInternalError(c, "")
+	}
+
+	return filper.GetSuccessResponse(c, "role updated successfully")
+}
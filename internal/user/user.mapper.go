@@ -68,10 +68,9 @@ func mapUserEntityToModel(e *entity.User) model.User {
 		City:           e.City,
 		Address:        e.Address,
 
-		Password:   e.Password,
-		PublicKey:  e.PublicKey,
-		PrivateKey: e.PrivateKey,
-		Mnemonic:   e.Mnemonic,
+		Password:  e.Password,
+		PublicKey: e.PublicKey,
+		Role:      model.Role(e.Role),
 	}
 }
 
@@ -88,6 +87,7 @@ func mapUserModelToResponse(userModel model.User) dto.User {
 		City:           userModel.City,
 		Address:        userModel.Address,
 		PublicKey:      userModel.PublicKey,
+		Role:           string(userModel.Role),
 	}
 }
 
@@ -106,6 +106,7 @@ func createUserList(users []model.User) dto.UserList {
 			City:           userModel.City,
 			Address:        userModel.Address,
 			PublicKey:      userModel.PublicKey,
+			Role:           string(userModel.Role),
 		}
 	}
 
@@ -128,6 +129,7 @@ func createUserModelList(users *[]entity.User) []model.User {
 			City:           userModel.City,
 			Address:        userModel.Address,
 			PublicKey:      userModel.PublicKey,
+			Role:           model.Role(userModel.Role),
 		})
 	}
 
@@ -145,7 +147,6 @@ func mapUserModelToEntity(userModel model.User) entity.User {
 		Province:       userModel.Province,
 		City:           userModel.City,
 		Address:        userModel.Address,
-		Mnemonic:       userModel.Mnemonic,
-		PrivateKey:     userModel.PrivateKey,
+		PublicKey:      userModel.PublicKey,
 	}
 }
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	entity "nft/internal/user/entity"
+	model "nft/internal/user/model"
+	"strings"
+	"testing"
+)
+
+func TestWalletSecretsNeverLeave(t *testing.T) {
+	const privateKey, mnemonic = "private-key-secret", "mnemonic secret words"
+
+	userModel := mapUserEntityToModel(&entity.User{PrivateKey: privateKey, Mnemonic: mnemonic})
+	if userModel.Wallet != (model.WalletSecrets{}) {
+		t.Error("mapUserEntityToModel() copied the wallet secrets")
+	}
+
+	userModel.Wallet = model.WalletSecrets{PrivateKey: privateKey, Mnemonic: mnemonic}
+
+	response, err := json.Marshal(mapUserModelToResponse(userModel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := json.Marshal(createUserList([]model.User{userModel}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, err := json.Marshal(userModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, out := range map[string]string{
+		"response":   string(response),
+		"list":       string(list),
+		"model json": string(serialized),
+		"model text": fmt.Sprintf("%+v", userModel),
+	} {
+		if strings.Contains(out, privateKey) || strings.Contains(out, mnemonic) {
+			t.Errorf("%s leaks the wallet secrets: %s", name, out)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+	"nft/contract"
+	merror "nft/error"
+	"nft/infra/jtrace"
+	model "nft/internal/user/model"
+	"nft/pkg/filper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+type RoleMiddleware struct {
+	userRepository contract.IUserRepository
+}
+
+type RoleMiddlewareParams struct {
+	fx.In
+	UserRepository contract.IUserRepository
+}
+
+func NewRoleMiddleware(params RoleMiddlewareParams) contract.IRoleMiddleware {
+	return &RoleMiddleware{
+		userRepository: params.UserRepository,
+	}
+}
+
+// Require lets the request through only if the user's role grants the
+// permission. It reads the role on every request, so a role change applies
+// to tokens already issued. It has to run after JwtMiddleware.Handle.
+func (r RoleMiddleware) Require(permission model.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		span, ctx := jtrace.T().SpanFromContext(c.Context(), "RoleMiddleware[Require]")
+		defer span.Finish()
+
+		if c.Locals("user_id") == nil {
+			return filper.GetUnAuthError(c, "no authorization token provided")
+		}
+		userId := c.Locals("user_id").(uuid.UUID)
+
+		userModel, err := r.userRepository.Get(ctx, map[string]any{"id": userId})
+		if err != nil {
+			if errors.Is(err, merror.ErrRecordNotFound) {
+				return filper.GetUnAuthError(c, "user not found")
+			}
+			return filper.GetInternalError(c, "")
+		}
+
+		if !hasPermission(userModel.Role, permission) {
+			return filper.GetForbiddenError(c, merror.ErrPermissionDenied.Error())
+		}
+
+		return c.Next()
+	}
+}
@@ -6,4 +6,5 @@ var Module = fx.Options(
 	fx.Provide(NewUserRepository),
 	fx.Provide(NewUserService),
 	fx.Provide(NewUserController),
+	fx.Provide(NewRoleMiddleware),
 )
@@ -17,17 +17,20 @@ import (
 )
 
 type UserRepository struct {
-	db contract.IPersist
+	db    contract.IPersist
+	vault contract.IVault
 }
 
 type UserRepositoryParams struct {
 	fx.In
-	DB contract.IPersist
+	DB    contract.IPersist
+	Vault contract.IVault
 }
 
 func NewUserRepository(params UserRepositoryParams) contract.IUserRepository {
 	return &UserRepository{
-		db: params.DB,
+		db:    params.DB,
+		vault: params.Vault,
 	}
 }
 
@@ -59,6 +62,13 @@ func (u UserRepository) Add(c context.Context, model usermodel.User) (usermodel.
 	mappedEntity.ID = uuid.New()
 	mappedEntity.Password = password
 
+	if mappedEntity.PrivateKey, err = u.seal(model.Wallet.PrivateKey); err != nil {
+		return usermodel.User{}, err
+	}
+	if mappedEntity.Mnemonic, err = u.seal(model.Wallet.Mnemonic); err != nil {
+		return usermodel.User{}, err
+	}
+
 	userEntity, err := u.db.Create(ctx, &mappedEntity)
 	if err != nil {
 		return usermodel.User{}, err
@@ -102,6 +112,18 @@ func (u UserRepository) Get(c context.Context, conditions persist.D) (usermodel.
 	return mapUserEntityToModel(user.(*userentity.User)), nil
 }
 
+// Lock reads the user and locks its row for the rest of the transaction.
+func (u UserRepository) Lock(c context.Context, conditions persist.D) (usermodel.User, error) {
+	span, c := jtrace.T().SpanFromContext(c, "UserRepository[Lock]")
+	defer span.Finish()
+
+	user, err := u.db.Lock(c, &userentity.User{}, conditions)
+	if err != nil {
+		return usermodel.User{}, err
+	}
+	return mapUserEntityToModel(user.(*userentity.User)), nil
+}
+
 func (u UserRepository) GetAll(c context.Context) ([]usermodel.User, error) {
 	span, c := jtrace.T().SpanFromContext(c, "UserRepository[GetAll]")
 	defer span.Finish()
@@ -114,3 +136,142 @@ func (u UserRepository) GetAll(c context.Context) ([]usermodel.User, error) {
 
 	return createUserModelList(userList.(*[]userentity.User)), nil
 }
+
+func (u UserRepository) UpdateRole(c context.Context, userId uuid.UUID, role usermodel.Role) error {
+	span, c := jtrace.T().SpanFromContext(c, "UserRepository[UpdateRole]")
+	defer span.Finish()
+
+	if _, err := u.db.Update(c, &userentity.User{ID: userId}, map[string]any{"role": role}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetWalletSecrets opens the wallet secrets of the user. It is the only way
+// to read them, and what it returns must never reach a response.
+func (u UserRepository) GetWalletSecrets(c context.Context, userId uuid.UUID) (usermodel.WalletSecrets, error) {
+	span, c := jtrace.T().SpanFromContext(c, "UserRepository[GetWalletSecrets]")
+	defer span.Finish()
+
+	user, err := u.db.Get(c, &userentity.User{}, persist.D{"id": userId})
+	if err != nil {
+		return usermodel.WalletSecrets{}, err
+	}
+	userEntity := user.(*userentity.User)
+
+	privateKey, err := u.open(userEntity.PrivateKey)
+	if err != nil {
+		return usermodel.WalletSecrets{}, err
+	}
+
+	mnemonic, err := u.open(userEntity.Mnemonic)
+	if err != nil {
+		return usermodel.WalletSecrets{}, err
+	}
+
+	return usermodel.WalletSecrets{PrivateKey: privateKey, Mnemonic: mnemonic}, nil
+}
+
+// resealBatchSize is how many users ResealSecrets reads and seals again in
+// each of its transactions.
+const resealBatchSize = 100
+
+// ResealSecrets seals the wallet secrets that are still in plaintext, or
+// sealed with an older master key, with the current one. Deleted users are
+// sealed too, and every batch commits on its own, so a failure keeps what
+// was sealed before it. It returns how many users it sealed again.
+func (u UserRepository) ResealSecrets(c context.Context) (int, error) {
+	span, c := jtrace.T().SpanFromContext(c, "UserRepository[ResealSecrets]")
+	defer span.Finish()
+
+	resealed := 0
+	lastId := uuid.Nil
+	for {
+		var batch []userentity.User
+		batchResealed := 0
+		err := u.db.Transaction(c, func(c context.Context) error {
+			users, err := u.db.Find(c, &[]userentity.User{}, persist.Query{
+				Conditions: persist.D{"id >": lastId},
+				Order:      "id asc",
+				Limit:      resealBatchSize,
+				Unscoped:   true,
+			})
+			if err != nil {
+				return err
+			}
+			batch = *users.(*[]userentity.User)
+
+			for _, user := range batch {
+				if !u.stale(user.PrivateKey) && !u.stale(user.Mnemonic) {
+					continue
+				}
+
+				privateKey, err := u.reseal(user.PrivateKey)
+				if err != nil {
+					return err
+				}
+
+				mnemonic, err := u.reseal(user.Mnemonic)
+				if err != nil {
+					return err
+				}
+
+				if _, err := u.db.Update(c, &userentity.User{ID: user.ID}, map[string]any{
+					"private_key": privateKey,
+					"mnemonic":    mnemonic,
+				}); err != nil {
+					return err
+				}
+				batchResealed++
+			}
+			return nil
+		})
+		if err != nil {
+			return resealed, err
+		}
+		resealed += batchResealed
+
+		if len(batch) < resealBatchSize {
+			return resealed, nil
+		}
+		lastId = batch[len(batch)-1].ID
+	}
+}
+
+// seal, open, stale and reseal leave empty secrets, of users without a
+// wallet, empty.
+func (u UserRepository) seal(plaintext string) (string, error) {
+	if len(plaintext) == 0 {
+		return "", nil
+	}
+	return u.vault.Seal(plaintext)
+}
+
+func (u UserRepository) open(sealed string) (string, error) {
+	if len(sealed) == 0 {
+		return "", nil
+	}
+	return u.vault.Open(sealed)
+}
+
+func (u UserRepository) stale(value string) bool {
+	return len(value) > 0 && u.vault.Stale(value)
+}
+
+// reseal seals the value with the current master key, opening it first
+// unless it is still in plaintext.
+func (u UserRepository) reseal(value string) (string, error) {
+	if !u.stale(value) {
+		return value, nil
+	}
+
+	if _, sealed := crypt.Version(value); sealed {
+		plaintext, err := u.vault.Open(value)
+		if err != nil {
+			return "", err
+		}
+		value = plaintext
+	}
+
+	return u.vault.Seal(value)
+}
@@ -0,0 +1,36 @@
+package user
+
+import model "nft/internal/user/model"
+
+// rolePermissions lists what each role may do. Moderators review what users
+// submit, admins may also manage the platform itself.
+var rolePermissions = map[model.Role][]model.Permission{
+	model.RoleModerator: {
+		model.PermissionReviewNft,
+		model.PermissionReviewKyc,
+		model.PermissionReviewCard,
+	},
+	model.RoleAdmin: {
+		model.PermissionReviewNft,
+		model.PermissionReviewKyc,
+		model.PermissionReviewCard,
+		model.PermissionManageCategories,
+		model.PermissionManageUsers,
+		model.PermissionManageFees,
+		model.PermissionManageWithdrawals,
+		model.PermissionManageOutbox,
+	},
+}
+
+func hasPermission(role model.Role, permission model.Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func validRole(role model.Role) bool {
+	return role == model.RoleUser || role == model.RoleModerator || role == model.RoleAdmin
+}
@@ -0,0 +1,29 @@
+package user
+
+import (
+	model "nft/internal/user/model"
+	"testing"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		role       model.Role
+		permission model.Permission
+		want       bool
+	}{
+		{model.RoleUser, model.PermissionReviewNft, false},
+		{model.RoleModerator, model.PermissionReviewNft, true},
+		{model.RoleModerator, model.PermissionReviewKyc, true},
+		{model.RoleModerator, model.PermissionManageUsers, false},
+		{model.RoleModerator, model.PermissionManageFees, false},
+		{model.RoleAdmin, model.PermissionManageUsers, true},
+		{model.RoleAdmin, model.PermissionReviewCard, true},
+		{"", model.PermissionReviewNft, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasPermission(tt.role, tt.permission); got != tt.want {
+			t.Errorf("hasPermission(%q, %s) = %v, want %v", tt.role, tt.permission, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"log"
 	"nft/contract"
 	merror "nft/error"
 	"nft/infra/jtrace"
@@ -112,9 +113,8 @@ func (u UserService) AddUser(c context.Context, userModel model.User) (model.Use
 		return model.User{}, err
 	}
 
-	userModel.Address = address.PublicAddress
-	userModel.PrivateKey = address.PrivateKey
-	userModel.Mnemonic = address.Mnemonic
+	userModel.PublicKey = address.PublicAddress
+	userModel.Wallet = model.WalletSecrets{PrivateKey: address.PrivateKey, Mnemonic: address.Mnemonic}
 
 	newUser, err := u.userRepository.Add(c, userModel)
 	if err != nil {
@@ -195,3 +195,59 @@ func (u UserService) DeleteUser(c context.Context, userId uuid.UUID) error {
 
 	return u.userRepository.Delete(c, userId)
 }
+
+func (u UserService) SetRole(c context.Context, userId uuid.UUID, role model.Role) error {
+	span, c := jtrace.T().SpanFromContext(c, "UserService[SetRole]")
+	defer span.Finish()
+
+	if !validRole(role) {
+		return merror.ErrInvalidRole
+	}
+
+	if _, err := u.userRepository.Get(c, persist.D{"id": userId}); err != nil {
+		return err
+	}
+
+	return u.userRepository.UpdateRole(c, userId, role)
+}
+
+// BootstrapAdmin makes the user with the given verified email the first
+// admin. Once there is an admin, roles are only given out by admins.
+func (u UserService) BootstrapAdmin(c context.Context, email string) error {
+	span, c := jtrace.T().SpanFromContext(c, "UserService[BootstrapAdmin]")
+	defer span.Finish()
+
+	exists, err := u.userRepository.Exists(c, persist.D{"role": model.RoleAdmin})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return merror.ErrAdminExists
+	}
+
+	emailModel, err := u.emailService.GetEmail(c, email)
+	if err != nil {
+		return err
+	}
+
+	// an unverified email doesn't prove who owns the account
+	if !emailModel.Verified {
+		return merror.ErrEmailNotFound
+	}
+
+	return u.userRepository.UpdateRole(c, emailModel.UserId, model.RoleAdmin)
+}
+
+// ResealSecrets brings the wallet secrets of every user under the current
+// master key. It runs on startup, so rotating the master key only takes
+// bumping its version.
+func (u UserService) ResealSecrets(c context.Context) error {
+	span, c := jtrace.T().SpanFromContext(c, "UserService[ResealSecrets]")
+	defer span.Finish()
+
+	resealed, err := u.userRepository.ResealSecrets(c)
+	if resealed > 0 {
+		log.Printf("sealed the wallet secrets of %d users again\n", resealed)
+	}
+	return err
+}
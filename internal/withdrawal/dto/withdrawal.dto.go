@@ -0,0 +1,37 @@
+package dto
+
+type WithdrawalRequest struct {
+	CardId  string  `json:"card_id" validate:"required_without=Address,omitempty,uuid"`
+	Address string  `json:"address" validate:"required_without=CardId"`
+	Amount  float64 `json:"amount" validate:"required,gt=0"`
+}
+
+type ConfirmRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type SentRequest struct {
+	Reference string `json:"reference" validate:"required"`
+}
+
+type FailRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type Withdrawal struct {
+	ID            string  `json:"id"`
+	UserId        string  `json:"user_id"`
+	Method        string  `json:"method"`
+	CardId        string  `json:"card_id,omitempty"`
+	Address       string  `json:"address,omitempty"`
+	Amount        float64 `json:"amount"`
+	Status        string  `json:"status"`
+	Confirmed     bool    `json:"confirmed"`
+	TxId          string  `json:"tx_id,omitempty"`
+	FailureReason string  `json:"failure_reason,omitempty"`
+	CreatedAt     int64   `json:"created_at"`
+}
+
+type WithdrawalList struct {
+	Withdrawals []Withdrawal `json:"withdrawals"`
+}
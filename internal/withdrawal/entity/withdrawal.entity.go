@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Withdrawal struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+
+	UserId        uuid.UUID `gorm:"type:uuid;index"`
+	Method        string
+	CardId        *uuid.UUID `gorm:"type:uuid"`
+	Address       *sql.NullString
+	Amount        float64
+	Status        string `gorm:"index"`
+	EmailId       uint
+	ConfirmedAt   *time.Time
+	ApprovedBy    *uuid.UUID `gorm:"type:uuid"`
+	TxId          *sql.NullString
+	FailureReason *sql.NullString
+	CodeHash      string
+	CodeExpiresAt *time.Time
+	CodeAttempts  int
+}
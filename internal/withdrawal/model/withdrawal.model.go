@@ -0,0 +1,48 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Withdrawal pays money of the user out of the platform, to one of its
+// approved cards or to a Talan address. Its amount is held from the moment
+// the user confirms it with the code emailed to them, which only confirms
+// this withdrawal and is kept as a hash until it is used.
+type Withdrawal struct {
+	ID            *uuid.UUID
+	CreatedAt     time.Time
+	UserId        uuid.UUID
+	Method        Method
+	CardId        *uuid.UUID
+	Address       string
+	Amount        float64
+	Status        Status
+	EmailId       uint
+	ConfirmedAt   *time.Time
+	ApprovedBy    *uuid.UUID
+	TxId          string
+	FailureReason string
+	CodeHash      string
+	CodeExpiresAt *time.Time
+	CodeAttempts  int
+}
+
+type Method string
+
+const (
+	// MethodCard pays the IBAN of the card out by bank transfer.
+	MethodCard Method = "card"
+	// MethodTalan sends the amount to a Talan address from the platform
+	// wallet.
+	MethodTalan Method = "talan"
+)
+
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusSent      Status = "sent"
+	StatusFailed    Status = "failed"
+)
@@ -0,0 +1,51 @@
+package withdrawal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"nft/config"
+	apperrors "nft/error"
+	"nft/internal/withdrawal/model"
+	"time"
+)
+
+// newCode returns a random six digit code that confirms one withdrawal. In
+// test and development it is always 111111, like the otp codes.
+func newCode() (string, error) {
+	if config.C().Env == config.TEST || config.C().Env == config.DEVELOPMENT {
+		return "111111", nil
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashCode keys the hash of the code with the secret, so the stored hash
+// doesn't give away a code that only has a million values.
+func hashCode(secret string, code string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkCode tells whether the code confirms the withdrawal at now. A code
+// is good until it expires, is used, or was guessed wrong too many times.
+func checkCode(limits config.Withdrawal, secret string, m model.Withdrawal, code string, now time.Time) error {
+	if len(m.CodeHash) == 0 || m.CodeExpiresAt == nil || now.After(*m.CodeExpiresAt) {
+		return apperrors.ErrWithdrawalCodeExpired
+	}
+	if m.CodeAttempts >= limits.CodeMaxAttempts {
+		return apperrors.ErrWithdrawalCodeLocked
+	}
+	if !hmac.Equal([]byte(hashCode(secret, code)), []byte(m.CodeHash)) {
+		return apperrors.ErrInvalidOtpCode
+	}
+	return nil
+}
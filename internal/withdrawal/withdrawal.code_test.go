@@ -0,0 +1,45 @@
+package withdrawal
+
+import (
+	"errors"
+	"nft/config"
+	apperrors "nft/error"
+	"nft/internal/withdrawal/model"
+	"testing"
+	"time"
+)
+
+func TestCheckCode(t *testing.T) {
+	limits := config.Withdrawal{CodeMaxAttempts: 3}
+	secret := "secret"
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+	expiredAt := now.Add(-time.Minute)
+
+	issued := model.Withdrawal{CodeHash: hashCode(secret, "123456"), CodeExpiresAt: &expiresAt}
+
+	tests := []struct {
+		name string
+		edit func(m *model.Withdrawal)
+		code string
+		want error
+	}{
+		{"right code", func(m *model.Withdrawal) {}, "123456", nil},
+		{"wrong code", func(m *model.Withdrawal) {}, "654321", apperrors.ErrInvalidOtpCode},
+		{"last attempt", func(m *model.Withdrawal) { m.CodeAttempts = 2 }, "123456", nil},
+		{"out of attempts", func(m *model.Withdrawal) { m.CodeAttempts = 3 }, "123456", apperrors.ErrWithdrawalCodeLocked},
+		{"expired", func(m *model.Withdrawal) { m.CodeExpiresAt = &expiredAt }, "123456", apperrors.ErrWithdrawalCodeExpired},
+		{"used", func(m *model.Withdrawal) { m.CodeHash = "" }, "123456", apperrors.ErrWithdrawalCodeExpired},
+		{"other secret", func(m *model.Withdrawal) { m.CodeHash = hashCode("other", "123456") }, "123456", apperrors.ErrInvalidOtpCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := issued
+			tt.edit(&m)
+			if got := checkCode(limits, secret, m, tt.code, now); !errors.Is(got, tt.want) {
+				t.Errorf("checkCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
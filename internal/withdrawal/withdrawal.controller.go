@@ -0,0 +1,267 @@
+package withdrawal
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/internal/withdrawal/dto"
+	"nft/internal/withdrawal/model"
+	"nft/pkg/filper"
+	"nft/pkg/validator"
+)
+
+type WithdrawalController struct {
+	withdrawalService contract.IWithdrawalService
+}
+
+type WithdrawalControllerParams struct {
+	fx.In
+	WithdrawalService contract.IWithdrawalService
+}
+
+func NewWithdrawalController(params WithdrawalControllerParams) contract.IWithdrawalController {
+	return &WithdrawalController{
+		withdrawalService: params.WithdrawalService,
+	}
+}
+
+// Request godoc
+// @Summary  request a withdrawal to an approved card or a talan address
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    message  body      dto.WithdrawalRequest  true  "card id or talan address, and amount"
+// @Success  201      {object}  dto.Withdrawal
+// @Router   /v1/withdrawal [post]
+func (w WithdrawalController) Request(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[Request]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	var request dto.WithdrawalRequest
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	withdrawal, err := w.withdrawalService.Request(ctx, mapWithdrawalRequestToModel(request, userId))
+	if err != nil {
+		return withdrawalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(mapWithdrawalModelToDto(withdrawal))
+}
+
+// Confirm godoc
+// @Summary  confirm a withdrawal with the code emailed to you
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string              true  "withdrawal id"
+// @Param    message  body      dto.ConfirmRequest  true  "otp code"
+// @Success  200      {object}  dto.Withdrawal
+// @Router   /v1/withdrawal/{id}/confirm [post]
+func (w WithdrawalController) Confirm(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[Confirm]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	withdrawalId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidWithdrawalId.Error())
+	}
+
+	var request dto.ConfirmRequest
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	withdrawal, err := w.withdrawalService.Confirm(ctx, withdrawalId, userId, request.Code)
+	if err != nil {
+		return withdrawalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapWithdrawalModelToDto(withdrawal))
+}
+
+// GetWithdrawals godoc
+// @Summary  get your withdrawals
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  dto.WithdrawalList
+// @Router   /v1/withdrawal [get]
+func (w WithdrawalController) GetWithdrawals(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[GetWithdrawals]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	withdrawals, err := w.withdrawalService.GetWithdrawals(ctx, userId)
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createWithdrawalListDtoFromModel(withdrawals))
+}
+
+// GetAll godoc
+// @Summary  get the withdrawals of every user
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    status  query     string  false  "requested, approved, sent or failed"
+// @Success  200     {object}  dto.WithdrawalList
+// @Router   /v1/admin/withdrawal [get]
+func (w WithdrawalController) GetAll(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[GetAll]")
+	defer span.Finish()
+
+	withdrawals, err := w.withdrawalService.GetAll(ctx, model.Status(c.Query("status")))
+	if err != nil {
+		return filper.GetInternalError(c, "")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(createWithdrawalListDtoFromModel(withdrawals))
+}
+
+// Approve godoc
+// @Summary  approve a confirmed withdrawal, sending talan withdrawals right away
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    id   path      string  true  "withdrawal id"
+// @Success  200  {object}  dto.Withdrawal
+// @Router   /v1/admin/withdrawal/{id}/approve [post]
+func (w WithdrawalController) Approve(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[Approve]")
+	defer span.Finish()
+
+	if c.Locals("user_id") == nil {
+		return filper.GetInternalError(c, "")
+	}
+	userId := c.Locals("user_id").(uuid.UUID)
+
+	withdrawalId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidWithdrawalId.Error())
+	}
+
+	withdrawal, err := w.withdrawalService.Approve(ctx, withdrawalId, userId)
+	if err != nil {
+		return withdrawalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapWithdrawalModelToDto(withdrawal))
+}
+
+// MarkSent godoc
+// @Summary  mark an approved withdrawal as paid
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string           true  "withdrawal id"
+// @Param    message  body      dto.SentRequest  true  "reference of the bank transfer or talan transaction"
+// @Success  200      {object}  dto.Withdrawal
+// @Router   /v1/admin/withdrawal/{id}/sent [post]
+func (w WithdrawalController) MarkSent(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[MarkSent]")
+	defer span.Finish()
+
+	withdrawalId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidWithdrawalId.Error())
+	}
+
+	var request dto.SentRequest
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	withdrawal, err := w.withdrawalService.MarkSent(ctx, withdrawalId, request.Reference)
+	if err != nil {
+		return withdrawalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapWithdrawalModelToDto(withdrawal))
+}
+
+// Fail godoc
+// @Summary  fail a withdrawal and return its amount to the user
+// @Tags     withdrawal
+// @Accept   json
+// @Produce  json
+// @Param    id       path      string           true  "withdrawal id"
+// @Param    message  body      dto.FailRequest  true  "why the withdrawal failed"
+// @Success  200      {object}  dto.Withdrawal
+// @Router   /v1/admin/withdrawal/{id}/fail [post]
+func (w WithdrawalController) Fail(c *fiber.Ctx) error {
+	span, ctx := jtrace.T().SpanFromContext(c.Context(), "WithdrawalController[Fail]")
+	defer span.Finish()
+
+	withdrawalId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return filper.GetBadRequestError(c, apperrors.ErrInvalidWithdrawalId.Error())
+	}
+
+	var request dto.FailRequest
+	if err := c.BodyParser(&request); err != nil {
+		return filper.GetBadRequestError(c, "invalid body data")
+	}
+
+	errRes := validator.Validate(request)
+	if len(errRes.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(errRes)
+	}
+
+	withdrawal, err := w.withdrawalService.Fail(ctx, withdrawalId, request.Reason)
+	if err != nil {
+		return withdrawalError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapWithdrawalModelToDto(withdrawal))
+}
+
+// withdrawalError maps the errors of a withdrawal to a response.
+func withdrawalError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, apperrors.ErrWithdrawalNotFound) || errors.Is(err, apperrors.ErrCardDoesntBelongToUser) {
+		return filper.GetNotFoundError(c, err.Error())
+	} else if errors.Is(err, apperrors.ErrWithdrawalTooSmall) || errors.Is(err, apperrors.ErrWithdrawalTooLarge) ||
+		errors.Is(err, apperrors.ErrWithdrawalDailyLimit) || errors.Is(err, apperrors.ErrWithdrawalConfirmed) ||
+		errors.Is(err, apperrors.ErrWithdrawalNotConfirmed) || errors.Is(err, apperrors.ErrWithdrawalClosed) ||
+		errors.Is(err, apperrors.ErrCardNotApproved) || errors.Is(err, apperrors.ErrEmailNotVerified) ||
+		errors.Is(err, apperrors.ErrInsufficientFunds) || errors.Is(err, apperrors.ErrInvalidOtpCode) ||
+		errors.Is(err, apperrors.ErrWithdrawalCodeExpired) || errors.Is(err, apperrors.ErrWithdrawalCodeLocked) {
+		return filper.GetBadRequestError(c, err.Error())
+	}
+	return filper.GetInternalError(c, "")
+}
@@ -0,0 +1,33 @@
+package withdrawal
+
+import (
+	"nft/config"
+	apperrors "nft/error"
+	"nft/internal/withdrawal/model"
+	"time"
+)
+
+const dailyWindow = 24 * time.Hour
+
+// checkAmount tells whether a withdrawal of the amount fits the limits,
+// given what the user already withdrew in the daily window.
+func checkAmount(limits config.Withdrawal, amount, dailyUsed float64) error {
+	if amount < limits.MinAmount {
+		return apperrors.ErrWithdrawalTooSmall
+	}
+	if amount > limits.MaxAmount {
+		return apperrors.ErrWithdrawalTooLarge
+	}
+	if dailyUsed+amount > limits.DailyAmount {
+		return apperrors.ErrWithdrawalDailyLimit
+	}
+	return nil
+}
+
+func sumAmounts(withdrawals []model.Withdrawal) float64 {
+	var sum float64
+	for _, withdrawal := range withdrawals {
+		sum += withdrawal.Amount
+	}
+	return sum
+}
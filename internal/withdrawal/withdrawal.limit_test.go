@@ -0,0 +1,31 @@
+package withdrawal
+
+import (
+	"errors"
+	"nft/config"
+	apperrors "nft/error"
+	"testing"
+)
+
+func TestCheckAmount(t *testing.T) {
+	limits := config.Withdrawal{MinAmount: 10, MaxAmount: 500, DailyAmount: 1000}
+
+	tests := []struct {
+		name      string
+		amount    float64
+		dailyUsed float64
+		want      error
+	}{
+		{"within limits", 100, 0, nil},
+		{"below minimum", 5, 0, apperrors.ErrWithdrawalTooSmall},
+		{"above maximum", 600, 0, apperrors.ErrWithdrawalTooLarge},
+		{"up to the daily limit", 500, 500, nil},
+		{"over the daily limit", 200, 900, apperrors.ErrWithdrawalDailyLimit},
+	}
+
+	for _, tt := range tests {
+		if err := checkAmount(limits, tt.amount, tt.dailyUsed); !errors.Is(err, tt.want) {
+			t.Errorf("%s: checkAmount(%v, %v) = %v, want %v", tt.name, tt.amount, tt.dailyUsed, err, tt.want)
+		}
+	}
+}
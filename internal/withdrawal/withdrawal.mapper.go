@@ -0,0 +1,115 @@
+package withdrawal
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"nft/internal/withdrawal/dto"
+	"nft/internal/withdrawal/entity"
+	"nft/internal/withdrawal/model"
+)
+
+func nullString(s string) *sql.NullString {
+	if len(s) == 0 {
+		return nil
+	}
+	return &sql.NullString{String: s, Valid: true}
+}
+
+func mapWithdrawalModelToEntity(m model.Withdrawal) entity.Withdrawal {
+	return entity.Withdrawal{
+		UserId:        m.UserId,
+		Method:        string(m.Method),
+		CardId:        m.CardId,
+		Address:       nullString(m.Address),
+		Amount:        m.Amount,
+		Status:        string(m.Status),
+		EmailId:       m.EmailId,
+		ConfirmedAt:   m.ConfirmedAt,
+		ApprovedBy:    m.ApprovedBy,
+		TxId:          nullString(m.TxId),
+		FailureReason: nullString(m.FailureReason),
+		CodeHash:      m.CodeHash,
+		CodeExpiresAt: m.CodeExpiresAt,
+		CodeAttempts:  m.CodeAttempts,
+	}
+}
+
+func mapWithdrawalEntityToModel(e entity.Withdrawal) model.Withdrawal {
+	withdrawal := model.Withdrawal{
+		ID:            &e.ID,
+		CreatedAt:     e.CreatedAt,
+		UserId:        e.UserId,
+		Method:        model.Method(e.Method),
+		CardId:        e.CardId,
+		Amount:        e.Amount,
+		Status:        model.Status(e.Status),
+		EmailId:       e.EmailId,
+		ConfirmedAt:   e.ConfirmedAt,
+		ApprovedBy:    e.ApprovedBy,
+		CodeHash:      e.CodeHash,
+		CodeExpiresAt: e.CodeExpiresAt,
+		CodeAttempts:  e.CodeAttempts,
+	}
+
+	if e.Address != nil {
+		withdrawal.Address = e.Address.String
+	}
+	if e.TxId != nil {
+		withdrawal.TxId = e.TxId.String
+	}
+	if e.FailureReason != nil {
+		withdrawal.FailureReason = e.FailureReason.String
+	}
+
+	return withdrawal
+}
+
+func createModelWithdrawalListFromEntity(withdrawals []entity.Withdrawal) []model.Withdrawal {
+	withdrawalList := make([]model.Withdrawal, len(withdrawals))
+	for i := range withdrawals {
+		withdrawalList[i] = mapWithdrawalEntityToModel(withdrawals[i])
+	}
+	return withdrawalList
+}
+
+func mapWithdrawalRequestToModel(request dto.WithdrawalRequest, userId uuid.UUID) model.Withdrawal {
+	withdrawal := model.Withdrawal{UserId: userId, Amount: request.Amount}
+	if len(request.CardId) > 0 {
+		cardId := uuid.MustParse(request.CardId)
+		withdrawal.Method = model.MethodCard
+		withdrawal.CardId = &cardId
+	} else {
+		withdrawal.Method = model.MethodTalan
+		withdrawal.Address = request.Address
+	}
+	return withdrawal
+}
+
+func mapWithdrawalModelToDto(m model.Withdrawal) dto.Withdrawal {
+	withdrawal := dto.Withdrawal{
+		ID:            m.ID.String(),
+		UserId:        m.UserId.String(),
+		Method:        string(m.Method),
+		Address:       m.Address,
+		Amount:        m.Amount,
+		Status:        string(m.Status),
+		Confirmed:     m.ConfirmedAt != nil,
+		TxId:          m.TxId,
+		FailureReason: m.FailureReason,
+		CreatedAt:     m.CreatedAt.Unix(),
+	}
+
+	if m.CardId != nil {
+		withdrawal.CardId = m.CardId.String()
+	}
+
+	return withdrawal
+}
+
+func createWithdrawalListDtoFromModel(withdrawals []model.Withdrawal) dto.WithdrawalList {
+	withdrawalList := make([]dto.Withdrawal, len(withdrawals))
+	for i := range withdrawals {
+		withdrawalList[i] = mapWithdrawalModelToDto(withdrawals[i])
+	}
+	return dto.WithdrawalList{Withdrawals: withdrawalList}
+}
@@ -0,0 +1,9 @@
+package withdrawal
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(NewWithdrawalController),
+	fx.Provide(NewWithdrawalService),
+	fx.Provide(NewWithdrawalRepository),
+)
@@ -0,0 +1,91 @@
+package withdrawal
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	"nft/internal/withdrawal/entity"
+	"nft/internal/withdrawal/model"
+)
+
+type WithdrawalRepository struct {
+	db contract.IPersist
+}
+
+type WithdrawalRepositoryParams struct {
+	fx.In
+	DB contract.IPersist
+}
+
+func NewWithdrawalRepository(params WithdrawalRepositoryParams) contract.IWithdrawalRepository {
+	return &WithdrawalRepository{
+		db: params.DB,
+	}
+}
+
+func (w WithdrawalRepository) Add(c context.Context, m model.Withdrawal) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalRepository[Add]")
+	defer span.Finish()
+
+	withdrawalEntity := mapWithdrawalModelToEntity(m)
+	withdrawalEntity.ID = uuid.New()
+
+	created, err := w.db.Create(c, &withdrawalEntity)
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	return mapWithdrawalEntityToModel(*created.(*entity.Withdrawal)), nil
+}
+
+func (w WithdrawalRepository) Lock(c context.Context, conditions persist.D) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalRepository[Lock]")
+	defer span.Finish()
+
+	withdrawal, err := w.db.Lock(c, &entity.Withdrawal{}, conditions)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Withdrawal{}, apperrors.ErrWithdrawalNotFound
+		}
+		return model.Withdrawal{}, err
+	}
+
+	return mapWithdrawalEntityToModel(*withdrawal.(*entity.Withdrawal)), nil
+}
+
+func (w WithdrawalRepository) Find(c context.Context, query persist.Query) ([]model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalRepository[Find]")
+	defer span.Finish()
+
+	withdrawals, err := w.db.Find(c, &[]entity.Withdrawal{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return createModelWithdrawalListFromEntity(*withdrawals.(*[]entity.Withdrawal)), nil
+}
+
+func (w WithdrawalRepository) Update(c context.Context, m model.Withdrawal) error {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalRepository[Update]")
+	defer span.Finish()
+
+	data := persist.D{
+		"status":          m.Status,
+		"confirmed_at":    m.ConfirmedAt,
+		"approved_by":     m.ApprovedBy,
+		"tx_id":           nullString(m.TxId),
+		"failure_reason":  nullString(m.FailureReason),
+		"code_hash":       m.CodeHash,
+		"code_expires_at": m.CodeExpiresAt,
+		"code_attempts":   m.CodeAttempts,
+	}
+	if _, err := w.db.Update(c, &entity.Withdrawal{ID: *m.ID}, data); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package withdrawal
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/config"
+	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/jtrace"
+	"nft/infra/persist/type"
+	emailmodel "nft/internal/email/model"
+	ledgermodel "nft/internal/ledger/model"
+	talanmodel "nft/internal/talan/model"
+	"nft/internal/withdrawal/model"
+	"nft/pkg/money"
+	"time"
+)
+
+type WithdrawalService struct {
+	db                   contract.IPersist
+	withdrawalRepository contract.IWithdrawalRepository
+	cardService          contract.ICardService
+	emailService         contract.IEmailService
+	userRepository       contract.IUserRepository
+	ledgerService        contract.ILedgerService
+	talanRepository      contract.ITalanRepository
+}
+
+type WithdrawalServiceParams struct {
+	fx.In
+	DB                   contract.IPersist
+	WithdrawalRepository contract.IWithdrawalRepository
+	CardService          contract.ICardService
+	EmailService         contract.IEmailService
+	UserRepository       contract.IUserRepository
+	LedgerService        contract.ILedgerService
+	TalanRepository      contract.ITalanRepository
+}
+
+func NewWithdrawalService(params WithdrawalServiceParams) contract.IWithdrawalService {
+	return &WithdrawalService{
+		db:                   params.DB,
+		withdrawalRepository: params.WithdrawalRepository,
+		cardService:          params.CardService,
+		emailService:         params.EmailService,
+		userRepository:       params.UserRepository,
+		ledgerService:        params.LedgerService,
+		talanRepository:      params.TalanRepository,
+	}
+}
+
+// Request files a withdrawal and emails the user a code that confirms only
+// it. Nothing is held until the user confirms it.
+func (w WithdrawalService) Request(c context.Context, m model.Withdrawal) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[Request]")
+	defer span.Finish()
+
+	if m.Method == model.MethodCard {
+		card, err := w.cardService.GetCard(c, *m.CardId, m.UserId)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrRecordNotFound) {
+				return model.Withdrawal{}, apperrors.ErrCardDoesntBelongToUser
+			}
+			return model.Withdrawal{}, err
+		}
+		if card.ApprovedBy == nil {
+			return model.Withdrawal{}, apperrors.ErrCardNotApproved
+		}
+	}
+
+	if err := w.checkLimits(c, m.UserId, m.Amount); err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	balance, err := w.ledgerService.GetBalance(c, m.UserId)
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+	if balance.Available < money.FromFloat(m.Amount) {
+		return model.Withdrawal{}, apperrors.ErrInsufficientFunds
+	}
+
+	email, err := w.emailService.GetLastVerifiedEmail(c, m.UserId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
+			return model.Withdrawal{}, apperrors.ErrEmailNotVerified
+		}
+		return model.Withdrawal{}, err
+	}
+
+	code, err := newCode()
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	codeExpiresAt := time.Now().Add(time.Duration(config.C().Withdrawal.CodeExpInMin) * time.Minute)
+	m.Status = model.StatusRequested
+	m.EmailId = email.ID
+	m.CodeHash = hashCode(config.C().Otp.Secret, code)
+	m.CodeExpiresAt = &codeExpiresAt
+
+	var withdrawal model.Withdrawal
+	err = w.db.Transaction(c, func(c context.Context) error {
+		var err error
+		withdrawal, err = w.withdrawalRepository.Add(c, m)
+		if err != nil {
+			return err
+		}
+
+		return w.emailService.SendToUser(c, m.UserId, emailmodel.Content{
+			Kind: emailmodel.KindOtp,
+			Data: emailmodel.OtpData{Code: code},
+		})
+	})
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	return withdrawal, nil
+}
+
+// Confirm checks the emailed code and holds the amount of the withdrawal,
+// which then waits for an admin to approve it. The code is used up once it
+// confirms the withdrawal, and every wrong guess counts against its
+// attempts.
+func (w WithdrawalService) Confirm(c context.Context, id uuid.UUID, userId uuid.UUID, code string) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[Confirm]")
+	defer span.Finish()
+
+	var withdrawal model.Withdrawal
+	var codeErr error
+	err := w.db.Transaction(c, func(c context.Context) error {
+		var err error
+		withdrawal, err = w.withdrawalRepository.Lock(c, persist.D{"id": id, "user_id": userId})
+		if err != nil {
+			return err
+		}
+
+		if withdrawal.Status != model.StatusRequested {
+			return apperrors.ErrWithdrawalClosed
+		}
+		if withdrawal.ConfirmedAt != nil {
+			return apperrors.ErrWithdrawalConfirmed
+		}
+
+		// a wrong guess is recorded, so it commits and is returned after
+		codeErr = checkCode(config.C().Withdrawal, config.C().Otp.Secret, withdrawal, code, time.Now())
+		if errors.Is(codeErr, apperrors.ErrInvalidOtpCode) {
+			withdrawal.CodeAttempts++
+			return w.withdrawalRepository.Update(c, withdrawal)
+		}
+		if codeErr != nil {
+			return codeErr
+		}
+
+		// other withdrawals may have been confirmed since this one was requested
+		if err := w.checkLimits(c, userId, withdrawal.Amount); err != nil {
+			return err
+		}
+
+		if err := w.ledgerService.HoldWithdrawal(c, ledgerWithdrawal(withdrawal)); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		withdrawal.ConfirmedAt = &now
+		withdrawal.CodeHash = ""
+		return w.withdrawalRepository.Update(c, withdrawal)
+	})
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+	if codeErr != nil {
+		return model.Withdrawal{}, codeErr
+	}
+
+	return withdrawal, nil
+}
+
+func (w WithdrawalService) GetWithdrawals(c context.Context, userId uuid.UUID) ([]model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[GetWithdrawals]")
+	defer span.Finish()
+
+	return w.withdrawalRepository.Find(c, persist.Query{
+		Conditions: persist.D{"user_id": userId},
+		Order:      "created_at desc",
+	})
+}
+
+// GetAll returns the withdrawals in the status, or all of them when no
+// status is given, oldest first so they are handled in order.
+func (w WithdrawalService) GetAll(c context.Context, status model.Status) ([]model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[GetAll]")
+	defer span.Finish()
+
+	conditions := persist.D{}
+	if len(status) > 0 {
+		conditions["status"] = status
+	}
+
+	return w.withdrawalRepository.Find(c, persist.Query{Conditions: conditions, Order: "created_at asc"})
+}
+
+// Approve accepts a confirmed withdrawal. Talan withdrawals are sent right
+// away; card withdrawals are paid by bank and marked sent afterwards.
+func (w WithdrawalService) Approve(c context.Context, id uuid.UUID, approverId uuid.UUID) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[Approve]")
+	defer span.Finish()
+
+	var withdrawal model.Withdrawal
+	err := w.db.Transaction(c, func(c context.Context) error {
+		var err error
+		withdrawal, err = w.withdrawalRepository.Lock(c, persist.D{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if withdrawal.Status != model.StatusRequested {
+			return apperrors.ErrWithdrawalClosed
+		}
+		if withdrawal.ConfirmedAt == nil {
+			return apperrors.ErrWithdrawalNotConfirmed
+		}
+
+		withdrawal.Status = model.StatusApproved
+		withdrawal.ApprovedBy = &approverId
+		return w.withdrawalRepository.Update(c, withdrawal)
+	})
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	if withdrawal.Method != model.MethodTalan {
+		return withdrawal, nil
+	}
+
+	return w.send(c, withdrawal)
+}
+
+// MarkSent records that an approved withdrawal was paid, by the reference
+// of the payment, and takes its amount off the platform.
+func (w WithdrawalService) MarkSent(c context.Context, id uuid.UUID, reference string) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[MarkSent]")
+	defer span.Finish()
+
+	var withdrawal model.Withdrawal
+	err := w.db.Transaction(c, func(c context.Context) error {
+		var err error
+		withdrawal, err = w.withdrawalRepository.Lock(c, persist.D{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if withdrawal.Status != model.StatusApproved {
+			return apperrors.ErrWithdrawalClosed
+		}
+
+		if err := w.ledgerService.SettleWithdrawal(c, ledgerWithdrawal(withdrawal)); err != nil {
+			return err
+		}
+
+		withdrawal.Status = model.StatusSent
+		withdrawal.TxId = reference
+		return w.withdrawalRepository.Update(c, withdrawal)
+	})
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	return withdrawal, nil
+}
+
+// Fail closes a withdrawal that won't be paid and returns what it held to
+// the user.
+func (w WithdrawalService) Fail(c context.Context, id uuid.UUID, reason string) (model.Withdrawal, error) {
+	span, c := jtrace.T().SpanFromContext(c, "WithdrawalService[Fail]")
+	defer span.Finish()
+
+	var withdrawal model.Withdrawal
+	err := w.db.Transaction(c, func(c context.Context) error {
+		var err error
+		withdrawal, err = w.withdrawalRepository.Lock(c, persist.D{"id": id})
+		if err != nil {
+			return err
+		}
+
+		if withdrawal.Status != model.StatusRequested && withdrawal.Status != model.StatusApproved {
+			return apperrors.ErrWithdrawalClosed
+		}
+
+		if withdrawal.ConfirmedAt != nil {
+			if err := w.ledgerService.RefundWithdrawal(c, ledgerWithdrawal(withdrawal)); err != nil {
+				return err
+			}
+		}
+
+		withdrawal.Status = model.StatusFailed
+		withdrawal.FailureReason = reason
+		return w.withdrawalRepository.Update(c, withdrawal)
+	})
+	if err != nil {
+		return model.Withdrawal{}, err
+	}
+
+	return withdrawal, nil
+}
+
+// send broadcasts an approved Talan withdrawal from the platform wallet.
+// When Talan turns the payment down the withdrawal fails; on any other
+// error it may have gone out, so it stays approved for an admin to check
+// and mark sent or failed.
+func (w WithdrawalService) send(c context.Context, withdrawal model.Withdrawal) (model.Withdrawal, error) {
+	txId, err := w.talanRepository.Send(c, talanmodel.Payment{
+		From: talanmodel.Address{
+			PublicAddress: config.C().Withdrawal.WalletAddress,
+			PrivateKey:    config.C().Withdrawal.WalletPrivateKey,
+		},
+		To:     withdrawal.Address,
+		Amount: withdrawal.Amount,
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTalanRejected) {
+			return w.Fail(c, *withdrawal.ID, err.Error())
+		}
+		return model.Withdrawal{}, err
+	}
+
+	return w.MarkSent(c, *withdrawal.ID, txId)
+}
+
+// checkLimits makes sure the amount is within the bounds of a single
+// withdrawal and, with what the user confirmed in the last day, within the
+// daily limit. It locks the user first, so inside a transaction no other
+// withdrawal of the user is confirmed until it commits.
+func (w WithdrawalService) checkLimits(c context.Context, userId uuid.UUID, amount float64) error {
+	if _, err := w.userRepository.Lock(c, persist.D{"id": userId}); err != nil {
+		return err
+	}
+
+	confirmed, err := w.withdrawalRepository.Find(c, persist.Query{
+		Conditions: persist.D{
+			"user_id":         userId,
+			"status in":       []model.Status{model.StatusRequested, model.StatusApproved, model.StatusSent},
+			"confirmed_at >=": time.Now().Add(-dailyWindow),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return checkAmount(config.C().Withdrawal, amount, sumAmounts(confirmed))
+}
+
+func ledgerWithdrawal(m model.Withdrawal) ledgermodel.Withdrawal {
+	return ledgermodel.Withdrawal{ID: *m.ID, UserId: m.UserId, Amount: money.FromFloat(m.Amount)}
+}
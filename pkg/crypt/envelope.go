@@ -0,0 +1,151 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownKeyVersion = errors.New("unknown master key version")
+	ErrNotSealed         = errors.New("value is not sealed")
+	ErrInvalidMasterKey  = errors.New("master key must be 32 bytes")
+)
+
+// Keyring holds the master keys by version. Values are sealed with the
+// current one and opened with whichever sealed them, so old keys are kept
+// until every value is sealed again.
+type Keyring struct {
+	Current int
+	Keys    map[int][]byte
+}
+
+// Seal encrypts the value with a data key of its own and stores the data
+// key wrapped by the current master key alongside it, as
+// v<version>:<wrapped key>:<ciphertext>.
+func (k Keyring) Seal(plaintext string) (string, error) {
+	masterKey, ok := k.Keys[k.Current]
+	if !ok {
+		return "", ErrUnknownKeyVersion
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+
+	header := fmt.Sprintf("v%d", k.Current)
+	wrapped, err := seal(masterKey, dataKey, []byte(header))
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		header,
+		base64.RawStdEncoding.EncodeToString(wrapped),
+		base64.RawStdEncoding.EncodeToString(ciphertext),
+	}, ":"), nil
+}
+
+// Open unwraps the data key of a sealed value with the master key it was
+// sealed with and decrypts the value.
+func (k Keyring) Open(sealed string) (string, error) {
+	version, ok := Version(sealed)
+	if !ok {
+		return "", ErrNotSealed
+	}
+
+	masterKey, ok := k.Keys[version]
+	if !ok {
+		return "", ErrUnknownKeyVersion
+	}
+
+	parts := strings.Split(sealed, ":")
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrNotSealed
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrNotSealed
+	}
+
+	dataKey, err := open(masterKey, wrapped, []byte(parts[0]))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Version returns the master key version a value was sealed with, and
+// false when the value isn't sealed at all.
+func Version(sealed string) (int, bool) {
+	parts := strings.Split(sealed, ":")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "v") {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(parts[0][1:])
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// seal encrypts with AES-GCM, prefixing the ciphertext with its nonce.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key, ciphertext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, ErrNotSealed
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidMasterKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
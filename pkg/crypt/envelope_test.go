@@ -0,0 +1,53 @@
+package crypt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestKeyring(t *testing.T) {
+	old := Keyring{Current: 1, Keys: map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}}
+	rotated := Keyring{Current: 2, Keys: map[int][]byte{
+		1: bytes.Repeat([]byte{1}, 32),
+		2: bytes.Repeat([]byte{2}, 32),
+	}}
+
+	sealed, err := old.Seal("seed words")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if strings.Contains(sealed, "seed words") {
+		t.Errorf("Seal() = %q leaks the plaintext", sealed)
+	}
+	if version, ok := Version(sealed); !ok || version != 1 {
+		t.Errorf("Version(%q) = %d, %v, want 1", sealed, version, ok)
+	}
+
+	// a rotated keyring still opens what older keys sealed
+	plaintext, err := rotated.Open(sealed)
+	if err != nil || plaintext != "seed words" {
+		t.Errorf("Open() = %q, %v, want the plaintext", plaintext, err)
+	}
+
+	resealed, err := rotated.Seal(plaintext)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if version, _ := Version(resealed); version != 2 {
+		t.Errorf("Version(resealed) = %d, want 2", version)
+	}
+	if _, err := old.Open(resealed); !errors.Is(err, ErrUnknownKeyVersion) {
+		t.Errorf("Open() with a missing key error = %v, want ErrUnknownKeyVersion", err)
+	}
+
+	tampered := strings.Replace(sealed, "v1:", "v2:", 1)
+	if _, err := rotated.Open(tampered); err == nil {
+		t.Error("Open() opened a value whose version was tampered with")
+	}
+
+	if _, ok := Version("plain private key"); ok {
+		t.Error("Version() took a plaintext for a sealed value")
+	}
+}
@@ -48,3 +48,9 @@ func GetUnAuthError(c *fiber.Ctx, message string) error {
 		"message": message,
 	})
 }
+
+func GetForbiddenError(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"message": message,
+	})
+}
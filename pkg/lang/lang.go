@@ -2,6 +2,7 @@ package lang
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc/metadata"
 )
@@ -31,3 +32,18 @@ func Get(ctx context.Context) Language {
 	}
 	return Language(headers.Get("grpcgateway-accept-language")[0])
 }
+
+// Parse picks the supported language an Accept-Language header prefers,
+// falling back to FA like Get does.
+func Parse(header string) Language {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.ToLower(strings.TrimSpace(strings.SplitN(tag, ";", 2)[0]))
+		switch {
+		case strings.HasPrefix(tag, "en"):
+			return EN
+		case strings.HasPrefix(tag, "fa"):
+			return FA
+		}
+	}
+	return FA
+}
@@ -0,0 +1,25 @@
+package money
+
+import "math"
+
+// Scale is the number of units in one talan. Amounts are counted in units
+// so that they add up and split exactly.
+const Scale = 100_000_000
+
+// Amount is a sum of money in units of 1/Scale talan.
+type Amount int64
+
+// FromFloat converts a talan amount to units, rounding to the nearest one.
+func FromFloat(f float64) Amount {
+	return Amount(math.Round(f * Scale))
+}
+
+// Float converts the amount back to talan, for the api and the chain.
+func (a Amount) Float() float64 {
+	return float64(a) / Scale
+}
+
+// Percent returns the given percent of the amount, rounded down to a unit.
+func (a Amount) Percent(percent float64) Amount {
+	return Amount(math.Floor(float64(a) * percent / 100))
+}
@@ -0,0 +1,21 @@
+package money
+
+import "testing"
+
+func TestFromFloat(t *testing.T) {
+	if got := FromFloat(0.1) + FromFloat(0.2); got != FromFloat(0.3) {
+		t.Errorf("FromFloat(0.1) + FromFloat(0.2) = %d, want %d", got, FromFloat(0.3))
+	}
+	if got := FromFloat(12.5).Float(); got != 12.5 {
+		t.Errorf("Float() = %v, want 12.5", got)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	if got := FromFloat(1000).Percent(10); got != FromFloat(100) {
+		t.Errorf("Percent() = %d, want %d", got, FromFloat(100))
+	}
+	if got := Amount(7).Percent(50); got != 3 {
+		t.Errorf("Percent() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,9 @@
+package royalty
+
+import "nft/config"
+
+// Valid reports whether creators may set the percent as the royalty of
+// their nfts and collections.
+func Valid(percent float64) bool {
+	return percent >= 0 && percent <= config.C().Settlement.MaxRoyaltyPercent
+}
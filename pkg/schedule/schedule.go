@@ -0,0 +1,55 @@
+// Package schedule runs periodic background jobs bound to the fx lifecycle
+package schedule
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// Every runs job on a fixed interval between application start and stop.
+// The interval is resolved on start, after configs are loaded. A failing
+// run is logged and retried on the next tick.
+func Every(lc fx.Lifecycle, name string, interval func() time.Duration, job func(c context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				ticker := time.NewTicker(interval())
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if err := job(ctx); err != nil {
+							log.Printf("%s failed: %v\n", name, err)
+						}
+					}
+				}
+			}()
+			log.Printf("%s started\n", name)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			log.Printf("%s stopped\n", name)
+			return nil
+		},
+	})
+}
+
+// Backoff returns how long a job waits after its nth failed attempt. The
+// wait doubles with every attempt, from base up to max.
+func Backoff(attempts int, base time.Duration, max time.Duration) time.Duration {
+	wait := base
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
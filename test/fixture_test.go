@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"nft/contract"
+	persist "nft/infra/persist/type"
+	collectionmodel "nft/internal/collection/model"
+	kycmodel "nft/internal/kyc/model"
+	ledgermodel "nft/internal/ledger/model"
+	nftmodel "nft/internal/nft/model"
+	offermodel "nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	talanmodel "nft/internal/talan/model"
+	usermodel "nft/internal/user/model"
+	"nft/pkg/money"
+
+	. "github.com/onsi/gomega"
+)
+
+// services are what the marketplace specs drive directly, below the http
+// api, to set up users, assets and sales and to race them.
+type services struct {
+	fx.In
+	DB                    contract.IPersist
+	UserRepository        contract.IUserRepository
+	KycRepository         contract.IKycRepository
+	LedgerService         contract.ILedgerService
+	LedgerRepository      contract.ILedgerRepository
+	FeeService            contract.IFeeService
+	NftRepository         contract.INftRepository
+	NftService            contract.INftService
+	CollectionService     contract.ICollectionService
+	CollectionRepository  contract.ICollectionRepository
+	SaleService           contract.ISaleService
+	SaleRepository        contract.ISaleRepository
+	OfferService          contract.IOfferService
+	OfferRepository       contract.IOfferRepository
+	TransferService       contract.ITransferService
+	TransferRepository    contract.ITransferRepository
+	EmailRepository       contract.IEmailRepository
+	TransactionService    contract.ITransactionService
+	TransactionRepository contract.ITransactionRepository
+}
+
+var svc services
+
+// newTrader adds a user with an approved kyc of the given tier and credits
+// it the given deposit.
+func newTrader(c context.Context, tier int, deposit float64) usermodel.User {
+	suffix := uuid.NewString()
+	user, err := svc.UserRepository.Add(c, usermodel.User{
+		FirstName:   "Trader",
+		LastName:    suffix[:8],
+		NationalId:  suffix,
+		Email:       fmt.Sprintf("%s@trader.test", suffix),
+		PhoneNumber: suffix,
+		PublicKey:   "TLN" + suffix,
+		Role:        usermodel.RoleUser,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	if tier > 0 {
+		_, err = svc.KycRepository.Add(c, kycmodel.Kyc{UserId: user.ID, Status: kycmodel.KycStatusApproved, Tier: tier})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	if deposit > 0 {
+		Expect(svc.LedgerService.CreditDeposit(c, user.ID, talanmodel.Transaction{
+			ID:            uuid.NewString(),
+			Amount:        deposit,
+			Confirmations: 100,
+			Type:          talanmodel.TransactionTypeReceive,
+		})).To(Succeed())
+	}
+
+	return user
+}
+
+// newNft adds an approved nft created by the user.
+func newNft(c context.Context, creator usermodel.User, royaltyPercent float64) nftmodel.Nft {
+	nft, err := svc.NftRepository.Add(c, nftmodel.Nft{
+		Title:          "nft " + uuid.NewString()[:8],
+		User:           creator,
+		Status:         nftmodel.NftStatusApproved,
+		RoyaltyPercent: royaltyPercent,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return nft
+}
+
+// newCollection adds a saved collection of the creator with the given
+// number of approved nfts in it.
+func newCollection(c context.Context, creator usermodel.User, royaltyPercent float64, size int) (collectionmodel.Collection, []nftmodel.Nft) {
+	collection, err := svc.CollectionRepository.Add(c, collectionmodel.Collection{
+		Title:          "collection " + uuid.NewString()[:8],
+		User:           creator,
+		Status:         collectionmodel.CollectionStatusSaved,
+		RoyaltyPercent: royaltyPercent,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	members := make([]nftmodel.Nft, size)
+	for i := range members {
+		members[i], err = svc.NftRepository.Add(c, nftmodel.Nft{
+			Title:        "member " + uuid.NewString()[:8],
+			User:         creator,
+			Status:       nftmodel.NftStatusApproved,
+			CollectionId: collection.ID,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	return collection, members
+}
+
+// listNft puts the nft owned by the seller on sale.
+func listNft(c context.Context, seller usermodel.User, nft nftmodel.Nft, saleType salemodel.Type, price float64) salemodel.Sale {
+	sale, err := svc.SaleService.CreateNftSale(c, salemodel.Sale{
+		User:     seller,
+		Nft:      &nftmodel.Nft{ID: nft.ID},
+		SaleType: saleType,
+		MinPrice: price,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return sale
+}
+
+// listCollection puts the collection owned by the seller on sale.
+func listCollection(c context.Context, seller usermodel.User, collection collectionmodel.Collection, saleType salemodel.Type, price float64) salemodel.Sale {
+	sale, err := svc.SaleService.CreateCollectionSale(c, salemodel.Sale{
+		User:       seller,
+		Collection: &collectionmodel.Collection{ID: collection.ID},
+		SaleType:   saleType,
+		MinPrice:   price,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return sale
+}
+
+// makeOffer makes an offer of the buyer to the sale and returns it. The
+// buyer must have no other active offer on the sale.
+func makeOffer(c context.Context, sale salemodel.Sale, buyer usermodel.User, price float64) offermodel.Offer {
+	Expect(svc.OfferService.MakeOfferToSale(c, offermodel.Offer{SaleId: *sale.ID, User: buyer, Price: price})).To(Succeed())
+
+	offers, err := svc.OfferRepository.GetAll(c, persist.D{"sale_id": *sale.ID, "user_id": buyer.ID, "rejected_at": nil})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(offers).To(HaveLen(1))
+	return offers[0]
+}
+
+// balanceOf returns the ledger balance of the user.
+func balanceOf(c context.Context, user usermodel.User) ledgermodel.Balance {
+	balance, err := svc.LedgerService.GetBalance(c, user.ID)
+	Expect(err).NotTo(HaveOccurred())
+	return balance
+}
+
+// ledgerBalance is the balance with the given available and held amounts.
+func ledgerBalance(available, held float64) ledgermodel.Balance {
+	return ledgermodel.Balance{Available: money.FromFloat(available), Held: money.FromFloat(held)}
+}
+
+// ownerOf returns the current owner of the nft.
+func ownerOf(c context.Context, nft nftmodel.Nft) uuid.UUID {
+	ownerId, err := svc.TransactionService.GetOwner(c, *nft.ID, nft.User.ID)
+	Expect(err).NotTo(HaveOccurred())
+	return ownerId
+}
@@ -109,8 +109,36 @@ var _ = Describe("Kyc Management", Ordered, func() {
 				Fail("unable to unmarshal kyc object")
 			}
 
-			By("status field should be equal to undefined")
-			Expect(kyc.Status).To(Equal(dto.KYCStatusUndefined))
+			By("status field should be equal to submitted")
+			Expect(kyc.Status).To(Equal(dto.KYCStatusSubmitted))
+		})
+	})
+
+	Describe("Assign kyc", func() {
+		It("should not approve kyc before it is in review", func() {
+			resp, err := client.R().
+				SetAuthToken(token).
+				Post(baseUrl + kycId.String() + "/approve")
+
+			if err != nil {
+				Fail(fmt.Sprintf("failed to make the request to approve kyc: %s", err.Error()), 1)
+			}
+
+			By("status code should be 400")
+			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should assign kyc successfully", func() {
+			resp, err := client.R().
+				SetAuthToken(token).
+				Post(baseUrl + kycId.String() + "/assign")
+
+			if err != nil {
+				Fail(fmt.Sprintf("failed to make the request to assign kyc: %s", err.Error()), 1)
+			}
+
+			By("status code should be 200")
+			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 		})
 	})
 
@@ -152,7 +180,7 @@ var _ = Describe("Kyc Management", Ordered, func() {
 	})
 
 	Describe("Reject kyc", func() {
-		It("should reject kyc successfully", func() {
+		It("should not reject an approved kyc", func() {
 			resp, err := client.R().
 				SetAuthToken(token).
 				SetBody(dto.RejectAppeal{Message: "some reason"}).
@@ -162,29 +190,32 @@ var _ = Describe("Kyc Management", Ordered, func() {
 				Fail(fmt.Sprintf("failed to make the request to reject kyc: %s", err.Error()), 1)
 			}
 
-			By("status code should be 200")
-			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+			By("status code should be 400")
+			Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
 		})
+	})
 
-		It("should get single kyc and check its status to be rejected", func() {
+	Describe("Get kyc decisions", func() {
+		It("should list every decision made on kyc", func() {
 			resp, err := client.R().
 				SetAuthToken(token).
-				Get(baseUrl + kycId.String())
+				Get(baseUrl + kycId.String() + "/decisions")
 
 			if err != nil {
-				Fail(fmt.Sprintf("failed to make the request to get kyc: %s", err.Error()))
+				Fail(fmt.Sprintf("failed to make the request to get kyc decisions: %s", err.Error()))
 			}
 
 			By("status code should be 200")
 			Expect(resp.StatusCode()).To(Equal(http.StatusOK))
 
-			var kyc dto.Kyc
-			if err = json.Unmarshal(resp.Body(), &kyc); err != nil {
-				Fail("unable to unmarshal kyc object")
+			var decisions dto.DecisionList
+			if err = json.Unmarshal(resp.Body(), &decisions); err != nil {
+				Fail("unable to unmarshal kyc decisions")
 			}
 
-			By("status field should be equal to rejected")
-			Expect(kyc.Status).To(Equal(dto.KYCStatusRejected))
+			By("decisions should be submission, review and approval")
+			Expect(len(decisions.Decisions)).To(Equal(3))
+			Expect(decisions.Decisions[2].To).To(Equal(string(dto.KYCStatusApproved)))
 		})
 	})
 })
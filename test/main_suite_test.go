@@ -3,31 +3,45 @@ package test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"log"
 	"nft/config"
 	"nft/contract"
+	apperrors "nft/error"
+	"nft/infra/eventbus"
+	"nft/infra/eventbus/memory"
 	"nft/infra/persist"
 	"nft/infra/server"
 	"nft/infra/storage"
+	"nft/infra/vault"
 	"nft/internal/auth"
 	authdto "nft/internal/auth/dto"
 	"nft/internal/card"
 	"nft/internal/category"
+	"nft/internal/chain"
 	"nft/internal/collection"
 	"nft/internal/email"
+	"nft/internal/fee"
+	"nft/internal/feed"
 	"nft/internal/file"
 	"nft/internal/jwt"
 	jwtmodel "nft/internal/jwt/model"
 	"nft/internal/kyc"
+	"nft/internal/ledger"
+	"nft/internal/limit"
 	"nft/internal/nft"
+	"nft/internal/notification"
 	"nft/internal/offer"
 	"nft/internal/otp"
+	"nft/internal/outbox"
 	"nft/internal/sale"
 	"nft/internal/talan"
 	"nft/internal/transaction"
+	"nft/internal/transfer"
 	"nft/internal/user"
+	"nft/internal/withdrawal"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -43,10 +57,14 @@ func TestTest(t *testing.T) {
 var token string
 
 var _ = BeforeSuite(func() {
+	var userService contract.IUserService
 	err := fx.New(
 		fx.Provide(persist.New),
 		fx.Provide(storage.New),
+		fx.Provide(vault.New),
 		fx.Provide(server.New),
+		fx.Provide(memory.New),
+		fx.Provide(fx.Annotated{Group: "outbox_handlers", Target: eventbus.NewOutboxHandler}),
 
 		auth.Module,
 		user.Module,
@@ -63,10 +81,21 @@ var _ = BeforeSuite(func() {
 		offer.Module,
 		sale.Module,
 		transaction.Module,
+		ledger.Module,
+		fee.Module,
+		limit.Module,
+		transfer.Module,
+		chain.Module,
+		withdrawal.Module,
+		outbox.Module,
+		notification.Module,
+		feed.Module,
 
 		fx.Invoke(initConfig),
+		fx.Invoke(fee.CheckConfigPolicy),
 		fx.Invoke(migrate),
 		fx.Invoke(serve),
+		fx.Populate(&userService, &svc),
 	).Start(context.Background())
 	if err != nil {
 		return
@@ -113,6 +142,12 @@ var _ = BeforeSuite(func() {
 		AbortSuite(fmt.Sprintf("failed to verify user email for testing categories: %s", err.Error()))
 	}
 
+	// the suite user reviews and manages what the other specs create
+	err = userService.BootstrapAdmin(context.Background(), signUpDto.Email)
+	if err != nil && !errors.Is(err, apperrors.ErrAdminExists) {
+		AbortSuite(fmt.Sprintf("failed to make test user admin: %s", err.Error()))
+	}
+
 	loginDto := authdto.LoginRequest{
 		Email:    "test@gmail.com",
 		Password: "ali1379",
@@ -0,0 +1,188 @@
+package test
+
+import (
+	"context"
+	"errors"
+	apperrors "nft/error"
+	"nft/infra/persist/type"
+	feemodel "nft/internal/fee/model"
+	ledgermodel "nft/internal/ledger/model"
+	offermodel "nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	usermodel "nft/internal/user/model"
+	"nft/pkg/money"
+	"sync"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Offer Settlement", func() {
+	c := context.Background()
+
+	Describe("accept an offer", func() {
+		It("should settle the sale with the offer", func() {
+			seller := newTrader(c, 1, 0)
+			buyer := newTrader(c, 1, 500)
+			nft := newNft(c, seller, 5)
+			sale := listNft(c, seller, nft, salemodel.SaleTypeP2P, 100)
+
+			offer := makeOffer(c, sale, buyer, 120)
+			By("the price of the offer should be held")
+			Expect(balanceOf(c, buyer).Held).To(Equal(money.FromFloat(120)))
+
+			Expect(svc.OfferService.AcceptOffer(c, offermodel.Offer{ID: offer.ID, User: seller})).To(Succeed())
+
+			By("the sale should be sold")
+			settled, err := svc.SaleRepository.Get(c, persist.D{"id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(settled.Status).To(Equal(salemodel.SaleStatusSold))
+
+			By("the offer should be accepted")
+			accepted, err := svc.OfferRepository.Get(c, persist.D{"id": *offer.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(accepted.Accepted).To(BeTrue())
+
+			By("the buyer should own the nft")
+			Expect(ownerOf(c, nft)).To(Equal(buyer.ID))
+
+			By("the buyer should have paid the price")
+			Expect(balanceOf(c, buyer)).To(Equal(ledgerBalance(380, 0)))
+
+			By("the seller should be paid the price less the fee")
+			fee, err := svc.FeeService.Quote(c, feemodel.Quote{
+				SaleType:  string(sale.SaleType),
+				AssetType: string(sale.AssetType),
+				Price:     120,
+				SellerId:  seller.ID,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balanceOf(c, seller).Available).To(Equal(money.FromFloat(120) - money.FromFloat(fee)))
+		})
+
+		It("should settle only one of two offers accepted at once", func() {
+			seller := newTrader(c, 1, 0)
+			first, second := newTrader(c, 1, 500), newTrader(c, 1, 500)
+			nft := newNft(c, seller, 0)
+			sale := listNft(c, seller, nft, salemodel.SaleTypeP2P, 100)
+
+			offers := []offermodel.Offer{makeOffer(c, sale, first, 110), makeOffer(c, sale, second, 120)}
+
+			errs := make([]error, len(offers))
+			var wg sync.WaitGroup
+			for i, offer := range offers {
+				wg.Add(1)
+				go func(i int, offer offermodel.Offer) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					errs[i] = svc.OfferService.AcceptOffer(c, offermodel.Offer{ID: offer.ID, User: seller})
+				}(i, offer)
+			}
+			wg.Wait()
+
+			By("one accept should win and the other find the sale closed")
+			winner := -1
+			for i, err := range errs {
+				if err == nil {
+					Expect(winner).To(Equal(-1))
+					winner = i
+					continue
+				}
+				Expect(errors.Is(err, apperrors.ErrSaleClosed) || errors.Is(err, apperrors.ErrOfferNotActive)).To(BeTrue())
+			}
+			Expect(winner).NotTo(Equal(-1))
+
+			By("the sale should be settled once")
+			txs, err := svc.TransactionRepository.GetAll(c, persist.D{"sale_id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txs).To(HaveLen(1))
+			Expect(txs[0].OfferId).To(Equal(*offers[winner].ID))
+
+			By("the losing buyer should get the hold back")
+			loser := []int{1, 0}[winner]
+			buyers := []usermodel.User{first, second}
+			Expect(balanceOf(c, buyers[loser])).To(Equal(ledgerBalance(500, 0)))
+			Expect(balanceOf(c, buyers[winner])).To(Equal(ledgerBalance(500-offers[winner].Price, 0)))
+		})
+	})
+
+	Describe("get a sold sale", func() {
+		It("should show the fee charged at settlement after the policy changes", func() {
+			seller := newTrader(c, 1, 0)
+			buyer := newTrader(c, 1, 500)
+			sale := listNft(c, seller, newNft(c, seller, 0), salemodel.SaleTypeP2P, 100)
+			offer := makeOffer(c, sale, buyer, 200)
+			Expect(svc.OfferService.AcceptOffer(c, offermodel.Offer{ID: offer.ID, User: seller})).To(Succeed())
+
+			charged := money.FromFloat(200) - balanceOf(c, seller).Available
+
+			By("raising every fee after the sale settled")
+			policy, err := svc.FeeService.GetPolicy(c)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				Expect(svc.FeeService.SetPolicy(c, policy, uuid.New())).To(Succeed())
+			})
+			raised := feemodel.Policy{Rules: []feemodel.Rule{{MinFee: 50, Tiers: []feemodel.Tier{{FromPrice: 0, Percent: 20}}}}}
+			Expect(svc.FeeService.SetPolicy(c, raised, uuid.New())).To(Succeed())
+
+			sold, err := svc.SaleService.GetSale(c, salemodel.Sale{ID: sale.ID, User: seller})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(money.FromFloat(sold.Fee)).To(Equal(charged))
+		})
+	})
+
+	Describe("buy a fixed price sale", func() {
+		It("should sell to only one of several buyers at once", func() {
+			seller := newTrader(c, 1, 0)
+			sale := listNft(c, seller, newNft(c, seller, 0), salemodel.SaleTypeFixedPrice, 100)
+
+			buyers := []usermodel.User{newTrader(c, 1, 300), newTrader(c, 1, 300), newTrader(c, 1, 300), newTrader(c, 1, 300)}
+			errs := make([]error, len(buyers))
+			var wg sync.WaitGroup
+			for i, buyer := range buyers {
+				wg.Add(1)
+				go func(i int, buyer usermodel.User) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					errs[i] = svc.SaleService.BuySale(c, salemodel.Sale{ID: sale.ID, User: buyer})
+				}(i, buyer)
+			}
+			wg.Wait()
+
+			By("one buy should win and the others find the sale closed")
+			winner := -1
+			for i, err := range errs {
+				if err == nil {
+					Expect(winner).To(Equal(-1))
+					winner = i
+					continue
+				}
+				Expect(errors.Is(err, apperrors.ErrSaleClosed)).To(BeTrue())
+			}
+			Expect(winner).NotTo(Equal(-1))
+
+			By("the sale should be settled once")
+			txs, err := svc.TransactionRepository.GetAll(c, persist.D{"sale_id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txs).To(HaveLen(1))
+			Expect(txs[0].BuyerId).To(Equal(buyers[winner].ID))
+
+			By("only the hold of the winner should be captured")
+			offers, err := svc.OfferRepository.GetAll(c, persist.D{"sale_id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offers).To(HaveLen(1))
+			hold, err := svc.LedgerRepository.GetHold(c, persist.D{"offer_id": *offers[0].ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hold.Status).To(Equal(ledgermodel.HoldStatusCaptured))
+
+			for i, buyer := range buyers {
+				if i == winner {
+					Expect(balanceOf(c, buyer)).To(Equal(ledgerBalance(200, 0)))
+				} else {
+					Expect(balanceOf(c, buyer)).To(Equal(ledgerBalance(300, 0)))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,194 @@
+package test
+
+import (
+	"context"
+	apperrors "nft/error"
+	"nft/infra/persist/type"
+	collectionmodel "nft/internal/collection/model"
+	nftmodel "nft/internal/nft/model"
+	offermodel "nft/internal/offer/model"
+	salemodel "nft/internal/sale/model"
+	usermodel "nft/internal/user/model"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sale Expiration", func() {
+	c := context.Background()
+
+	// expire moves the end of the sale into the past
+	expire := func(sale salemodel.Sale) {
+		Expect(svc.SaleRepository.Extend(c, salemodel.Sale{ID: sale.ID, Expiration: time.Now().Add(-time.Minute)})).To(Succeed())
+	}
+
+	Describe("expire sales", func() {
+		It("should expire a sale past its expiration and void its offers", func() {
+			seller, buyer := newTrader(c, 1, 0), newTrader(c, 1, 200)
+			sale := listNft(c, seller, newNft(c, seller, 0), salemodel.SaleTypeP2P, 100)
+			offer := makeOffer(c, sale, buyer, 150)
+
+			expire(sale)
+			Expect(svc.SaleService.ExpireSales(c)).To(Succeed())
+
+			By("the sale should be expired")
+			expired, err := svc.SaleRepository.Get(c, persist.D{"id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expired.Status).To(Equal(salemodel.SaleStatusExpired))
+
+			By("the offer should be rejected and its hold released")
+			rejected, err := svc.OfferRepository.Get(c, persist.D{"id": *offer.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rejected.RejectedAt).NotTo(BeNil())
+			Expect(balanceOf(c, buyer)).To(Equal(ledgerBalance(200, 0)))
+		})
+
+		It("should leave sales that haven't expired and auctions alone", func() {
+			seller := newTrader(c, 1, 0)
+			open := listNft(c, seller, newNft(c, seller, 0), salemodel.SaleTypeP2P, 100)
+			auction := listNft(c, seller, newNft(c, seller, 0), salemodel.SaleTypeAuction, 100)
+
+			expire(auction)
+			Expect(svc.SaleService.ExpireSales(c)).To(Succeed())
+
+			for _, sale := range []salemodel.Sale{open, auction} {
+				got, err := svc.SaleRepository.Get(c, persist.D{"id": *sale.ID})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(got.Status).To(Equal(salemodel.SaleStatusInProgress))
+			}
+		})
+	})
+
+	Describe("list an nft", func() {
+		It("should not list an nft that is already listed", func() {
+			seller := newTrader(c, 1, 0)
+			nft := newNft(c, seller, 0)
+			listNft(c, seller, nft, salemodel.SaleTypeP2P, 100)
+
+			_, err := svc.SaleService.CreateNftSale(c, salemodel.Sale{
+				User:     seller,
+				Nft:      &nftmodel.Nft{ID: nft.ID},
+				SaleType: salemodel.SaleTypeFixedPrice,
+				MinPrice: 120,
+			})
+			Expect(err).To(MatchError(apperrors.ErrAssetListed))
+		})
+	})
+})
+
+var _ = Describe("Collection Settlement", func() {
+	c := context.Background()
+
+	Describe("settle a collection sale", func() {
+		It("should move every member to the buyer under one settlement", func() {
+			seller, buyer := newTrader(c, 1, 0), newTrader(c, 1, 500)
+			collection, members := newCollection(c, seller, 5, 3)
+			sale := listCollection(c, seller, collection, salemodel.SaleTypeP2P, 100)
+			offer := makeOffer(c, sale, buyer, 150)
+
+			Expect(svc.OfferService.AcceptOffer(c, offermodel.Offer{ID: offer.ID, User: seller})).To(Succeed())
+
+			By("the buyer should own every member")
+			for _, member := range members {
+				Expect(ownerOf(c, member)).To(Equal(buyer.ID))
+			}
+
+			By("the collection and its members should share one settlement")
+			txs, err := svc.TransactionRepository.GetAll(c, persist.D{"sale_id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txs).To(HaveLen(len(members) + 1))
+			for _, tx := range txs {
+				Expect(tx.SettlementId).To(Equal(txs[0].SettlementId))
+				Expect(tx.BuyerId).To(Equal(buyer.ID))
+			}
+		})
+
+		It("should roll the whole settlement back when a step of it fails", func() {
+			seller, buyer := newTrader(c, 1, 0), newTrader(c, 1, 500)
+			collection, members := newCollection(c, seller, 0, 3)
+			sale := listCollection(c, seller, collection, salemodel.SaleTypeP2P, 100)
+			offer := makeOffer(c, sale, buyer, 150)
+
+			// capturing the hold fails after the members were moved
+			Expect(svc.LedgerService.ReleaseHold(c, *offer.ID)).To(Succeed())
+			Expect(svc.OfferService.AcceptOffer(c, offermodel.Offer{ID: offer.ID, User: seller})).NotTo(Succeed())
+
+			By("no member should have moved")
+			for _, member := range members {
+				Expect(ownerOf(c, member)).To(Equal(seller.ID))
+			}
+			txs, err := svc.TransactionRepository.GetAll(c, persist.D{"sale_id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txs).To(BeEmpty())
+
+			By("the sale and the offer should be untouched")
+			got, err := svc.SaleRepository.Get(c, persist.D{"id": *sale.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Status).To(Equal(salemodel.SaleStatusInProgress))
+			pending, err := svc.OfferRepository.Get(c, persist.D{"id": *offer.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending.Accepted).To(BeFalse())
+		})
+	})
+
+	Describe("list a collection", func() {
+		It("should not both list a collection and move a listed nft into it", func() {
+			seller := newTrader(c, 1, 0)
+			collection, _ := newCollection(c, seller, 0, 2)
+			nft := newNft(c, seller, 0)
+			listNft(c, seller, nft, salemodel.SaleTypeP2P, 100)
+
+			var listErr, moveErr error
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, listErr = svc.SaleService.CreateCollectionSale(c, salemodel.Sale{
+					User:       seller,
+					Collection: &collectionmodel.Collection{ID: collection.ID},
+					SaleType:   salemodel.SaleTypeP2P,
+					MinPrice:   100,
+				})
+			}()
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				moveErr = svc.NftService.SetCollection(c, nftmodel.Nft{ID: nft.ID, CurrentOwner: &seller, CollectionId: collection.ID})
+			}()
+			wg.Wait()
+
+			By("either the listing or the move should win, not both")
+			if listErr == nil {
+				Expect(moveErr).To(MatchError(apperrors.ErrCollectionOnSale))
+			} else {
+				Expect(listErr).To(MatchError(apperrors.ErrNftListedSeparately))
+				Expect(moveErr).NotTo(HaveOccurred())
+			}
+		})
+	})
+
+	Describe("get an owned collection", func() {
+		It("should follow the collection through a resale", func() {
+			creator, first, second := newTrader(c, 1, 0), newTrader(c, 1, 500), newTrader(c, 1, 500)
+			collection, _ := newCollection(c, creator, 5, 2)
+
+			sale := listCollection(c, creator, collection, salemodel.SaleTypeFixedPrice, 100)
+			Expect(svc.SaleService.BuySale(c, salemodel.Sale{ID: sale.ID, User: first})).To(Succeed())
+
+			resale := listCollection(c, first, collection, salemodel.SaleTypeFixedPrice, 200)
+			Expect(svc.SaleService.BuySale(c, salemodel.Sale{ID: resale.ID, User: second})).To(Succeed())
+
+			owned, err := svc.CollectionService.GetOwnedCollection(c, collectionmodel.Collection{ID: collection.ID, User: second})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*owned.ID).To(Equal(*collection.ID))
+
+			for _, former := range []usermodel.User{creator, first} {
+				_, err := svc.CollectionService.GetOwnedCollection(c, collectionmodel.Collection{ID: collection.ID, User: former})
+				Expect(err).To(MatchError(apperrors.ErrCollectionNotFound))
+			}
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	apperrors "nft/error"
+	"nft/infra/persist/type"
+	salemodel "nft/internal/sale/model"
+	transfermodel "nft/internal/transfer/model"
+	usermodel "nft/internal/user/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nft Transfer", func() {
+	c := context.Background()
+
+	var sender, recipient usermodel.User
+
+	BeforeEach(func() {
+		sender, recipient = newTrader(c, 1, 0), newTrader(c, 0, 0)
+	})
+
+	// statusOf reads the status of the transfer back
+	statusOf := func(transfer transfermodel.Transfer) transfermodel.Status {
+		transfers, err := svc.TransferRepository.Find(c, persist.Query{Conditions: persist.D{"id": *transfer.ID}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transfers).To(HaveLen(1))
+		return transfers[0].Status
+	}
+
+	transfer := func(nftId uuid.UUID, to transfermodel.Recipient, requireAcceptance bool) (transfermodel.Transfer, error) {
+		return svc.TransferService.Transfer(c, transfermodel.Transfer{NftId: nftId, Sender: sender}, to, requireAcceptance)
+	}
+
+	Describe("transfer right away", func() {
+		It("should move the nft to the recipient", func() {
+			nft := newNft(c, sender, 0)
+
+			sent, err := transfer(*nft.ID, transfermodel.Recipient{UserId: recipient.ID}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statusOf(sent)).To(Equal(transfermodel.StatusAccepted))
+			Expect(ownerOf(c, nft)).To(Equal(recipient.ID))
+		})
+	})
+
+	Describe("transfer once accepted", func() {
+		It("should move the nft when the recipient accepts", func() {
+			nft := newNft(c, sender, 0)
+
+			sent, err := transfer(*nft.ID, transfermodel.Recipient{UserId: recipient.ID}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusOf(sent)).To(Equal(transfermodel.StatusPending))
+			Expect(ownerOf(c, nft)).To(Equal(sender.ID))
+
+			Expect(svc.TransferService.Accept(c, transfermodel.Transfer{ID: sent.ID, Recipient: recipient})).To(Succeed())
+
+			Expect(statusOf(sent)).To(Equal(transfermodel.StatusAccepted))
+			Expect(ownerOf(c, nft)).To(Equal(recipient.ID))
+		})
+
+		It("should keep the nft with the sender when the recipient declines", func() {
+			nft := newNft(c, sender, 0)
+
+			sent, err := transfer(*nft.ID, transfermodel.Recipient{UserId: recipient.ID}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(svc.TransferService.Decline(c, transfermodel.Transfer{ID: sent.ID, Recipient: recipient})).To(Succeed())
+
+			Expect(statusOf(sent)).To(Equal(transfermodel.StatusDeclined))
+			Expect(ownerOf(c, nft)).To(Equal(sender.ID))
+		})
+
+		It("should not let the recipient accept a transfer the sender canceled", func() {
+			nft := newNft(c, sender, 0)
+
+			sent, err := transfer(*nft.ID, transfermodel.Recipient{UserId: recipient.ID}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(svc.TransferService.Cancel(c, transfermodel.Transfer{ID: sent.ID, Sender: sender})).To(Succeed())
+			Expect(statusOf(sent)).To(Equal(transfermodel.StatusCanceled))
+
+			err = svc.TransferService.Accept(c, transfermodel.Transfer{ID: sent.ID, Recipient: recipient})
+			Expect(err).To(MatchError(apperrors.ErrTransferClosed))
+			Expect(ownerOf(c, nft)).To(Equal(sender.ID))
+		})
+	})
+
+	Describe("transfer a listed nft", func() {
+		It("should not transfer an nft listed on its own", func() {
+			nft := newNft(c, sender, 0)
+			listNft(c, sender, nft, salemodel.SaleTypeP2P, 100)
+
+			_, err := transfer(*nft.ID, transfermodel.Recipient{UserId: recipient.ID}, false)
+			Expect(err).To(MatchError(apperrors.ErrNftOnSale))
+		})
+
+		It("should not transfer an nft listed with its collection", func() {
+			collection, members := newCollection(c, sender, 0, 2)
+			listCollection(c, sender, collection, salemodel.SaleTypeP2P, 100)
+
+			_, err := transfer(*members[0].ID, transfermodel.Recipient{UserId: recipient.ID}, false)
+			Expect(err).To(MatchError(apperrors.ErrNftOnSale))
+		})
+	})
+
+	Describe("resolve the recipient", func() {
+		It("should not resolve a recipient by an unverified email", func() {
+			nft := newNft(c, sender, 0)
+			email := fmt.Sprintf("%s@unverified.test", uuid.NewString())
+			_, err := svc.EmailRepository.Add(c, recipient.ID, email)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = transfer(*nft.ID, transfermodel.Recipient{Email: email}, false)
+			Expect(err).To(MatchError(apperrors.ErrRecipientNotFound))
+			Expect(ownerOf(c, nft)).To(Equal(sender.ID))
+		})
+
+		It("should not transfer an nft to its owner", func() {
+			nft := newNft(c, sender, 0)
+
+			_, err := transfer(*nft.ID, transfermodel.Recipient{UserId: sender.ID}, false)
+			Expect(err).To(MatchError(apperrors.ErrTransferToSelf))
+		})
+	})
+})
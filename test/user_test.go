@@ -35,12 +35,13 @@ var _ = Describe("User Management", Ordered, func() {
 	client := resty.New()
 
 	BeforeAll(func() {
-		baseUrl = fmt.Sprintf("http://%s:%s/v1/user/", config.C().App.Http.Host, config.C().App.Http.Port)
+		baseUrl = fmt.Sprintf("http://%s:%s/v1/admin/user/", config.C().App.Http.Host, config.C().App.Http.Port)
 	})
 
 	Describe("add new user", func() {
 		It("should add new user successfully", func() {
 			resp, err := client.R().
+				SetAuthToken(token).
 				SetBody(user).
 				Post(baseUrl)
 			Expect(err).NotTo(HaveOccurred())
@@ -54,6 +55,7 @@ var _ = Describe("User Management", Ordered, func() {
 		It("should get users list successfully", func() {
 
 			resp, err := client.R().
+				SetAuthToken(token).
 				Get(baseUrl)
 			if err != nil {
 				Fail(fmt.Sprintf("unable to make request to get user list: %s", err.Error()), 3)
@@ -73,6 +75,7 @@ var _ = Describe("User Management", Ordered, func() {
 		It("should get single user successfully", func() {
 
 			resp, err := client.R().
+				SetAuthToken(token).
 				Get(baseUrl + userList.Users[0].ID)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -96,6 +99,7 @@ var _ = Describe("User Management", Ordered, func() {
 			userDetails.FirstName = generatedName
 
 			resp, err := client.R().
+				SetAuthToken(token).
 				SetBody(userDetails).
 				Patch(baseUrl + userDetails.ID)
 			Expect(err).NotTo(HaveOccurred())
@@ -116,6 +120,7 @@ var _ = Describe("User Management", Ordered, func() {
 		It("should delete user successfully", func() {
 
 			resp, err := client.R().
+				SetAuthToken(token).
 				Delete(baseUrl + userList.Users[0].ID)
 			Expect(err).NotTo(HaveOccurred())
 